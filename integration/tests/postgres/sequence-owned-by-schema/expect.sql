@@ -0,0 +1 @@
+create sequence "billing"."invoice_number";
@@ -0,0 +1,5 @@
+CREATE SCHEMA billing;
+
+CREATE TABLE public.invoices (
+  id SERIAL PRIMARY KEY
+);
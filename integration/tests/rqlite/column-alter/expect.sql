@@ -1,4 +1,5 @@
-alter table "users" rename to "users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee";
-create table "users" ("id" integer not null, "name" text, "age" integer, primary key ("id"));
-insert into users (id, name, age) select id, name, age from users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee;
-drop table users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee;
+create table "users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee" ("id" integer not null, "name" text, "age" integer, primary key ("id"));
+/* schemahero:warning the data in column age of table users is converted from REAL to integer */
+insert into "users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee" ("id", "name", "age") select "id", "name", "age" from "users";
+/* schemahero:rebuild */ drop table "users";
+alter table "users_b2c1a24f693cd984c516dc5a970c88b6ea2b08b29dd73c45ea98111c7ea82eee" rename to "users";
@@ -1,4 +1,5 @@
-alter table "users" rename to "users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6";
-create table "users" ("id" integer not null, primary key ("id"));
-insert into users (id) select id from users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6;
-drop table users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6;
+create table "users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6" ("id" integer not null, primary key ("id"));
+/* schemahero:warning the data in column email of table users is dropped */
+insert into "users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6" ("id") select "id" from "users";
+/* schemahero:rebuild */ drop table "users";
+alter table "users_662b1fbe4ce8b8e73cc961d3400d5dbcce5105681d2d5857568bfab4fdafb6b6" rename to "users";
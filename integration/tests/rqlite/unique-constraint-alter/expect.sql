@@ -1,5 +1,5 @@
-alter table "projects" rename to "projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75";
-create table "projects" ("id" integer not null, "email" text not null, primary key ("id"));
+create table "projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75" ("id" integer not null, "email" text not null, primary key ("id"));
+insert into "projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75" ("id", "email") select "id", "email" from "projects";
+/* schemahero:rebuild */ drop table "projects";
+alter table "projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75" rename to "projects";
 create unique index idx_projects_email on projects (email);
-insert into projects (id, email) select id, email from projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75;
-drop table projects_0342a1ab69fe0887ec4f83d0a5c1a3678e4b4e2c9d631b82549f55cbaea90b75;
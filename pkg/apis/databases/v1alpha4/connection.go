@@ -211,6 +211,11 @@ func (d *Database) getConnectionFromParams(ctx context.Context) (string, string,
 			protocol = "http"
 		}
 		uri = fmt.Sprintf("%s://%s:%s@%s:%s/", protocol, user, password, hostname, port)
+
+		queryParams, err = d.rqliteQueryParameters(ctx, driver)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to compute query string parameters")
+		}
 	} else if driver == "timescaledb" {
 		hostname, err := d.getValueFromValueOrValueFrom(ctx, driver, &d.Spec.Connection.TimescaleDB.Host)
 		if err != nil {
@@ -284,7 +289,47 @@ func (d *Database) getConnectionFromURI(ctx context.Context) (string, string, er
 	}
 
 	value, err := d.getValueFromValueOrValueFrom(ctx, driver, valueOrValueFrom)
-	return driver, value, err
+	if err != nil {
+		return driver, value, err
+	}
+
+	if driver == "rqlite" {
+		queryParams, err := d.rqliteQueryParameters(ctx, driver)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to compute query string parameters")
+		}
+		if len(queryParams) > 0 {
+			separator := "?"
+			if strings.Contains(value, "?") {
+				separator = "&"
+			}
+			value = fmt.Sprintf("%s%s%s", value, separator, strings.Join(queryParams, "&"))
+		}
+	}
+
+	return driver, value, nil
+}
+
+// rqliteQueryParameters returns the query string parameters of the rqlite connection options. The plugin reads them
+// from the uri, level is the consistency level parameter of gorqlite
+func (d *Database) rqliteQueryParameters(ctx context.Context, driver string) ([]string, error) {
+	rqlite := d.Spec.Connection.RQLite
+	queryParams := []string{}
+
+	if rqlite.ReadConsistency != "" {
+		queryParams = append(queryParams, fmt.Sprintf("level=%s", rqlite.ReadConsistency))
+	}
+	if rqlite.QueuedSeedData {
+		queryParams = append(queryParams, "queuedSeedData=true")
+	}
+	if rqlite.TLSCACertFile != "" {
+		queryParams = append(queryParams, fmt.Sprintf("tlsCACertFile=%s", url.QueryEscape(rqlite.TLSCACertFile)))
+	}
+	if rqlite.TLSInsecureSkipVerify {
+		queryParams = append(queryParams, "tlsInsecureSkipVerify=true")
+	}
+
+	return queryParams, nil
 }
 
 func (d *Database) addQueryStringParameter(ctx context.Context, driver string, queryParams *[]string, label string, key string, valueOrValueFrom *ValueOrValueFrom) error {
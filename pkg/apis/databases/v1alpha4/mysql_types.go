@@ -29,4 +29,14 @@ type MysqlConnection struct {
 
 	DefaultCharset string `json:"defaultCharset,omitempty"`
 	Collation      string `json:"collation,omitempty"`
+
+	// OnlineDDL is the default online DDL policy for tables that do not set their own
+	OnlineDDL *MysqlOnlineDDL `json:"onlineDDL,omitempty"`
+}
+
+type MysqlOnlineDDL struct {
+	// +kubebuilder:validation:Enum=INSTANT;INPLACE;COPY
+	Algorithm string `json:"algorithm,omitempty"`
+	// +kubebuilder:validation:Enum=NONE;SHARED;EXCLUSIVE
+	Lock string `json:"lock,omitempty"`
 }
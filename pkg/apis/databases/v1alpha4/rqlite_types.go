@@ -26,4 +26,16 @@ type RqliteConnection struct {
 
 	// +kubebuilder:validation:Optional
 	DisableTLS bool `json:"disableTLS"`
+	// TLSCACertFile is the path of the PEM encoded certificate authority that the server certificate is verified
+	// with, usually a Secret mounted into the SchemaHero pods
+	TLSCACertFile string `json:"tlsCACertFile,omitempty"`
+	// TLSInsecureSkipVerify does not verify the server certificate
+	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+
+	// ReadConsistency is the consistency level of the reads that plan the changes. Weak reads are served by the
+	// leader but may be stale while the leadership changes, none reads may be served stale by any node
+	// +kubebuilder:validation:Enum=none;weak;linearizable;strong
+	ReadConsistency string `json:"readConsistency,omitempty"`
+	// QueuedSeedData writes the seed data with queued writes, which rqlite acknowledges before they are applied
+	QueuedSeedData bool `json:"queuedSeedData,omitempty"`
 }
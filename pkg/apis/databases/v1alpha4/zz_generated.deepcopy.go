@@ -245,6 +245,11 @@ func (in *MysqlConnection) DeepCopyInto(out *MysqlConnection) {
 	in.User.DeepCopyInto(&out.User)
 	in.Password.DeepCopyInto(&out.Password)
 	in.DBName.DeepCopyInto(&out.DBName)
+	if in.OnlineDDL != nil {
+		in, out := &in.OnlineDDL, &out.OnlineDDL
+		*out = new(MysqlOnlineDDL)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlConnection.
@@ -257,6 +262,21 @@ func (in *MysqlConnection) DeepCopy() *MysqlConnection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlOnlineDDL) DeepCopyInto(out *MysqlOnlineDDL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlOnlineDDL.
+func (in *MysqlOnlineDDL) DeepCopy() *MysqlOnlineDDL {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlOnlineDDL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresConnection) DeepCopyInto(out *PostgresConnection) {
 	*out = *in
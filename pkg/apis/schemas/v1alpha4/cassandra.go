@@ -42,20 +42,77 @@ type CassandraTableProperties struct {
 	MinIndexInterval        *int              `json:"minIndexInterval,omitempty" yaml:"minIndexInterval,omitempty"`
 	ReadRepairChance        string            `json:"readRepairChance,omitempty" yaml:"readRepairChance,omitempty"`
 	SpeculativeRetry        string            `json:"speculativeRetry,omitempty" yaml:"speculativeRetry,omitempty"`
+	// CDC enables change data capture on the table
+	CDC *bool `json:"cdc,omitempty" yaml:"cdc,omitempty"`
+	// AdditionalWritePolicy is when to write to an additional replica, such as 99p, 10ms, ALWAYS or NEVER
+	AdditionalWritePolicy string `json:"additionalWritePolicy,omitempty" yaml:"additionalWritePolicy,omitempty"`
+	// ReadRepair is BLOCKING or NONE
+	// +kubebuilder:validation:Enum=BLOCKING;NONE
+	ReadRepair string `json:"readRepair,omitempty" yaml:"readRepair,omitempty"`
+}
+
+type CassandraTableIndex struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Column is the indexed column, collections can be indexed with keys(column), values(column), entries(column) or full(column)
+	Column string `json:"column" yaml:"column"`
+	// Type is SAI or SASI for those index implementations, or CUSTOM to use the class. Regular secondary indexes are the default.
+	// +kubebuilder:validation:Enum=SAI;SASI;CUSTOM
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Class is the index class of a CUSTOM index
+	Class   string            `json:"class,omitempty" yaml:"class,omitempty"`
+	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
 }
 
 type CassandraTableSchema struct {
-	IsDeleted       bool                      `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
-	PrimaryKey      [][]string                `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
-	ClusteringOrder *CassandraClusteringOrder `json:"clusteringOrder,omitempty" yaml:"clusteringOrder,omitempty"`
-	Columns         []*CassandraColumn        `json:"columns,omitempty" yaml:"columns,omitempty"`
+	IsDeleted  bool       `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+	PrimaryKey [][]string `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
+	// ClusteringOrder is the order of the clustering columns, in the order they appear in the primary key
+	ClusteringOrder []*CassandraClusteringOrder `json:"clusteringOrder,omitempty" yaml:"clusteringOrder,omitempty"`
+	Columns         []*CassandraColumn          `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Indexes are not managed when they are not set, when set any other index on the table is dropped
+	Indexes []*CassandraTableIndex `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+
+	Properties *CassandraTableProperties `json:"properties,omitempty" yaml:"properties,omitempty"`
+
+	// PrimaryKeyChange opts the table in to being recreated when its primary key changes, planning fails otherwise
+	PrimaryKeyChange *CassandraPrimaryKeyChange `json:"primaryKeyChange,omitempty" yaml:"primaryKeyChange,omitempty"`
+}
+
+// CassandraPrimaryKeyChange recreates a table when its partition key or clustering columns change, which
+// cassandra cannot alter. The rows are copied into a new table, the table is dropped and created with the
+// new primary key, and the rows are copied back. Writes made while the rows are copied are lost, so writes
+// to the table should be stopped while the migration runs.
+type CassandraPrimaryKeyChange struct {
+	// Destructive must be true to recreate the table. Cassandra cannot rename a table, so the table is dropped
+	// before the rows are copied back from the copy, and writes made while the rows are copied are lost
+	Destructive bool `json:"destructive,omitempty" yaml:"destructive,omitempty"`
+	// ChunkSize is the number of rows read at a time, defaults to 1000
+	ChunkSize int `json:"chunkSize,omitempty" yaml:"chunkSize,omitempty"`
+	// ThrottleMilliseconds is the pause between chunks, defaults to 100
+	ThrottleMilliseconds int `json:"throttleMilliseconds,omitempty" yaml:"throttleMilliseconds,omitempty"`
+}
+
+// CassandraViewSchema is a materialized view
+type CassandraViewSchema struct {
+	// Table is the base table of the view
+	Table string `json:"table" yaml:"table"`
+	// Columns are the selected columns, all columns are selected when there are none
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Where restricts the rows of the view, primary key columns are restricted to not null without being listed
+	Where           string                      `json:"where,omitempty" yaml:"where,omitempty"`
+	PrimaryKey      [][]string                  `json:"primaryKey" yaml:"primaryKey"`
+	ClusteringOrder []*CassandraClusteringOrder `json:"clusteringOrder,omitempty" yaml:"clusteringOrder,omitempty"`
 
 	Properties *CassandraTableProperties `json:"properties,omitempty" yaml:"properties,omitempty"`
+
+	IsDeleted bool `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 }
 
 type CassandraField struct {
 	Name string `json:"name" yaml:"name"`
 	Type string `json:"type" yaml:"type"`
+	// RenamedFrom is the previous name of the field, an existing field with that name is renamed
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type CassandraDataTypeSchema struct {
@@ -102,6 +159,23 @@ func (p *CassandraTableProperties) UnmarshalYAML(unmarshal func(interface{}) err
 			p.SpeculativeRetry = str
 		}
 	}
+	if v, ok := raw["additionalWritePolicy"]; ok {
+		if str, ok := v.(string); ok {
+			p.AdditionalWritePolicy = str
+		}
+	}
+	if v, ok := raw["readRepair"]; ok {
+		if str, ok := v.(string); ok {
+			p.ReadRepair = str
+		}
+	}
+
+	// Handle boolean fields
+	if v, ok := raw["cdc"]; ok {
+		if b, ok := v.(bool); ok {
+			p.CDC = &b
+		}
+	}
 
 	// Handle map fields
 	if v, ok := raw["caching"]; ok {
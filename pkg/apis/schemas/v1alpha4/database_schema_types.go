@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The SchemaHero Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DatabaseSchemaSpec struct {
+	Database string `json:"database" yaml:"database"`
+	Name     string `json:"name" yaml:"name"`
+
+	Postgres  *PostgresqlDatabaseSchema `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	Cassandra *CassandraKeyspaceSchema  `json:"cassandra,omitempty" yaml:"cassandra,omitempty"`
+}
+
+type PostgresqlDatabaseSchema struct {
+	Owner   string  `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	IsDeleted bool `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+}
+
+// CassandraKeyspaceSchema is a keyspace, the name of the schema is the name of the keyspace
+type CassandraKeyspaceSchema struct {
+	Replication CassandraKeyspaceReplication `json:"replication" yaml:"replication"`
+	// DurableWrites defaults to true when it is not set
+	DurableWrites *bool `json:"durableWrites,omitempty" yaml:"durableWrites,omitempty"`
+
+	IsDeleted bool `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+}
+
+type CassandraKeyspaceReplication struct {
+	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
+	Class string `json:"class" yaml:"class"`
+	// ReplicationFactor is the number of replicas with SimpleStrategy
+	ReplicationFactor int `json:"replicationFactor,omitempty" yaml:"replicationFactor,omitempty"`
+	// DataCenters is the number of replicas in each data center with NetworkTopologyStrategy
+	DataCenters map[string]int `json:"dataCenters,omitempty" yaml:"dataCenters,omitempty"`
+}
+
+type DatabaseSchemaStatus struct {
+	// The SHA of the spec from the last time a plan was executed, so that objects that
+	// have been planned are not planned again on startup
+	LastPlannedDatabaseSchemaSpecSHA string `json:"lastPlannedDatabaseSchemaSpecSHA,omitempty" yaml:"lastPlannedDatabaseSchemaSpecSHA,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DatabaseSchema is the Schema for the databaseschemas API
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+type DatabaseSchema struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseSchemaSpec   `json:"spec,omitempty"`
+	Status DatabaseSchemaStatus `json:"status,omitempty"`
+}
+
+func (d DatabaseSchema) GetSHA() (string, error) {
+	// ignoring the status, json marshal the spec and the metadata
+	o := struct {
+		Spec DatabaseSchemaSpec `json:"spec,omitempty"`
+	}{
+		Spec: d.Spec,
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal")
+	}
+
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DatabaseSchemaList contains a list of DatabaseSchema
+type DatabaseSchemaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseSchema `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseSchema{}, &DatabaseSchemaList{})
+}
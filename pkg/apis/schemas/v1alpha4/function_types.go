@@ -24,7 +24,7 @@ type NotImplementedFunctionSchema struct{}
 
 type FunctionSchema struct {
 	Postgres    *PostgresqlFunctionSchema     `json:"postgres,omitempty" yaml:"postgres,omitempty"`
-	Mysql       *NotImplementedFunctionSchema `json:"mysql,omitempty" yaml:"mysql,omitempty"`
+	Mysql       *MysqlFunctionSchema          `json:"mysql,omitempty" yaml:"mysql,omitempty"`
 	CockroachDB *NotImplementedFunctionSchema `json:"cockroachdb,omitempty" yaml:"cockroachdb,omitempty"`
 	RQLite      *NotImplementedFunctionSchema `json:"rqlite,omitempty" yaml:"rqlite,omitempty"`
 	SQLite      *NotImplementedFunctionSchema `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
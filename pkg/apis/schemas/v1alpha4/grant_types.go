@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The SchemaHero Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrantObject identifies the database object that privileges are granted on
+type GrantObject struct {
+	// +kubebuilder:validation:Enum=table;view;schema;sequence;function
+	Type   string `json:"type" yaml:"type"`
+	Schema string `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Name   string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type PostgresqlGrantSchema struct {
+	Role            string      `json:"role" yaml:"role"`
+	Object          GrantObject `json:"object" yaml:"object"`
+	Privileges      []string    `json:"privileges" yaml:"privileges"`
+	WithGrantOption bool        `json:"withGrantOption,omitempty" yaml:"withGrantOption,omitempty"`
+	IsDeleted       bool        `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+}
+
+type MysqlGrantSchema struct {
+	User            string      `json:"user" yaml:"user"`
+	Host            string      `json:"host,omitempty" yaml:"host,omitempty"`
+	Object          GrantObject `json:"object" yaml:"object"`
+	Privileges      []string    `json:"privileges" yaml:"privileges"`
+	WithGrantOption bool        `json:"withGrantOption,omitempty" yaml:"withGrantOption,omitempty"`
+	IsDeleted       bool        `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+}
+
+type NotImplementedGrantSchema struct {
+}
+
+type GrantSchema struct {
+	Postgres    *PostgresqlGrantSchema     `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	Mysql       *MysqlGrantSchema          `json:"mysql,omitempty" yaml:"mysql,omitempty"`
+	CockroachDB *NotImplementedGrantSchema `json:"cockroachdb,omitempty" yaml:"cockroachdb,omitempty"`
+	RQLite      *NotImplementedGrantSchema `json:"rqlite,omitempty" yaml:"rqlite,omitempty"`
+	SQLite      *NotImplementedGrantSchema `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
+	TimescaleDB *PostgresqlGrantSchema     `json:"timescaledb,omitempty" yaml:"timescaledb,omitempty"`
+	Cassandra   *NotImplementedGrantSchema `json:"cassandra,omitempty" yaml:"cassandra,omitempty"`
+}
+
+// GrantSpec defines the desired state of Grant
+type GrantSpec struct {
+	Database string `json:"database" yaml:"database"`
+
+	// RevokeOnDeletion revokes the privileges from the database when the grant is deleted.
+	// Without it, deleting the grant leaves the privileges in place
+	RevokeOnDeletion bool `json:"revokeOnDeletion,omitempty" yaml:"revokeOnDeletion,omitempty"`
+
+	Schema *GrantSchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// GrantStatus defines the observed state of Grant
+type GrantStatus struct {
+	// We store the SHA of the grant spec from the last time we executed a plan to
+	// make startup less noisy by skipping re-planning objects that have been planned
+	// we cannot use the resourceVersion or generation fields because updating them
+	// would cause the object to be modified again
+	LastPlannedGrantSpecSHA string `json:"lastPlannedGrantSpecSHA,omitempty" yaml:"lastPlannedGrantSpecSHA,omitempty"`
+
+	// PlanError is the reason the last plan of the grant spec failed, cleared when planned successfully
+	PlanError string `json:"planError,omitempty" yaml:"planError,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Grant is the Schema for the grant API
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.metadata.namespace`,priority=1
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+type Grant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrantSpec   `json:"spec,omitempty"`
+	Status GrantStatus `json:"status,omitempty"`
+}
+
+func (g Grant) GetSHA() (string, error) {
+	// ignoring the status, json marshal the spec and the metadata
+	o := struct {
+		Spec GrantSpec `json:"spec,omitempty"`
+	}{
+		Spec: g.Spec,
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal")
+	}
+
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GrantList contains a list of Grant
+type GrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Grant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Grant{}, &GrantList{})
+}
@@ -49,6 +49,24 @@ type MigrationSpec struct {
 	// This is populated for batch migrations that include multiple tables.
 	// For single-table migrations, this may be empty (use TableName/TableNamespace).
 	Tables []TableReference `json:"tables,omitempty"`
+
+	// RequiresOnlineCopy is set when the migration rebuilds a table by copying it into a shadow table
+	RequiresOnlineCopy bool `json:"requiresOnlineCopy,omitempty"`
+
+	// Warnings are actions that need to be taken after the migration is executed
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Destructive is set when the migration drops a table or a materialized view and the data stored in it
+	Destructive bool `json:"destructive,omitempty"`
+}
+
+// OnlineCopyStatus is the progress of copying rows into a shadow table
+type OnlineCopyStatus struct {
+	RowsCopied int64 `json:"rowsCopied,omitempty"`
+	// TotalRows is estimated from the table statistics when the copy starts
+	TotalRows int64 `json:"totalRows,omitempty"`
+	// UpdatedAt is the unix timestamp of the last progress update
+	UpdatedAt int64 `json:"updatedAt,omitempty"`
 }
 
 // MigrationStatus defines the observed state of Migration
@@ -64,6 +82,8 @@ type MigrationStatus struct {
 	ApprovedAt int64 `json:"approvedAt,omitempty"`
 	RejectedAt int64 `json:"rejectedAt,omitempty"`
 	ExecutedAt int64 `json:"executedAt,omitempty"`
+
+	OnlineCopy *OnlineCopyStatus `json:"onlineCopy,omitempty"`
 }
 
 // +genclient
@@ -37,10 +37,35 @@ type MysqlTableForeignKey struct {
 }
 
 type MysqlTableIndex struct {
-	Columns  []string `json:"columns" yaml:"columns"`
+	Columns  []string `json:"columns,omitempty" yaml:"columns,omitempty"`
 	Name     string   `json:"name,omitempty" yaml:"name,omitempty"`
 	IsUnique bool     `json:"isUnique,omitempty" yaml:"isUnique,omitempty"`
-	Type     string   `json:"type,omitempty" yaml:"type,omitempty"`
+	// Type is FULLTEXT or SPATIAL for those index kinds, or BTREE or HASH to choose the index method
+	// +kubebuilder:validation:Enum=BTREE;HASH;FULLTEXT;SPATIAL
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Parser is the full-text parser plugin of a FULLTEXT index, such as ngram
+	Parser string `json:"parser,omitempty" yaml:"parser,omitempty"`
+	// Parts are used instead of columns when a key part has a prefix length, a descending order or an expression
+	Parts []*MysqlTableIndexPart `json:"parts,omitempty" yaml:"parts,omitempty"`
+}
+
+type MysqlTableIndexPart struct {
+	Column string `json:"column,omitempty" yaml:"column,omitempty"`
+	// Length indexes only the first characters or bytes of the column
+	Length int `json:"length,omitempty" yaml:"length,omitempty"`
+	// Expression is a functional key part, it requires mysql 8.0.13 or later
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	Descending bool   `json:"descending,omitempty" yaml:"descending,omitempty"`
+}
+
+type MysqlTableTrigger struct {
+	Name string `json:"name" yaml:"name"`
+	// +kubebuilder:validation:Enum=BEFORE;AFTER
+	Timing string `json:"timing" yaml:"timing"`
+	// +kubebuilder:validation:Enum=INSERT;UPDATE;DELETE
+	Event string `json:"event" yaml:"event"`
+	// Body runs for each row, it is a single statement or a BEGIN ... END block
+	Body string `json:"body" yaml:"body"`
 }
 
 type MysqlTableColumn struct {
@@ -51,6 +76,7 @@ type MysqlTableColumn struct {
 	Default     *string                      `json:"default,omitempty" yaml:"default,omitempty"`
 	Charset     string                       `json:"charset,omitempty" yaml:"charset,omitempty"`
 	Collation   string                       `json:"collation,omitempty" yaml:"collation,omitempty"`
+	Comment     *string                      `json:"comment,omitempty" yaml:"comment,omitempty"`
 }
 
 type MysqlTableSchema struct {
@@ -61,4 +87,94 @@ type MysqlTableSchema struct {
 	IsDeleted      bool                    `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	DefaultCharset string                  `json:"defaultCharset,omitempty" yaml:"defaultCharset,omitempty"`
 	Collation      string                  `json:"collation,omitempty" yaml:"collation,omitempty"`
+	Comment        *string                 `json:"comment,omitempty" yaml:"comment,omitempty"`
+	OnlineDDL      *MysqlOnlineDDL         `json:"onlineDDL,omitempty" yaml:"onlineDDL,omitempty"`
+	Engine         string                  `json:"engine,omitempty" yaml:"engine,omitempty"`
+	// +kubebuilder:validation:Enum=DEFAULT;DYNAMIC;FIXED;COMPRESSED;REDUNDANT;COMPACT
+	RowFormat    string `json:"rowFormat,omitempty" yaml:"rowFormat,omitempty"`
+	KeyBlockSize *int   `json:"keyBlockSize,omitempty" yaml:"keyBlockSize,omitempty"`
+	// AutoIncrement is the first value of the auto increment column. An existing table is only
+	// moved forward to this value, never back.
+	AutoIncrement *int64                  `json:"autoIncrement,omitempty" yaml:"autoIncrement,omitempty"`
+	Partitioning  *MysqlTablePartitioning `json:"partitioning,omitempty" yaml:"partitioning,omitempty"`
+	// Triggers, when set, are all of the triggers on the table. Triggers that are not in the list are dropped.
+	Triggers []*MysqlTableTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+
+	OnlineSchemaChange *MysqlOnlineSchemaChange `json:"onlineSchemaChange,omitempty" yaml:"onlineSchemaChange,omitempty"`
+}
+
+// MysqlTablePartitioning is the PARTITION BY clause of a table. The partitioning of a table is only
+// managed when it is set, and an empty type removes the partitioning from the table.
+type MysqlTablePartitioning struct {
+	// +kubebuilder:validation:Enum=RANGE;RANGE COLUMNS;LIST;LIST COLUMNS;HASH;LINEAR HASH;KEY;LINEAR KEY
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Expression partitions RANGE, LIST and HASH tables, such as year(created_at)
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	// Columns partition RANGE COLUMNS, LIST COLUMNS and KEY tables
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Count is the number of HASH or KEY partitions
+	Count      int                    `json:"count,omitempty" yaml:"count,omitempty"`
+	Partitions []*MysqlTablePartition `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+}
+
+// MysqlTablePartition is a single RANGE or LIST partition
+type MysqlTablePartition struct {
+	Name string `json:"name" yaml:"name"`
+	// Values is the upper bound of a RANGE partition, such as 2024 or MAXVALUE,
+	// or the comma separated values of a LIST partition
+	Values string `json:"values" yaml:"values"`
+}
+
+// MysqlOnlineSchemaChange opts a table in to being rebuilt through a shadow table when a change would
+// otherwise copy the table under a lock. Rows are copied into the shadow table in chunks while triggers
+// keep it up to date, and the tables are then swapped with an atomic rename.
+type MysqlOnlineSchemaChange struct {
+	// ChunkSize is the number of rows copied at a time, defaults to 1000
+	ChunkSize int `json:"chunkSize,omitempty" yaml:"chunkSize,omitempty"`
+	// ThrottleMilliseconds is the pause between chunks, defaults to 100
+	ThrottleMilliseconds int `json:"throttleMilliseconds,omitempty" yaml:"throttleMilliseconds,omitempty"`
+}
+
+// MysqlOnlineDDL sets the ALGORITHM and LOCK clauses on the statements that alter a table.
+// Planning fails when a change cannot be made with the requested algorithm.
+type MysqlOnlineDDL struct {
+	// +kubebuilder:validation:Enum=INSTANT;INPLACE;COPY
+	Algorithm string `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	// +kubebuilder:validation:Enum=NONE;SHARED;EXCLUSIVE
+	Lock string `json:"lock,omitempty" yaml:"lock,omitempty"`
+}
+
+// MysqlFunctionSchema is a stored function or procedure. A routine that changes is dropped and created again.
+type MysqlFunctionSchema struct {
+	// +kubebuilder:validation:Enum=FUNCTION;PROCEDURE
+	// +kubebuilder:default:=FUNCTION
+	Type   string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Params []*MysqlFunctionParameter `json:"params,omitempty" yaml:"params,omitempty"`
+	// Returns is the type that a function returns
+	Returns       string `json:"returns,omitempty" yaml:"returns,omitempty"`
+	Deterministic bool   `json:"deterministic,omitempty" yaml:"deterministic,omitempty"`
+	// +kubebuilder:validation:Enum=CONTAINS SQL;NO SQL;READS SQL DATA;MODIFIES SQL DATA
+	DataAccess string `json:"dataAccess,omitempty" yaml:"dataAccess,omitempty"`
+	// +kubebuilder:validation:Enum=DEFINER;INVOKER
+	Security string `json:"security,omitempty" yaml:"security,omitempty"`
+	Comment  string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Body is the routine body, a single statement or a BEGIN ... END block. An example looks as follows:
+	// ```
+	// BEGIN
+	//     DECLARE user_count bigint;
+	//     SELECT COUNT(*) INTO user_count FROM users;
+	//     RETURN user_count;
+	// END
+	// ```
+	Body string `json:"body" yaml:"body"`
+	// IsDeleted is used internally to mark the routine for deletion during planning
+	IsDeleted bool `json:"-" yaml:"-"`
+}
+
+type MysqlFunctionParameter struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+	// Mode is only used by procedures
+	// +kubebuilder:validation:Enum=IN;OUT;INOUT
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
 }
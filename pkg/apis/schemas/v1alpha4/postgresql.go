@@ -60,6 +60,23 @@ type PostgresqlTableIndex struct {
 	With     map[string]string `json:"with,omitempty" yaml:"with,omitempty"`
 }
 
+type PostgresqlTableRowLevelSecurity struct {
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Forced applies the policies to the table owner as well
+	Forced bool `json:"forced,omitempty" yaml:"forced,omitempty"`
+}
+
+type PostgresqlTablePolicy struct {
+	Name string `json:"name" yaml:"name"`
+	// +kubebuilder:validation:Enum=ALL;SELECT;INSERT;UPDATE;DELETE
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Restrictive policies must all pass, while at least one permissive policy must pass
+	Restrictive bool     `json:"restrictive,omitempty" yaml:"restrictive,omitempty"`
+	Roles       []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Using       *string  `json:"using,omitempty" yaml:"using,omitempty"`
+	WithCheck   *string  `json:"withCheck,omitempty" yaml:"withCheck,omitempty"`
+}
+
 type PostgresqlTableColumnConstraints struct {
 	NotNull *bool `json:"notNull,omitempty" yaml:"notNull,omitempty"`
 }
@@ -74,6 +91,7 @@ type PostgresqlTableColumn struct {
 	Constraints *PostgresqlTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *PostgresqlTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                           `json:"default,omitempty" yaml:"default,omitempty"`
+	Comment     *string                           `json:"comment,omitempty" yaml:"comment,omitempty"`
 }
 
 type PostgresqlTableSchema struct {
@@ -83,11 +101,15 @@ type PostgresqlTableSchema struct {
 	Indexes     []*PostgresqlTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
 	Columns     []*PostgresqlTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                         `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+	Comment     *string                      `json:"comment,omitempty" yaml:"comment,omitempty"`
 	// Deprecated: this field should be avoided and one should use Triggers without json prefix instead
 	// +kubebuilder:validation:MaxItems=100
 	JSONTriggers []*PostgresqlTableTrigger `json:"json:triggers,omitempty" yaml:"json:triggers,omitempty"`
 	// +kubebuilder:validation:MaxItems=100
 	Triggers []*PostgresqlTableTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+	// RowLevelSecurity and Policies are only managed when at least one of them is set
+	RowLevelSecurity *PostgresqlTableRowLevelSecurity `json:"rowLevelSecurity,omitempty" yaml:"rowLevelSecurity,omitempty"`
+	Policies         []*PostgresqlTablePolicy         `json:"policies,omitempty" yaml:"policies,omitempty"`
 }
 
 type PostgresqlFunctionSchema struct {
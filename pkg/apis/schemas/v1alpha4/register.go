@@ -47,6 +47,9 @@ func init() {
 	SchemeBuilder.Register(&DataType{}, &DataTypeList{})
 	SchemeBuilder.Register(&DatabaseExtension{}, &DatabaseExtensionList{})
 	SchemeBuilder.Register(&Function{}, &FunctionList{})
+	SchemeBuilder.Register(&Grant{}, &GrantList{})
+	SchemeBuilder.Register(&DatabaseSchema{}, &DatabaseSchemaList{})
+	SchemeBuilder.Register(&Sequence{}, &SequenceList{})
 }
 
 // Resource is required by pkg/client/listers/...
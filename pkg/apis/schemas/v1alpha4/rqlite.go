@@ -49,6 +49,28 @@ type RqliteTableColumn struct {
 	Constraints *RqliteTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *RqliteTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                       `json:"default,omitempty" yaml:"default,omitempty"`
+	Generated   *RqliteTableColumnGenerated   `json:"generated,omitempty" yaml:"generated,omitempty"`
+}
+
+// RqliteTableColumnGenerated computes the value of a column with "generated always as"
+type RqliteTableColumnGenerated struct {
+	Expression string `json:"expression" yaml:"expression"`
+	// Stored columns are computed when the row is written, virtual columns when the row is read
+	Stored bool `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
+type RqliteTableCheck struct {
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+type RqliteTableTrigger struct {
+	Name string `json:"name" yaml:"name"`
+	// Event is when the trigger runs, such as "after insert", "before delete" or "after update of email"
+	Event     string  `json:"event" yaml:"event"`
+	Condition *string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	// Statements are run for each row that the event changes
+	Statements []string `json:"statements" yaml:"statements"`
 }
 
 type RqliteTableSchema struct {
@@ -58,4 +80,7 @@ type RqliteTableSchema struct {
 	Columns     []*RqliteTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                     `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	Strict      bool                     `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Checks      []*RqliteTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
+	// +kubebuilder:validation:MaxItems=100
+	Triggers []*RqliteTableTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
 }
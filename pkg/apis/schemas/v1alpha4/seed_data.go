@@ -1,8 +1,94 @@
 package v1alpha4
 
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var seedDataFloatRegexp = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// SeedDataValue is the value of a column in a row of seed data. One of the fields is set
 type SeedDataValue struct {
-	Int *int    `json:"int,omitempty" yaml:"int,omitempty"`
-	Str *string `json:"str,omitempty" yaml:"str,omitempty"`
+	Int  *int    `json:"int,omitempty" yaml:"int,omitempty"`
+	Str  *string `json:"str,omitempty" yaml:"str,omitempty"`
+	Bool *bool   `json:"bool,omitempty" yaml:"bool,omitempty"`
+	// Float is a number, written as it is in the statement
+	Float *SeedDataFloat `json:"float,omitempty" yaml:"float,omitempty"`
+	// Null sets the column to null. The key is quoted in yaml, "null": true, because kubernetes reads an unquoted
+	// null key as a null
+	Null bool `json:"null,omitempty" yaml:"null,omitempty"`
+	// JSON is a json document
+	JSON *string `json:"json,omitempty" yaml:"json,omitempty"`
+	// Bytes is base64 encoded binary data
+	Bytes *string `json:"bytes,omitempty" yaml:"bytes,omitempty"`
+	// Expr is a sql expression, such as now(), that is written in the statement without quoting
+	Expr *string `json:"expr,omitempty" yaml:"expr,omitempty"`
+}
+
+// UnmarshalYAML reads the null field when its key is not quoted in a spec file
+func (v *SeedDataValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type seedDataValue SeedDataValue
+	if err := unmarshal((*seedDataValue)(v)); err != nil {
+		return err
+	}
+
+	fields := map[interface{}]interface{}{}
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	if null, ok := fields[nil].(bool); ok {
+		v.Null = null
+	}
+
+	return nil
+}
+
+// GobEncode encodes the value as json. gob does not send pointers to zero values, so an int of 0, an empty str or
+// a false bool would not reach the plugin
+func (v SeedDataValue) GobEncode() ([]byte, error) {
+	type seedDataValue SeedDataValue
+	return json.Marshal(seedDataValue(v))
+}
+
+// GobDecode decodes the value that GobEncode encoded
+func (v *SeedDataValue) GobDecode(b []byte) error {
+	type seedDataValue SeedDataValue
+	return json.Unmarshal(b, (*seedDataValue)(v))
+}
+
+// SeedDataFloat is a float in a string, so that the value keeps the precision it is written with and the CRD does
+// not have a float field
+// +kubebuilder:validation:Type=number
+type SeedDataFloat string
+
+// UnmarshalJSON accepts a number or a string
+func (f *SeedDataFloat) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*f = SeedDataFloat(strings.TrimSpace(s))
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*f = SeedDataFloat(n.String())
+	return nil
+}
+
+// MarshalJSON writes the float as a number, or as a string when it is not a json number
+func (f SeedDataFloat) MarshalJSON() ([]byte, error) {
+	if !f.IsValid() || !json.Valid([]byte(f)) {
+		return json.Marshal(string(f))
+	}
+	return []byte(f), nil
+}
+
+// IsValid returns true when the float is a decimal number that databases accept as a literal
+func (f SeedDataFloat) IsValid() bool {
+	return seedDataFloatRegexp.MatchString(string(f))
 }
 
 type Column struct {
@@ -14,6 +100,56 @@ type SeedDataRow struct {
 	Columns []Column `json:"columns" yaml:"columns"`
 }
 
+// SeedDataMode is how the rows of seed data are written to the table
+type SeedDataMode string
+
+const (
+	// SeedDataModeInsertOnly inserts the rows that are not in the table
+	SeedDataModeInsertOnly SeedDataMode = "insertOnly"
+	// SeedDataModeUpsert inserts the rows that are not in the table and updates the rows that changed
+	SeedDataModeUpsert SeedDataMode = "upsert"
+	// SeedDataModeExact also deletes the rows of the table that are not in the seed data
+	SeedDataModeExact SeedDataMode = "exact"
+)
+
 type SeedData struct {
-	Rows []SeedDataRow `json:"rows" yaml:"rows"`
+	// Mode is how the rows are written. Rows are matched to the table by its primary key. When it is not set,
+	// the rows are written without reading the table
+	// +kubebuilder:validation:Enum=insertOnly;upsert;exact
+	Mode SeedDataMode  `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Rows []SeedDataRow `json:"rows,omitempty" yaml:"rows,omitempty"`
+	// ValueFrom reads more rows from a ConfigMap, a Secret or, in the plan command, a local file
+	ValueFrom *SeedDataValueFrom `json:"valueFrom,omitempty" yaml:"valueFrom,omitempty"`
+}
+
+// SeedDataValueFrom is a source of rows of seed data. One of ConfigMapKeyRef, SecretKeyRef and File is set
+type SeedDataValueFrom struct {
+	ConfigMapKeyRef *SeedDataKeyRef `json:"configMapKeyRef,omitempty" yaml:"configMapKeyRef,omitempty"`
+	SecretKeyRef    *SeedDataKeyRef `json:"secretKeyRef,omitempty" yaml:"secretKeyRef,omitempty"`
+	// File is a path to a local file, relative to the spec file. It is only read by the plan command
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+	// Format is the format of the data. When it is not set, it is found from the extension of the key or the file,
+	// and a .json file is read as yaml
+	// +kubebuilder:validation:Enum=csv;jsonl;yaml
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Columns maps the fields of the data to the columns of the table. When it is not set, every field is
+	// written to the column with the same name
+	Columns []SeedDataColumnMapping `json:"columns,omitempty" yaml:"columns,omitempty"`
+}
+
+// SeedDataKeyRef is a key in a ConfigMap or a Secret in the namespace of the table
+type SeedDataKeyRef struct {
+	Name string `json:"name" yaml:"name"`
+	Key  string `json:"key" yaml:"key"`
+}
+
+// SeedDataColumnMapping writes a field of the data to a column
+type SeedDataColumnMapping struct {
+	Column string `json:"column" yaml:"column"`
+	// Field is the csv header or the key in the json or yaml row. It defaults to the column
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Type is the type of the value. When it is not set, csv values are strings and json and yaml values keep
+	// their own type. An empty csv value of a type other than str is null
+	// +kubebuilder:validation:Enum=int;str;bool;float;json;bytes
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
 }
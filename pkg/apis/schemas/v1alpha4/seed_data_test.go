@@ -0,0 +1,112 @@
+package v1alpha4
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_SeedDataValueYAML(t *testing.T) {
+	const rows = `
+rows:
+  - columns:
+    - column: price
+      value:
+        float: 19.90
+    - column: ratio
+      value:
+        float: "1e-3"
+    - column: active
+      value:
+        bool: false
+    - column: deleted_at
+      value:
+        null: true
+    - column: created_at
+      value:
+        expr: now()
+`
+
+	seedData := SeedData{}
+	require.NoError(t, yaml.Unmarshal([]byte(rows), &seedData))
+
+	columns := seedData.Rows[0].Columns
+	assert.Equal(t, SeedDataFloat("19.90"), *columns[0].Value.Float)
+	assert.Equal(t, SeedDataFloat("1e-3"), *columns[1].Value.Float)
+	assert.False(t, *columns[2].Value.Bool)
+	assert.True(t, columns[3].Value.Null)
+	assert.Equal(t, "now()", *columns[4].Value.Expr)
+}
+
+func Test_SeedDataFloatJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		expect      SeedDataFloat
+		expectValid bool
+		marshaled   string
+	}{
+		{
+			name:        "number",
+			json:        `19.90`,
+			expect:      "19.90",
+			expectValid: true,
+			marshaled:   `19.90`,
+		},
+		{
+			name:        "string",
+			json:        `" -2.5e10 "`,
+			expect:      "-2.5e10",
+			expectValid: true,
+			marshaled:   `-2.5e10`,
+		},
+		{
+			name:        "not a number",
+			json:        `"NaN"`,
+			expect:      "NaN",
+			expectValid: false,
+			marshaled:   `"NaN"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var f SeedDataFloat
+			require.NoError(t, json.Unmarshal([]byte(test.json), &f))
+			assert.Equal(t, test.expect, f)
+			assert.Equal(t, test.expectValid, f.IsValid())
+
+			b, err := json.Marshal(f)
+			require.NoError(t, err)
+			assert.Equal(t, test.marshaled, string(b))
+		})
+	}
+}
+
+func Test_SeedDataValueGob(t *testing.T) {
+	zero, empty, no := 0, "", false
+	seedData := SeedData{
+		Rows: []SeedDataRow{
+			{
+				Columns: []Column{
+					{Column: "count", Value: SeedDataValue{Int: &zero}},
+					{Column: "name", Value: SeedDataValue{Str: &empty}},
+					{Column: "active", Value: SeedDataValue{Bool: &no}},
+					{Column: "deleted_at", Value: SeedDataValue{Null: true}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&seedData))
+
+	decoded := SeedData{}
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, seedData, decoded)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The SchemaHero Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha4
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SequenceSpec struct {
+	Database string `json:"database" yaml:"database"`
+	Name     string `json:"name" yaml:"name"`
+
+	Postgres *PostgresqlSequenceSchema `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+}
+
+// PostgresqlSequenceSchema describes a standalone sequence. Options that are not set are left
+// at the database default when the sequence is created and are not changed afterwards.
+type PostgresqlSequenceSchema struct {
+	Schema    string `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Increment *int64 `json:"increment,omitempty" yaml:"increment,omitempty"`
+	MinValue  *int64 `json:"minValue,omitempty" yaml:"minValue,omitempty"`
+	MaxValue  *int64 `json:"maxValue,omitempty" yaml:"maxValue,omitempty"`
+	Start     *int64 `json:"start,omitempty" yaml:"start,omitempty"`
+	Cache     *int64 `json:"cache,omitempty" yaml:"cache,omitempty"`
+	Cycle     bool   `json:"cycle,omitempty" yaml:"cycle,omitempty"`
+	// OwnedBy is the table.column that owns the sequence, the sequence is dropped with the column
+	OwnedBy string `json:"ownedBy,omitempty" yaml:"ownedBy,omitempty"`
+
+	IsDeleted bool `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+}
+
+type SequenceStatus struct {
+	// The SHA of the spec from the last time a plan was executed, so that objects that
+	// have been planned are not planned again on startup
+	LastPlannedSequenceSpecSHA string `json:"lastPlannedSequenceSpecSHA,omitempty" yaml:"lastPlannedSequenceSpecSHA,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Sequence is the Schema for the sequences API
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+type Sequence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SequenceSpec   `json:"spec,omitempty"`
+	Status SequenceStatus `json:"status,omitempty"`
+}
+
+func (s Sequence) GetSHA() (string, error) {
+	// ignoring the status, json marshal the spec and the metadata
+	o := struct {
+		Spec SequenceSpec `json:"spec,omitempty"`
+	}{
+		Spec: s.Spec,
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal")
+	}
+
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SequenceList contains a list of Sequence
+type SequenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Sequence `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Sequence{}, &SequenceList{})
+}
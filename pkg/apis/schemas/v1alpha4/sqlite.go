@@ -49,6 +49,35 @@ type SqliteTableColumn struct {
 	Constraints *SqliteTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *SqliteTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                       `json:"default,omitempty" yaml:"default,omitempty"`
+	Generated   *SqliteTableColumnGenerated   `json:"generated,omitempty" yaml:"generated,omitempty"`
+}
+
+// SqliteTableColumnGenerated computes the value of a column with "generated always as"
+type SqliteTableColumnGenerated struct {
+	Expression string `json:"expression" yaml:"expression"`
+	// Stored columns are computed when the row is written, virtual columns when the row is read
+	Stored bool `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
+type SqliteTableCheck struct {
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+type SqliteTableTrigger struct {
+	Name string `json:"name" yaml:"name"`
+	// Event is when the trigger runs, such as "after insert", "before delete" or "after update of email"
+	Event     string  `json:"event" yaml:"event"`
+	Condition *string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	// Statements are run for each row that the event changes
+	Statements []string `json:"statements" yaml:"statements"`
+}
+
+// SqliteTableVirtual creates the table with a module, such as fts5 or rtree
+type SqliteTableVirtual struct {
+	Module string `json:"module" yaml:"module"`
+	// Arguments are passed to the module, such as the columns and options of an fts5 table
+	Arguments []string `json:"arguments,omitempty" yaml:"arguments,omitempty"`
 }
 
 type SqliteTableSchema struct {
@@ -58,4 +87,10 @@ type SqliteTableSchema struct {
 	Columns     []*SqliteTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                     `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	Strict      bool                     `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Checks      []*SqliteTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
+	// +kubebuilder:validation:MaxItems=100
+	Triggers     []*SqliteTableTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+	WithoutRowid bool                  `json:"withoutRowid,omitempty" yaml:"withoutRowid,omitempty"`
+	// Virtual tables are created with a module and cannot have columns, keys, indexes, checks or triggers
+	Virtual *SqliteTableVirtual `json:"virtual,omitempty" yaml:"virtual,omitempty"`
 }
@@ -52,6 +52,14 @@ type TableStatus struct {
 	// we cannot use the resourceVersion or generation fields because updating them
 	// would cause the object to be modified again
 	LastPlannedTableSpecSHA string `json:"lastPlannedTableSpecSHA,omitempty" yaml:"lastPlannedTableSpecSHA,omitempty"`
+
+	// PlanError is the reason the last plan of the table spec failed, such as a change
+	// the database cannot make. It is cleared when the table is planned successfully
+	PlanError string `json:"planError,omitempty" yaml:"planError,omitempty"`
+
+	// SeedDataError is the reason the seed data valueFrom could not be read. The table is
+	// planned without the seed data until it can be read
+	SeedDataError string `json:"seedDataError,omitempty" yaml:"seedDataError,omitempty"`
 }
 
 // +genclient
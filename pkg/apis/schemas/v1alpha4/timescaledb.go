@@ -34,23 +34,38 @@ type TimescaleDBHypertable struct {
 
 	Compression *TimescaleDBCompression `json:"compression,omitempty" yaml:"compression,omitempty"`
 	Retention   *TimescaleDBRetention   `json:"retention,omitempty" yaml:"retention,omitempty"`
+	Reorder     *TimescaleDBReorder     `json:"reorder,omitempty" yaml:"reorder,omitempty"`
+	Tiering     *TimescaleDBTiering     `json:"tiering,omitempty" yaml:"tiering,omitempty"`
 }
 
 type TimescaleDBCompression struct {
-	SegmentBy *string `json:"segmentBy" yaml:"segmentBy"`
-	Interval  *string `json:"interval" yaml:"interval"`
+	SegmentBy *string `json:"segmentBy,omitempty" yaml:"segmentBy,omitempty"`
+	// OrderBy is the compress_orderby setting, such as "time desc"
+	OrderBy  *string `json:"orderBy,omitempty" yaml:"orderBy,omitempty"`
+	Interval *string `json:"interval,omitempty" yaml:"interval,omitempty"`
 }
 
 type TimescaleDBRetention struct {
 	Interval string `json:"interval" yaml:"interval"`
 }
 
+// TimescaleDBReorder is a policy that reorders the chunks of the hypertable by an index
+type TimescaleDBReorder struct {
+	Index string `json:"index" yaml:"index"`
+}
+
+// TimescaleDBTiering is a policy that moves chunks older than the interval to object storage
+type TimescaleDBTiering struct {
+	Interval string `json:"interval" yaml:"interval"`
+}
+
 type TimescaleDBTableSchema struct {
 	PrimaryKey  []string                     `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
 	ForeignKeys []*PostgresqlTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
 	Indexes     []*PostgresqlTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
 	Columns     []*PostgresqlTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                         `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+	Comment     *string                      `json:"comment,omitempty" yaml:"comment,omitempty"`
 	// +kubebuilder:validation:MaxItems=100
 	Triggers   []*PostgresqlTableTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
 	Hypertable *TimescaleDBHypertable    `json:"hypertable,omitempty" yaml:"hypertable,omitempty"`
@@ -61,4 +76,23 @@ type TimescaleDBViewSchema struct {
 	WithNoData            *bool  `json:"withNoData,omitempty" yaml:"withNoData,omitempty"`
 	Query                 string `json:"query,omitempty" yaml:"query,omitempty"`
 	IsDeleted             bool   `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+
+	// MaterializedOnly disables real-time aggregation, so queries only return materialized data
+	MaterializedOnly *bool                                      `json:"materializedOnly,omitempty" yaml:"materializedOnly,omitempty"`
+	RefreshPolicy    *TimescaleDBRefreshPolicy                  `json:"refreshPolicy,omitempty" yaml:"refreshPolicy,omitempty"`
+	Compression      *TimescaleDBContinuousAggregateCompression `json:"compression,omitempty" yaml:"compression,omitempty"`
+}
+
+// TimescaleDBRefreshPolicy is the policy that refreshes a continuous aggregate. The window that is refreshed
+// starts at StartOffset and ends at EndOffset before the time the policy runs, an empty offset is unbounded
+type TimescaleDBRefreshPolicy struct {
+	StartOffset      *string `json:"startOffset,omitempty" yaml:"startOffset,omitempty"`
+	EndOffset        *string `json:"endOffset,omitempty" yaml:"endOffset,omitempty"`
+	ScheduleInterval string  `json:"scheduleInterval" yaml:"scheduleInterval"`
+}
+
+// TimescaleDBContinuousAggregateCompression enables compression on a continuous aggregate, and compresses
+// chunks older than the interval when it is set
+type TimescaleDBContinuousAggregateCompression struct {
+	Interval *string `json:"interval,omitempty" yaml:"interval,omitempty"`
 }
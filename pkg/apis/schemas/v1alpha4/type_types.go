@@ -34,12 +34,22 @@ type DataTypeSpec struct {
 
 // DataTypeStatus defines the observed state of Type
 type DataTypeStatus struct {
+	AppliedAt int64 `json:"appliedAt,omitempty" yaml:"appliedAt,omitempty"`
+
+	Phase string `json:"phase,omitempty" yaml:"phase,omitempty"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // DataType is the Schema for the datatypes API
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.metadata.namespace`,priority=1
+// +kubebuilder:printcolumn:name="DataType",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
 type DataType struct {
 	metav1.TypeMeta   `json:",inline"`
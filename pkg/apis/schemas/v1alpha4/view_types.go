@@ -35,7 +35,7 @@ type ViewSchema struct {
 	RQLite      *NotImplementedViewSchema `json:"rqlite,omitempty" yaml:"rqlite,omitempty"`
 	SQLite      *NotImplementedViewSchema `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
 	TimescaleDB *TimescaleDBViewSchema    `json:"timescaledb,omitempty" yaml:"timescaledb,omitempty"`
-	Cassandra   *NotImplementedViewSchema `json:"cassandra,omitempty" yaml:"cassandra,omitempty"`
+	Cassandra   *CassandraViewSchema      `json:"cassandra,omitempty" yaml:"cassandra,omitempty"`
 }
 
 // ViewSpec defines the desired state of View
@@ -105,6 +105,86 @@ func (in *CassandraField) DeepCopy() *CassandraField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraKeyspaceReplication) DeepCopyInto(out *CassandraKeyspaceReplication) {
+	*out = *in
+	if in.DataCenters != nil {
+		in, out := &in.DataCenters, &out.DataCenters
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraKeyspaceReplication.
+func (in *CassandraKeyspaceReplication) DeepCopy() *CassandraKeyspaceReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraKeyspaceReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraKeyspaceSchema) DeepCopyInto(out *CassandraKeyspaceSchema) {
+	*out = *in
+	in.Replication.DeepCopyInto(&out.Replication)
+	if in.DurableWrites != nil {
+		in, out := &in.DurableWrites, &out.DurableWrites
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraKeyspaceSchema.
+func (in *CassandraKeyspaceSchema) DeepCopy() *CassandraKeyspaceSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraKeyspaceSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraPrimaryKeyChange) DeepCopyInto(out *CassandraPrimaryKeyChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraPrimaryKeyChange.
+func (in *CassandraPrimaryKeyChange) DeepCopy() *CassandraPrimaryKeyChange {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraPrimaryKeyChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraTableIndex) DeepCopyInto(out *CassandraTableIndex) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraTableIndex.
+func (in *CassandraTableIndex) DeepCopy() *CassandraTableIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraTableIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraTableProperties) DeepCopyInto(out *CassandraTableProperties) {
 	*out = *in
@@ -154,6 +234,11 @@ func (in *CassandraTableProperties) DeepCopyInto(out *CassandraTableProperties)
 		*out = new(int)
 		**out = **in
 	}
+	if in.CDC != nil {
+		in, out := &in.CDC, &out.CDC
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraTableProperties.
@@ -182,8 +267,14 @@ func (in *CassandraTableSchema) DeepCopyInto(out *CassandraTableSchema) {
 	}
 	if in.ClusteringOrder != nil {
 		in, out := &in.ClusteringOrder, &out.ClusteringOrder
-		*out = new(CassandraClusteringOrder)
-		(*in).DeepCopyInto(*out)
+		*out = make([]*CassandraClusteringOrder, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CassandraClusteringOrder)
+				(*in).DeepCopyInto(*out)
+			}
+		}
 	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
@@ -196,11 +287,27 @@ func (in *CassandraTableSchema) DeepCopyInto(out *CassandraTableSchema) {
 			}
 		}
 	}
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]*CassandraTableIndex, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CassandraTableIndex)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = new(CassandraTableProperties)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PrimaryKeyChange != nil {
+		in, out := &in.PrimaryKeyChange, &out.PrimaryKeyChange
+		*out = new(CassandraPrimaryKeyChange)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraTableSchema.
@@ -213,6 +320,53 @@ func (in *CassandraTableSchema) DeepCopy() *CassandraTableSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraViewSchema) DeepCopyInto(out *CassandraViewSchema) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrimaryKey != nil {
+		in, out := &in.PrimaryKey, &out.PrimaryKey
+		*out = make([][]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.ClusteringOrder != nil {
+		in, out := &in.ClusteringOrder, &out.ClusteringOrder
+		*out = make([]*CassandraClusteringOrder, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CassandraClusteringOrder)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = new(CassandraTableProperties)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraViewSchema.
+func (in *CassandraViewSchema) DeepCopy() *CassandraViewSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraViewSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Column) DeepCopyInto(out *Column) {
 	*out = *in
@@ -437,6 +591,105 @@ func (in *DatabaseExtensionStatus) DeepCopy() *DatabaseExtensionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSchema) DeepCopyInto(out *DatabaseSchema) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSchema.
+func (in *DatabaseSchema) DeepCopy() *DatabaseSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseSchema) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSchemaList) DeepCopyInto(out *DatabaseSchemaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSchemaList.
+func (in *DatabaseSchemaList) DeepCopy() *DatabaseSchemaList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSchemaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseSchemaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSchemaSpec) DeepCopyInto(out *DatabaseSchemaSpec) {
+	*out = *in
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(PostgresqlDatabaseSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cassandra != nil {
+		in, out := &in.Cassandra, &out.Cassandra
+		*out = new(CassandraKeyspaceSchema)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSchemaSpec.
+func (in *DatabaseSchemaSpec) DeepCopy() *DatabaseSchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSchemaStatus) DeepCopyInto(out *DatabaseSchemaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSchemaStatus.
+func (in *DatabaseSchemaStatus) DeepCopy() *DatabaseSchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Function) DeepCopyInto(out *Function) {
 	*out = *in
@@ -506,8 +759,8 @@ func (in *FunctionSchema) DeepCopyInto(out *FunctionSchema) {
 	}
 	if in.Mysql != nil {
 		in, out := &in.Mysql, &out.Mysql
-		*out = new(NotImplementedFunctionSchema)
-		**out = **in
+		*out = new(MysqlFunctionSchema)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.CockroachDB != nil {
 		in, out := &in.CockroachDB, &out.CockroachDB
@@ -582,26 +835,26 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Migration) DeepCopyInto(out *Migration) {
+func (in *Grant) DeepCopyInto(out *Grant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migration.
-func (in *Migration) DeepCopy() *Migration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Grant.
+func (in *Grant) DeepCopy() *Grant {
 	if in == nil {
 		return nil
 	}
-	out := new(Migration)
+	out := new(Grant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Migration) DeepCopyObject() runtime.Object {
+func (in *Grant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -609,31 +862,31 @@ func (in *Migration) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationList) DeepCopyInto(out *MigrationList) {
+func (in *GrantList) DeepCopyInto(out *GrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Migration, len(*in))
+		*out = make([]Grant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationList.
-func (in *MigrationList) DeepCopy() *MigrationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantList.
+func (in *GrantList) DeepCopy() *GrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrationList)
+	out := new(GrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MigrationList) DeepCopyObject() runtime.Object {
+func (in *GrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -641,45 +894,306 @@ func (in *MigrationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationSpec) DeepCopyInto(out *MigrationSpec) {
+func (in *GrantObject) DeepCopyInto(out *GrantObject) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationSpec.
-func (in *MigrationSpec) DeepCopy() *MigrationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantObject.
+func (in *GrantObject) DeepCopy() *GrantObject {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrationSpec)
+	out := new(GrantObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+func (in *GrantSchema) DeepCopyInto(out *GrantSchema) {
 	*out = *in
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(PostgresqlGrantSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mysql != nil {
+		in, out := &in.Mysql, &out.Mysql
+		*out = new(MysqlGrantSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CockroachDB != nil {
+		in, out := &in.CockroachDB, &out.CockroachDB
+		*out = new(NotImplementedGrantSchema)
+		**out = **in
+	}
+	if in.RQLite != nil {
+		in, out := &in.RQLite, &out.RQLite
+		*out = new(NotImplementedGrantSchema)
+		**out = **in
+	}
+	if in.SQLite != nil {
+		in, out := &in.SQLite, &out.SQLite
+		*out = new(NotImplementedGrantSchema)
+		**out = **in
+	}
+	if in.TimescaleDB != nil {
+		in, out := &in.TimescaleDB, &out.TimescaleDB
+		*out = new(PostgresqlGrantSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cassandra != nil {
+		in, out := &in.Cassandra, &out.Cassandra
+		*out = new(NotImplementedGrantSchema)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
-func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantSchema.
+func (in *GrantSchema) DeepCopy() *GrantSchema {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrationStatus)
+	out := new(GrantSchema)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MysqlTableColumn) DeepCopyInto(out *MysqlTableColumn) {
+func (in *GrantSpec) DeepCopyInto(out *GrantSpec) {
 	*out = *in
-	if in.Constraints != nil {
-		in, out := &in.Constraints, &out.Constraints
-		*out = new(MysqlTableColumnConstraints)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(GrantSchema)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantSpec.
+func (in *GrantSpec) DeepCopy() *GrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantStatus) DeepCopyInto(out *GrantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantStatus.
+func (in *GrantStatus) DeepCopy() *GrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Migration) DeepCopyInto(out *Migration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migration.
+func (in *Migration) DeepCopy() *Migration {
+	if in == nil {
+		return nil
+	}
+	out := new(Migration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Migration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationList) DeepCopyInto(out *MigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Migration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationList.
+func (in *MigrationList) DeepCopy() *MigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationSpec) DeepCopyInto(out *MigrationSpec) {
+	*out = *in
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationSpec.
+func (in *MigrationSpec) DeepCopy() *MigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	if in.OnlineCopy != nil {
+		in, out := &in.OnlineCopy, &out.OnlineCopy
+		*out = new(OnlineCopyStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlFunctionParameter) DeepCopyInto(out *MysqlFunctionParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlFunctionParameter.
+func (in *MysqlFunctionParameter) DeepCopy() *MysqlFunctionParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlFunctionParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlFunctionSchema) DeepCopyInto(out *MysqlFunctionSchema) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]*MysqlFunctionParameter, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlFunctionParameter)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlFunctionSchema.
+func (in *MysqlFunctionSchema) DeepCopy() *MysqlFunctionSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlFunctionSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlGrantSchema) DeepCopyInto(out *MysqlGrantSchema) {
+	*out = *in
+	out.Object = in.Object
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlGrantSchema.
+func (in *MysqlGrantSchema) DeepCopy() *MysqlGrantSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlGrantSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlOnlineDDL) DeepCopyInto(out *MysqlOnlineDDL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlOnlineDDL.
+func (in *MysqlOnlineDDL) DeepCopy() *MysqlOnlineDDL {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlOnlineDDL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlOnlineSchemaChange) DeepCopyInto(out *MysqlOnlineSchemaChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlOnlineSchemaChange.
+func (in *MysqlOnlineSchemaChange) DeepCopy() *MysqlOnlineSchemaChange {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlOnlineSchemaChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTableColumn) DeepCopyInto(out *MysqlTableColumn) {
+	*out = *in
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(MysqlTableColumnConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
 		*out = new(MysqlTableColumnAttributes)
 		(*in).DeepCopyInto(*out)
 	}
@@ -688,6 +1202,11 @@ func (in *MysqlTableColumn) DeepCopyInto(out *MysqlTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableColumn.
@@ -789,6 +1308,17 @@ func (in *MysqlTableIndex) DeepCopyInto(out *MysqlTableIndex) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Parts != nil {
+		in, out := &in.Parts, &out.Parts
+		*out = make([]*MysqlTableIndexPart, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlTableIndexPart)
+				**out = **in
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableIndex.
@@ -801,6 +1331,67 @@ func (in *MysqlTableIndex) DeepCopy() *MysqlTableIndex {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTableIndexPart) DeepCopyInto(out *MysqlTableIndexPart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableIndexPart.
+func (in *MysqlTableIndexPart) DeepCopy() *MysqlTableIndexPart {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTableIndexPart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTablePartition) DeepCopyInto(out *MysqlTablePartition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTablePartition.
+func (in *MysqlTablePartition) DeepCopy() *MysqlTablePartition {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTablePartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTablePartitioning) DeepCopyInto(out *MysqlTablePartitioning) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]*MysqlTablePartition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlTablePartition)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTablePartitioning.
+func (in *MysqlTablePartitioning) DeepCopy() *MysqlTablePartitioning {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTablePartitioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MysqlTableSchema) DeepCopyInto(out *MysqlTableSchema) {
 	*out = *in
@@ -842,16 +1433,72 @@ func (in *MysqlTableSchema) DeepCopyInto(out *MysqlTableSchema) {
 			}
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableSchema.
-func (in *MysqlTableSchema) DeepCopy() *MysqlTableSchema {
-	if in == nil {
-		return nil
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	if in.OnlineDDL != nil {
+		in, out := &in.OnlineDDL, &out.OnlineDDL
+		*out = new(MysqlOnlineDDL)
+		**out = **in
+	}
+	if in.KeyBlockSize != nil {
+		in, out := &in.KeyBlockSize, &out.KeyBlockSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutoIncrement != nil {
+		in, out := &in.AutoIncrement, &out.AutoIncrement
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(MysqlTablePartitioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]*MysqlTableTrigger, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlTableTrigger)
+				**out = **in
+			}
+		}
+	}
+	if in.OnlineSchemaChange != nil {
+		in, out := &in.OnlineSchemaChange, &out.OnlineSchemaChange
+		*out = new(MysqlOnlineSchemaChange)
+		**out = **in
 	}
-	out := new(MysqlTableSchema)
-	in.DeepCopyInto(out)
-	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableSchema.
+func (in *MysqlTableSchema) DeepCopy() *MysqlTableSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTableSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTableTrigger) DeepCopyInto(out *MysqlTableTrigger) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableTrigger.
+func (in *MysqlTableTrigger) DeepCopy() *MysqlTableTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTableTrigger)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -869,6 +1516,21 @@ func (in *NotImplementedFunctionSchema) DeepCopy() *NotImplementedFunctionSchema
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotImplementedGrantSchema) DeepCopyInto(out *NotImplementedGrantSchema) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotImplementedGrantSchema.
+func (in *NotImplementedGrantSchema) DeepCopy() *NotImplementedGrantSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(NotImplementedGrantSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotImplementedViewSchema) DeepCopyInto(out *NotImplementedViewSchema) {
 	*out = *in
@@ -884,6 +1546,21 @@ func (in *NotImplementedViewSchema) DeepCopy() *NotImplementedViewSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineCopyStatus) DeepCopyInto(out *OnlineCopyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineCopyStatus.
+func (in *OnlineCopyStatus) DeepCopy() *OnlineCopyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineCopyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresDatabaseExtension) DeepCopyInto(out *PostgresDatabaseExtension) {
 	*out = *in
@@ -909,23 +1586,452 @@ func (in *PostgresDatabaseExtension) DeepCopy() *PostgresDatabaseExtension {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlDatabaseSchema) DeepCopyInto(out *PostgresqlDatabaseSchema) {
+	*out = *in
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlDatabaseSchema.
+func (in *PostgresqlDatabaseSchema) DeepCopy() *PostgresqlDatabaseSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlDatabaseSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresqlExecuteParameter) DeepCopyInto(out *PostgresqlExecuteParameter) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlExecuteParameter.
-func (in *PostgresqlExecuteParameter) DeepCopy() *PostgresqlExecuteParameter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlExecuteParameter.
+func (in *PostgresqlExecuteParameter) DeepCopy() *PostgresqlExecuteParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlExecuteParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlFunctionSchema) DeepCopyInto(out *PostgresqlFunctionSchema) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]*PostgresqlExecuteParameter, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlExecuteParameter)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlFunctionSchema.
+func (in *PostgresqlFunctionSchema) DeepCopy() *PostgresqlFunctionSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlFunctionSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlGrantSchema) DeepCopyInto(out *PostgresqlGrantSchema) {
+	*out = *in
+	out.Object = in.Object
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlGrantSchema.
+func (in *PostgresqlGrantSchema) DeepCopy() *PostgresqlGrantSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlGrantSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlSequenceSchema) DeepCopyInto(out *PostgresqlSequenceSchema) {
+	*out = *in
+	if in.Increment != nil {
+		in, out := &in.Increment, &out.Increment
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinValue != nil {
+		in, out := &in.MinValue, &out.MinValue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxValue != nil {
+		in, out := &in.MaxValue, &out.MaxValue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlSequenceSchema.
+func (in *PostgresqlSequenceSchema) DeepCopy() *PostgresqlSequenceSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlSequenceSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableColumn) DeepCopyInto(out *PostgresqlTableColumn) {
+	*out = *in
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(PostgresqlTableColumnConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(PostgresqlTableColumnAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumn.
+func (in *PostgresqlTableColumn) DeepCopy() *PostgresqlTableColumn {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableColumn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableColumnAttributes) DeepCopyInto(out *PostgresqlTableColumnAttributes) {
+	*out = *in
+	if in.AutoIncrement != nil {
+		in, out := &in.AutoIncrement, &out.AutoIncrement
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnAttributes.
+func (in *PostgresqlTableColumnAttributes) DeepCopy() *PostgresqlTableColumnAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableColumnAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableColumnConstraints) DeepCopyInto(out *PostgresqlTableColumnConstraints) {
+	*out = *in
+	if in.NotNull != nil {
+		in, out := &in.NotNull, &out.NotNull
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnConstraints.
+func (in *PostgresqlTableColumnConstraints) DeepCopy() *PostgresqlTableColumnConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableColumnConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableForeignKey) DeepCopyInto(out *PostgresqlTableForeignKey) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.References.DeepCopyInto(&out.References)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableForeignKey.
+func (in *PostgresqlTableForeignKey) DeepCopy() *PostgresqlTableForeignKey {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableForeignKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableForeignKeyReferences) DeepCopyInto(out *PostgresqlTableForeignKeyReferences) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableForeignKeyReferences.
+func (in *PostgresqlTableForeignKeyReferences) DeepCopy() *PostgresqlTableForeignKeyReferences {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableForeignKeyReferences)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableIndex) DeepCopyInto(out *PostgresqlTableIndex) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableIndex.
+func (in *PostgresqlTableIndex) DeepCopy() *PostgresqlTableIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTablePolicy) DeepCopyInto(out *PostgresqlTablePolicy) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Using != nil {
+		in, out := &in.Using, &out.Using
+		*out = new(string)
+		**out = **in
+	}
+	if in.WithCheck != nil {
+		in, out := &in.WithCheck, &out.WithCheck
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTablePolicy.
+func (in *PostgresqlTablePolicy) DeepCopy() *PostgresqlTablePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTablePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableRowLevelSecurity) DeepCopyInto(out *PostgresqlTableRowLevelSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableRowLevelSecurity.
+func (in *PostgresqlTableRowLevelSecurity) DeepCopy() *PostgresqlTableRowLevelSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableRowLevelSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
+	*out = *in
+	if in.PrimaryKey != nil {
+		in, out := &in.PrimaryKey, &out.PrimaryKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForeignKeys != nil {
+		in, out := &in.ForeignKeys, &out.ForeignKeys
+		*out = make([]*PostgresqlTableForeignKey, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableForeignKey)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]*PostgresqlTableIndex, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableIndex)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]*PostgresqlTableColumn, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableColumn)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	if in.JSONTriggers != nil {
+		in, out := &in.JSONTriggers, &out.JSONTriggers
+		*out = make([]*PostgresqlTableTrigger, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableTrigger)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]*PostgresqlTableTrigger, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableTrigger)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RowLevelSecurity != nil {
+		in, out := &in.RowLevelSecurity, &out.RowLevelSecurity
+		*out = new(PostgresqlTableRowLevelSecurity)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]*PostgresqlTablePolicy, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTablePolicy)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableSchema.
+func (in *PostgresqlTableSchema) DeepCopy() *PostgresqlTableSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableTrigger) DeepCopyInto(out *PostgresqlTableTrigger) {
+	*out = *in
+	if in.ConstraintTrigger != nil {
+		in, out := &in.ConstraintTrigger, &out.ConstraintTrigger
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForEachStatement != nil {
+		in, out := &in.ForEachStatement, &out.ForEachStatement
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForEachRow != nil {
+		in, out := &in.ForEachRow, &out.ForEachRow
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(string)
+		**out = **in
+	}
+	if in.Execute != nil {
+		in, out := &in.Execute, &out.Execute
+		*out = new(PostgresqlTableTriggerExecute)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableTrigger.
+func (in *PostgresqlTableTrigger) DeepCopy() *PostgresqlTableTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlExecuteParameter)
+	out := new(PostgresqlTableTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlFunctionSchema) DeepCopyInto(out *PostgresqlFunctionSchema) {
+func (in *PostgresqlTableTriggerExecute) DeepCopyInto(out *PostgresqlTableTriggerExecute) {
 	*out = *in
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
@@ -940,27 +2046,42 @@ func (in *PostgresqlFunctionSchema) DeepCopyInto(out *PostgresqlFunctionSchema)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlFunctionSchema.
-func (in *PostgresqlFunctionSchema) DeepCopy() *PostgresqlFunctionSchema {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableTriggerExecute.
+func (in *PostgresqlTableTriggerExecute) DeepCopy() *PostgresqlTableTriggerExecute {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlFunctionSchema)
+	out := new(PostgresqlTableTriggerExecute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableColumn) DeepCopyInto(out *PostgresqlTableColumn) {
+func (in *RqliteTableCheck) DeepCopyInto(out *RqliteTableCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableCheck.
+func (in *RqliteTableCheck) DeepCopy() *RqliteTableCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RqliteTableCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RqliteTableColumn) DeepCopyInto(out *RqliteTableColumn) {
 	*out = *in
 	if in.Constraints != nil {
 		in, out := &in.Constraints, &out.Constraints
-		*out = new(PostgresqlTableColumnConstraints)
+		*out = new(RqliteTableColumnConstraints)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
-		*out = new(PostgresqlTableColumnAttributes)
+		*out = new(RqliteTableColumnAttributes)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Default != nil {
@@ -968,20 +2089,25 @@ func (in *PostgresqlTableColumn) DeepCopyInto(out *PostgresqlTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Generated != nil {
+		in, out := &in.Generated, &out.Generated
+		*out = new(RqliteTableColumnGenerated)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumn.
-func (in *PostgresqlTableColumn) DeepCopy() *PostgresqlTableColumn {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumn.
+func (in *RqliteTableColumn) DeepCopy() *RqliteTableColumn {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableColumn)
+	out := new(RqliteTableColumn)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableColumnAttributes) DeepCopyInto(out *PostgresqlTableColumnAttributes) {
+func (in *RqliteTableColumnAttributes) DeepCopyInto(out *RqliteTableColumnAttributes) {
 	*out = *in
 	if in.AutoIncrement != nil {
 		in, out := &in.AutoIncrement, &out.AutoIncrement
@@ -990,18 +2116,18 @@ func (in *PostgresqlTableColumnAttributes) DeepCopyInto(out *PostgresqlTableColu
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnAttributes.
-func (in *PostgresqlTableColumnAttributes) DeepCopy() *PostgresqlTableColumnAttributes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumnAttributes.
+func (in *RqliteTableColumnAttributes) DeepCopy() *RqliteTableColumnAttributes {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableColumnAttributes)
+	out := new(RqliteTableColumnAttributes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableColumnConstraints) DeepCopyInto(out *PostgresqlTableColumnConstraints) {
+func (in *RqliteTableColumnConstraints) DeepCopyInto(out *RqliteTableColumnConstraints) {
 	*out = *in
 	if in.NotNull != nil {
 		in, out := &in.NotNull, &out.NotNull
@@ -1010,18 +2136,33 @@ func (in *PostgresqlTableColumnConstraints) DeepCopyInto(out *PostgresqlTableCol
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnConstraints.
-func (in *PostgresqlTableColumnConstraints) DeepCopy() *PostgresqlTableColumnConstraints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumnConstraints.
+func (in *RqliteTableColumnConstraints) DeepCopy() *RqliteTableColumnConstraints {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableColumnConstraints)
+	out := new(RqliteTableColumnConstraints)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableForeignKey) DeepCopyInto(out *PostgresqlTableForeignKey) {
+func (in *RqliteTableColumnGenerated) DeepCopyInto(out *RqliteTableColumnGenerated) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumnGenerated.
+func (in *RqliteTableColumnGenerated) DeepCopy() *RqliteTableColumnGenerated {
+	if in == nil {
+		return nil
+	}
+	out := new(RqliteTableColumnGenerated)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RqliteTableForeignKey) DeepCopyInto(out *RqliteTableForeignKey) {
 	*out = *in
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
@@ -1031,18 +2172,18 @@ func (in *PostgresqlTableForeignKey) DeepCopyInto(out *PostgresqlTableForeignKey
 	in.References.DeepCopyInto(&out.References)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableForeignKey.
-func (in *PostgresqlTableForeignKey) DeepCopy() *PostgresqlTableForeignKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableForeignKey.
+func (in *RqliteTableForeignKey) DeepCopy() *RqliteTableForeignKey {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableForeignKey)
+	out := new(RqliteTableForeignKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableForeignKeyReferences) DeepCopyInto(out *PostgresqlTableForeignKeyReferences) {
+func (in *RqliteTableForeignKeyReferences) DeepCopyInto(out *RqliteTableForeignKeyReferences) {
 	*out = *in
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
@@ -1051,18 +2192,18 @@ func (in *PostgresqlTableForeignKeyReferences) DeepCopyInto(out *PostgresqlTable
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableForeignKeyReferences.
-func (in *PostgresqlTableForeignKeyReferences) DeepCopy() *PostgresqlTableForeignKeyReferences {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableForeignKeyReferences.
+func (in *RqliteTableForeignKeyReferences) DeepCopy() *RqliteTableForeignKeyReferences {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableForeignKeyReferences)
+	out := new(RqliteTableForeignKeyReferences)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableIndex) DeepCopyInto(out *PostgresqlTableIndex) {
+func (in *RqliteTableIndex) DeepCopyInto(out *RqliteTableIndex) {
 	*out = *in
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
@@ -1071,18 +2212,18 @@ func (in *PostgresqlTableIndex) DeepCopyInto(out *PostgresqlTableIndex) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableIndex.
-func (in *PostgresqlTableIndex) DeepCopy() *PostgresqlTableIndex {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableIndex.
+func (in *RqliteTableIndex) DeepCopy() *RqliteTableIndex {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableIndex)
+	out := new(RqliteTableIndex)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
+func (in *RqliteTableSchema) DeepCopyInto(out *RqliteTableSchema) {
 	*out = *in
 	if in.PrimaryKey != nil {
 		in, out := &in.PrimaryKey, &out.PrimaryKey
@@ -1091,391 +2232,360 @@ func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
 	}
 	if in.ForeignKeys != nil {
 		in, out := &in.ForeignKeys, &out.ForeignKeys
-		*out = make([]*PostgresqlTableForeignKey, len(*in))
+		*out = make([]*RqliteTableForeignKey, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlTableForeignKey)
+				*out = new(RqliteTableForeignKey)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
 	if in.Indexes != nil {
 		in, out := &in.Indexes, &out.Indexes
-		*out = make([]*PostgresqlTableIndex, len(*in))
+		*out = make([]*RqliteTableIndex, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlTableIndex)
+				*out = new(RqliteTableIndex)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
-		*out = make([]*PostgresqlTableColumn, len(*in))
+		*out = make([]*RqliteTableColumn, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlTableColumn)
+				*out = new(RqliteTableColumn)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
-	if in.JSONTriggers != nil {
-		in, out := &in.JSONTriggers, &out.JSONTriggers
-		*out = make([]*PostgresqlTableTrigger, len(*in))
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*RqliteTableCheck, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlTableTrigger)
-				(*in).DeepCopyInto(*out)
+				*out = new(RqliteTableCheck)
+				**out = **in
 			}
 		}
 	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
-		*out = make([]*PostgresqlTableTrigger, len(*in))
+		*out = make([]*RqliteTableTrigger, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlTableTrigger)
+				*out = new(RqliteTableTrigger)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableSchema.
-func (in *PostgresqlTableSchema) DeepCopy() *PostgresqlTableSchema {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableSchema.
+func (in *RqliteTableSchema) DeepCopy() *RqliteTableSchema {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableSchema)
+	out := new(RqliteTableSchema)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableTrigger) DeepCopyInto(out *PostgresqlTableTrigger) {
+func (in *RqliteTableTrigger) DeepCopyInto(out *RqliteTableTrigger) {
 	*out = *in
-	if in.ConstraintTrigger != nil {
-		in, out := &in.ConstraintTrigger, &out.ConstraintTrigger
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Events != nil {
-		in, out := &in.Events, &out.Events
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ForEachStatement != nil {
-		in, out := &in.ForEachStatement, &out.ForEachStatement
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ForEachRow != nil {
-		in, out := &in.ForEachRow, &out.ForEachRow
-		*out = new(bool)
-		**out = **in
-	}
 	if in.Condition != nil {
 		in, out := &in.Condition, &out.Condition
 		*out = new(string)
 		**out = **in
 	}
-	if in.Execute != nil {
-		in, out := &in.Execute, &out.Execute
-		*out = new(PostgresqlTableTriggerExecute)
-		(*in).DeepCopyInto(*out)
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableTrigger.
-func (in *PostgresqlTableTrigger) DeepCopy() *PostgresqlTableTrigger {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableTrigger.
+func (in *RqliteTableTrigger) DeepCopy() *RqliteTableTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableTrigger)
+	out := new(RqliteTableTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresqlTableTriggerExecute) DeepCopyInto(out *PostgresqlTableTriggerExecute) {
+func (in *SeedData) DeepCopyInto(out *SeedData) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]*PostgresqlExecuteParameter, len(*in))
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = make([]SeedDataRow, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(PostgresqlExecuteParameter)
-				**out = **in
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(SeedDataValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableTriggerExecute.
-func (in *PostgresqlTableTriggerExecute) DeepCopy() *PostgresqlTableTriggerExecute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedData.
+func (in *SeedData) DeepCopy() *SeedData {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresqlTableTriggerExecute)
+	out := new(SeedData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableColumn) DeepCopyInto(out *RqliteTableColumn) {
+func (in *SeedDataColumnMapping) DeepCopyInto(out *SeedDataColumnMapping) {
 	*out = *in
-	if in.Constraints != nil {
-		in, out := &in.Constraints, &out.Constraints
-		*out = new(RqliteTableColumnConstraints)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = new(RqliteTableColumnAttributes)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumn.
-func (in *RqliteTableColumn) DeepCopy() *RqliteTableColumn {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataColumnMapping.
+func (in *SeedDataColumnMapping) DeepCopy() *SeedDataColumnMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableColumn)
+	out := new(SeedDataColumnMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableColumnAttributes) DeepCopyInto(out *RqliteTableColumnAttributes) {
+func (in *SeedDataKeyRef) DeepCopyInto(out *SeedDataKeyRef) {
 	*out = *in
-	if in.AutoIncrement != nil {
-		in, out := &in.AutoIncrement, &out.AutoIncrement
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumnAttributes.
-func (in *RqliteTableColumnAttributes) DeepCopy() *RqliteTableColumnAttributes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataKeyRef.
+func (in *SeedDataKeyRef) DeepCopy() *SeedDataKeyRef {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableColumnAttributes)
+	out := new(SeedDataKeyRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableColumnConstraints) DeepCopyInto(out *RqliteTableColumnConstraints) {
+func (in *SeedDataRow) DeepCopyInto(out *SeedDataRow) {
 	*out = *in
-	if in.NotNull != nil {
-		in, out := &in.NotNull, &out.NotNull
-		*out = new(bool)
-		**out = **in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]Column, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableColumnConstraints.
-func (in *RqliteTableColumnConstraints) DeepCopy() *RqliteTableColumnConstraints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataRow.
+func (in *SeedDataRow) DeepCopy() *SeedDataRow {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableColumnConstraints)
+	out := new(SeedDataRow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableForeignKey) DeepCopyInto(out *RqliteTableForeignKey) {
+func (in *SeedDataValue) DeepCopyInto(out *SeedDataValue) {
 	*out = *in
-	if in.Columns != nil {
-		in, out := &in.Columns, &out.Columns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Int != nil {
+		in, out := &in.Int, &out.Int
+		*out = new(int)
+		**out = **in
+	}
+	if in.Str != nil {
+		in, out := &in.Str, &out.Str
+		*out = new(string)
+		**out = **in
+	}
+	if in.Bool != nil {
+		in, out := &in.Bool, &out.Bool
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Float != nil {
+		in, out := &in.Float, &out.Float
+		*out = new(SeedDataFloat)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.Bytes != nil {
+		in, out := &in.Bytes, &out.Bytes
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expr != nil {
+		in, out := &in.Expr, &out.Expr
+		*out = new(string)
+		**out = **in
 	}
-	in.References.DeepCopyInto(&out.References)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableForeignKey.
-func (in *RqliteTableForeignKey) DeepCopy() *RqliteTableForeignKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataValue.
+func (in *SeedDataValue) DeepCopy() *SeedDataValue {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableForeignKey)
+	out := new(SeedDataValue)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableForeignKeyReferences) DeepCopyInto(out *RqliteTableForeignKeyReferences) {
+func (in *SeedDataValueFrom) DeepCopyInto(out *SeedDataValueFrom) {
 	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(SeedDataKeyRef)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SeedDataKeyRef)
+		**out = **in
+	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
-		*out = make([]string, len(*in))
+		*out = make([]SeedDataColumnMapping, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableForeignKeyReferences.
-func (in *RqliteTableForeignKeyReferences) DeepCopy() *RqliteTableForeignKeyReferences {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataValueFrom.
+func (in *SeedDataValueFrom) DeepCopy() *SeedDataValueFrom {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableForeignKeyReferences)
+	out := new(SeedDataValueFrom)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableIndex) DeepCopyInto(out *RqliteTableIndex) {
+func (in *Sequence) DeepCopyInto(out *Sequence) {
 	*out = *in
-	if in.Columns != nil {
-		in, out := &in.Columns, &out.Columns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableIndex.
-func (in *RqliteTableIndex) DeepCopy() *RqliteTableIndex {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sequence.
+func (in *Sequence) DeepCopy() *Sequence {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableIndex)
+	out := new(Sequence)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Sequence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RqliteTableSchema) DeepCopyInto(out *RqliteTableSchema) {
+func (in *SequenceList) DeepCopyInto(out *SequenceList) {
 	*out = *in
-	if in.PrimaryKey != nil {
-		in, out := &in.PrimaryKey, &out.PrimaryKey
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ForeignKeys != nil {
-		in, out := &in.ForeignKeys, &out.ForeignKeys
-		*out = make([]*RqliteTableForeignKey, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RqliteTableForeignKey)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.Indexes != nil {
-		in, out := &in.Indexes, &out.Indexes
-		*out = make([]*RqliteTableIndex, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RqliteTableIndex)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.Columns != nil {
-		in, out := &in.Columns, &out.Columns
-		*out = make([]*RqliteTableColumn, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Sequence, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RqliteTableColumn)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableSchema.
-func (in *RqliteTableSchema) DeepCopy() *RqliteTableSchema {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SequenceList.
+func (in *SequenceList) DeepCopy() *SequenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(RqliteTableSchema)
+	out := new(SequenceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SequenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SeedData) DeepCopyInto(out *SeedData) {
+func (in *SequenceSpec) DeepCopyInto(out *SequenceSpec) {
 	*out = *in
-	if in.Rows != nil {
-		in, out := &in.Rows, &out.Rows
-		*out = make([]SeedDataRow, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(PostgresqlSequenceSchema)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedData.
-func (in *SeedData) DeepCopy() *SeedData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SequenceSpec.
+func (in *SequenceSpec) DeepCopy() *SequenceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SeedData)
+	out := new(SequenceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SeedDataRow) DeepCopyInto(out *SeedDataRow) {
+func (in *SequenceStatus) DeepCopyInto(out *SequenceStatus) {
 	*out = *in
-	if in.Columns != nil {
-		in, out := &in.Columns, &out.Columns
-		*out = make([]Column, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataRow.
-func (in *SeedDataRow) DeepCopy() *SeedDataRow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SequenceStatus.
+func (in *SequenceStatus) DeepCopy() *SequenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SeedDataRow)
+	out := new(SequenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SeedDataValue) DeepCopyInto(out *SeedDataValue) {
+func (in *SqliteTableCheck) DeepCopyInto(out *SqliteTableCheck) {
 	*out = *in
-	if in.Int != nil {
-		in, out := &in.Int, &out.Int
-		*out = new(int)
-		**out = **in
-	}
-	if in.Str != nil {
-		in, out := &in.Str, &out.Str
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataValue.
-func (in *SeedDataValue) DeepCopy() *SeedDataValue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableCheck.
+func (in *SqliteTableCheck) DeepCopy() *SqliteTableCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(SeedDataValue)
+	out := new(SqliteTableCheck)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1498,6 +2608,11 @@ func (in *SqliteTableColumn) DeepCopyInto(out *SqliteTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Generated != nil {
+		in, out := &in.Generated, &out.Generated
+		*out = new(SqliteTableColumnGenerated)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableColumn.
@@ -1550,6 +2665,21 @@ func (in *SqliteTableColumnConstraints) DeepCopy() *SqliteTableColumnConstraints
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SqliteTableColumnGenerated) DeepCopyInto(out *SqliteTableColumnGenerated) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableColumnGenerated.
+func (in *SqliteTableColumnGenerated) DeepCopy() *SqliteTableColumnGenerated {
+	if in == nil {
+		return nil
+	}
+	out := new(SqliteTableColumnGenerated)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SqliteTableForeignKey) DeepCopyInto(out *SqliteTableForeignKey) {
 	*out = *in
@@ -1652,6 +2782,33 @@ func (in *SqliteTableSchema) DeepCopyInto(out *SqliteTableSchema) {
 			}
 		}
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*SqliteTableCheck, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SqliteTableCheck)
+				**out = **in
+			}
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]*SqliteTableTrigger, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SqliteTableTrigger)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Virtual != nil {
+		in, out := &in.Virtual, &out.Virtual
+		*out = new(SqliteTableVirtual)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableSchema.
@@ -1664,6 +2821,51 @@ func (in *SqliteTableSchema) DeepCopy() *SqliteTableSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SqliteTableTrigger) DeepCopyInto(out *SqliteTableTrigger) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(string)
+		**out = **in
+	}
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableTrigger.
+func (in *SqliteTableTrigger) DeepCopy() *SqliteTableTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(SqliteTableTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SqliteTableVirtual) DeepCopyInto(out *SqliteTableVirtual) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableVirtual.
+func (in *SqliteTableVirtual) DeepCopy() *SqliteTableVirtual {
+	if in == nil {
+		return nil
+	}
+	out := new(SqliteTableVirtual)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Table) DeepCopyInto(out *Table) {
 	*out = *in
@@ -1826,6 +3028,11 @@ func (in *TimescaleDBCompression) DeepCopyInto(out *TimescaleDBCompression) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OrderBy != nil {
+		in, out := &in.OrderBy, &out.OrderBy
+		*out = new(string)
+		**out = **in
+	}
 	if in.Interval != nil {
 		in, out := &in.Interval, &out.Interval
 		*out = new(string)
@@ -1843,6 +3050,26 @@ func (in *TimescaleDBCompression) DeepCopy() *TimescaleDBCompression {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimescaleDBContinuousAggregateCompression) DeepCopyInto(out *TimescaleDBContinuousAggregateCompression) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBContinuousAggregateCompression.
+func (in *TimescaleDBContinuousAggregateCompression) DeepCopy() *TimescaleDBContinuousAggregateCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(TimescaleDBContinuousAggregateCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimescaleDBHypertable) DeepCopyInto(out *TimescaleDBHypertable) {
 	*out = *in
@@ -1921,6 +3148,16 @@ func (in *TimescaleDBHypertable) DeepCopyInto(out *TimescaleDBHypertable) {
 		*out = new(TimescaleDBRetention)
 		**out = **in
 	}
+	if in.Reorder != nil {
+		in, out := &in.Reorder, &out.Reorder
+		*out = new(TimescaleDBReorder)
+		**out = **in
+	}
+	if in.Tiering != nil {
+		in, out := &in.Tiering, &out.Tiering
+		*out = new(TimescaleDBTiering)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBHypertable.
@@ -1933,6 +3170,46 @@ func (in *TimescaleDBHypertable) DeepCopy() *TimescaleDBHypertable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimescaleDBRefreshPolicy) DeepCopyInto(out *TimescaleDBRefreshPolicy) {
+	*out = *in
+	if in.StartOffset != nil {
+		in, out := &in.StartOffset, &out.StartOffset
+		*out = new(string)
+		**out = **in
+	}
+	if in.EndOffset != nil {
+		in, out := &in.EndOffset, &out.EndOffset
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBRefreshPolicy.
+func (in *TimescaleDBRefreshPolicy) DeepCopy() *TimescaleDBRefreshPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TimescaleDBRefreshPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimescaleDBReorder) DeepCopyInto(out *TimescaleDBReorder) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBReorder.
+func (in *TimescaleDBReorder) DeepCopy() *TimescaleDBReorder {
+	if in == nil {
+		return nil
+	}
+	out := new(TimescaleDBReorder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimescaleDBRetention) DeepCopyInto(out *TimescaleDBRetention) {
 	*out = *in
@@ -1989,6 +3266,11 @@ func (in *TimescaleDBTableSchema) DeepCopyInto(out *TimescaleDBTableSchema) {
 			}
 		}
 	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]*PostgresqlTableTrigger, len(*in))
@@ -2017,6 +3299,21 @@ func (in *TimescaleDBTableSchema) DeepCopy() *TimescaleDBTableSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimescaleDBTiering) DeepCopyInto(out *TimescaleDBTiering) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBTiering.
+func (in *TimescaleDBTiering) DeepCopy() *TimescaleDBTiering {
+	if in == nil {
+		return nil
+	}
+	out := new(TimescaleDBTiering)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimescaleDBViewSchema) DeepCopyInto(out *TimescaleDBViewSchema) {
 	*out = *in
@@ -2030,6 +3327,21 @@ func (in *TimescaleDBViewSchema) DeepCopyInto(out *TimescaleDBViewSchema) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MaterializedOnly != nil {
+		in, out := &in.MaterializedOnly, &out.MaterializedOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RefreshPolicy != nil {
+		in, out := &in.RefreshPolicy, &out.RefreshPolicy
+		*out = new(TimescaleDBRefreshPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(TimescaleDBContinuousAggregateCompression)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBViewSchema.
@@ -2136,8 +3448,8 @@ func (in *ViewSchema) DeepCopyInto(out *ViewSchema) {
 	}
 	if in.Cassandra != nil {
 		in, out := &in.Cassandra, &out.Cassandra
-		*out = new(NotImplementedViewSchema)
-		**out = **in
+		*out = new(CassandraViewSchema)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -10,8 +10,12 @@ import (
 	"github.com/schemahero/schemahero/pkg/config"
 	databasecontroller "github.com/schemahero/schemahero/pkg/controller/database"
 	databaseextensioncontroller "github.com/schemahero/schemahero/pkg/controller/databaseextension"
+	databaseschemacontroller "github.com/schemahero/schemahero/pkg/controller/databaseschema"
+	datatypecontroller "github.com/schemahero/schemahero/pkg/controller/datatype"
 	functioncontroller "github.com/schemahero/schemahero/pkg/controller/function"
+	grantcontroller "github.com/schemahero/schemahero/pkg/controller/grant"
 	migrationcontroller "github.com/schemahero/schemahero/pkg/controller/migration"
+	sequencecontroller "github.com/schemahero/schemahero/pkg/controller/sequence"
 	tablecontroller "github.com/schemahero/schemahero/pkg/controller/table"
 	viewcontroller "github.com/schemahero/schemahero/pkg/controller/view"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
@@ -137,6 +141,21 @@ func RunCmd() *cobra.Command {
 					os.Exit(1)
 				}
 
+				if err := grantcontroller.Add(mgr, v.GetStringSlice("database-name")); err != nil {
+					logger.Error(err)
+					os.Exit(1)
+				}
+
+				if err := databaseschemacontroller.Add(mgr, v.GetStringSlice("database-name")); err != nil {
+					logger.Error(err)
+					os.Exit(1)
+				}
+
+				if err := sequencecontroller.Add(mgr, v.GetStringSlice("database-name")); err != nil {
+					logger.Error(err)
+					os.Exit(1)
+				}
+
 				if err := migrationcontroller.Add(mgr, v.GetStringSlice("database-name")); err != nil {
 					logger.Error(err)
 					os.Exit(1)
@@ -151,6 +170,11 @@ func RunCmd() *cobra.Command {
 					logger.Error(err)
 					os.Exit(1)
 				}
+
+				if err := datatypecontroller.Add(mgr); err != nil {
+					logger.Error(err)
+					os.Exit(1)
+				}
 			}
 
 			if err := webhook.AddToManager(mgr); err != nil {
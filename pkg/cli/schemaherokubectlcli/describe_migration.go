@@ -88,6 +88,13 @@ func DescribeMigrationCmd() *cobra.Command {
 					time.Unix(foundMigration.Status.PlannedAt, 0).Format(time.RFC3339),
 					foundMigration.Spec.GeneratedDDL)
 
+				if len(foundMigration.Spec.Warnings) > 0 {
+					fmt.Println("\nWarnings:")
+					for _, warning := range foundMigration.Spec.Warnings {
+						fmt.Printf("  %s\n", warning)
+					}
+				}
+
 				// Display status information
 				fmt.Printf("\nStatus: %s\n", foundMigration.Status.Phase)
 				if foundMigration.Status.ApprovedAt > 0 {
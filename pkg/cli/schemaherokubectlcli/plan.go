@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/database/seeddata"
 	"github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/files"
 	"github.com/spf13/cobra"
@@ -134,6 +135,7 @@ func PlanCmd() *cobra.Command {
 				db.SortSpecs(specsFromFiles)
 
 				for _, spec := range specsFromFiles {
+					db.SeedDataSource = seeddata.FileSource(filepath.Dir(spec.SourceFilename))
 					statements, err := db.PlanSync(spec.Spec, v.GetString("spec-type"))
 					if err != nil {
 						return fmt.Errorf("plan sync from file %q: %w", spec.SourceFilename, err)
@@ -150,10 +152,12 @@ func PlanCmd() *cobra.Command {
 							fmt.Printf("%s;\n", statement)
 						}
 					}
+					printWarnings(statements)
 				}
 
 				return nil
 			} else {
+				db.SeedDataSource = seeddata.FileSource(filepath.Dir(v.GetString("spec-file")))
 				statements, err := db.PlanSyncFromFile(v.GetString("spec-file"), v.GetString("spec-type"))
 				if err != nil {
 					return fmt.Errorf("plan sync from file %q: %w", v.GetString("spec-file"), err)
@@ -170,6 +174,7 @@ func PlanCmd() *cobra.Command {
 						fmt.Printf("%s;\n", statement)
 					}
 				}
+				printWarnings(statements)
 
 				return nil
 			}
@@ -186,10 +191,18 @@ func PlanCmd() *cobra.Command {
 	cmd.Flags().String("keyspace", "", "the keyspace to use for databases that support keyspaces")
 
 	cmd.Flags().String("spec-file", "", "filename or directory name containing the spec(s) to apply")
-	cmd.Flags().String("spec-type", "table", "type of spec in spec-file (table, view, function, or extension)")
+	cmd.Flags().String("spec-type", "table", "type of spec in spec-file (table, view, function, extension, grant, schema, or sequence)")
 	cmd.Flags().String("out", "", "filename to write DDL statements to, if not present output file be written to stdout")
 	cmd.Flags().Bool("overwrite", true, "when set, will overwrite the out file, if it already exists")
 
-	cmd.Flags().Bool("seed-data", false, "when set, will deploy seed data")
+	cmd.Flags().Bool("seed-data", false, "when set, will deploy seed data, reading the files of seed data valueFrom relative to the spec file")
 	return cmd
 }
+
+// printWarnings writes the actions that need to be taken after the statements are applied to stderr,
+// so that they are not part of the planned statements
+func printWarnings(statements []string) {
+	for _, warning := range types.MigrationWarnings(statements) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
@@ -36,14 +36,26 @@ func (c *FakeSchemasV1alpha4) DatabaseExtensions(namespace string) v1alpha4.Data
 	return &FakeDatabaseExtensions{c, namespace}
 }
 
+func (c *FakeSchemasV1alpha4) DatabaseSchemas(namespace string) v1alpha4.DatabaseSchemaInterface {
+	return &FakeDatabaseSchemas{c, namespace}
+}
+
 func (c *FakeSchemasV1alpha4) Functions(namespace string) v1alpha4.FunctionInterface {
 	return &FakeFunctions{c, namespace}
 }
 
+func (c *FakeSchemasV1alpha4) Grants(namespace string) v1alpha4.GrantInterface {
+	return &FakeGrants{c, namespace}
+}
+
 func (c *FakeSchemasV1alpha4) Migrations(namespace string) v1alpha4.MigrationInterface {
 	return &FakeMigrations{c, namespace}
 }
 
+func (c *FakeSchemasV1alpha4) Sequences(namespace string) v1alpha4.SequenceInterface {
+	return &FakeSequences{c, namespace}
+}
+
 func (c *FakeSchemasV1alpha4) Tables(namespace string) v1alpha4.TableInterface {
 	return &FakeTables{c, namespace}
 }
@@ -22,10 +22,16 @@ type DataTypeExpansion interface{}
 
 type DatabaseExtensionExpansion interface{}
 
+type DatabaseSchemaExpansion interface{}
+
 type FunctionExpansion interface{}
 
+type GrantExpansion interface{}
+
 type MigrationExpansion interface{}
 
+type SequenceExpansion interface{}
+
 type TableExpansion interface{}
 
 type ViewExpansion interface{}
@@ -30,8 +30,11 @@ type SchemasV1alpha4Interface interface {
 	RESTClient() rest.Interface
 	DataTypesGetter
 	DatabaseExtensionsGetter
+	DatabaseSchemasGetter
 	FunctionsGetter
+	GrantsGetter
 	MigrationsGetter
+	SequencesGetter
 	TablesGetter
 	ViewsGetter
 }
@@ -49,14 +52,26 @@ func (c *SchemasV1alpha4Client) DatabaseExtensions(namespace string) DatabaseExt
 	return newDatabaseExtensions(c, namespace)
 }
 
+func (c *SchemasV1alpha4Client) DatabaseSchemas(namespace string) DatabaseSchemaInterface {
+	return newDatabaseSchemas(c, namespace)
+}
+
 func (c *SchemasV1alpha4Client) Functions(namespace string) FunctionInterface {
 	return newFunctions(c, namespace)
 }
 
+func (c *SchemasV1alpha4Client) Grants(namespace string) GrantInterface {
+	return newGrants(c, namespace)
+}
+
 func (c *SchemasV1alpha4Client) Migrations(namespace string) MigrationInterface {
 	return newMigrations(c, namespace)
 }
 
+func (c *SchemasV1alpha4Client) Sequences(namespace string) SequenceInterface {
+	return newSequences(c, namespace)
+}
+
 func (c *SchemasV1alpha4Client) Tables(namespace string) TableInterface {
 	return newTables(c, namespace)
 }
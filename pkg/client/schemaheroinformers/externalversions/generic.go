@@ -62,10 +62,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().DataTypes().Informer()}, nil
 	case schemasv1alpha4.SchemeGroupVersion.WithResource("databaseextensions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().DatabaseExtensions().Informer()}, nil
+	case schemasv1alpha4.SchemeGroupVersion.WithResource("databaseschemas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().DatabaseSchemas().Informer()}, nil
 	case schemasv1alpha4.SchemeGroupVersion.WithResource("functions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().Functions().Informer()}, nil
+	case schemasv1alpha4.SchemeGroupVersion.WithResource("grants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().Grants().Informer()}, nil
 	case schemasv1alpha4.SchemeGroupVersion.WithResource("migrations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().Migrations().Informer()}, nil
+	case schemasv1alpha4.SchemeGroupVersion.WithResource("sequences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().Sequences().Informer()}, nil
 	case schemasv1alpha4.SchemeGroupVersion.WithResource("tables"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Schemas().V1alpha4().Tables().Informer()}, nil
 	case schemasv1alpha4.SchemeGroupVersion.WithResource("views"):
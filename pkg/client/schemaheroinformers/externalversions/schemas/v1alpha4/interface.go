@@ -28,10 +28,16 @@ type Interface interface {
 	DataTypes() DataTypeInformer
 	// DatabaseExtensions returns a DatabaseExtensionInformer.
 	DatabaseExtensions() DatabaseExtensionInformer
+	// DatabaseSchemas returns a DatabaseSchemaInformer.
+	DatabaseSchemas() DatabaseSchemaInformer
 	// Functions returns a FunctionInformer.
 	Functions() FunctionInformer
+	// Grants returns a GrantInformer.
+	Grants() GrantInformer
 	// Migrations returns a MigrationInformer.
 	Migrations() MigrationInformer
+	// Sequences returns a SequenceInformer.
+	Sequences() SequenceInformer
 	// Tables returns a TableInformer.
 	Tables() TableInformer
 	// Views returns a ViewInformer.
@@ -59,16 +65,31 @@ func (v *version) DatabaseExtensions() DatabaseExtensionInformer {
 	return &databaseExtensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DatabaseSchemas returns a DatabaseSchemaInformer.
+func (v *version) DatabaseSchemas() DatabaseSchemaInformer {
+	return &databaseSchemaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Functions returns a FunctionInformer.
 func (v *version) Functions() FunctionInformer {
 	return &functionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Grants returns a GrantInformer.
+func (v *version) Grants() GrantInformer {
+	return &grantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Migrations returns a MigrationInformer.
 func (v *version) Migrations() MigrationInformer {
 	return &migrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Sequences returns a SequenceInformer.
+func (v *version) Sequences() SequenceInformer {
+	return &sequenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Tables returns a TableInformer.
 func (v *version) Tables() TableInformer {
 	return &tableInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -34,6 +34,14 @@ type DatabaseExtensionListerExpansion interface{}
 // DatabaseExtensionNamespaceLister.
 type DatabaseExtensionNamespaceListerExpansion interface{}
 
+// DatabaseSchemaListerExpansion allows custom methods to be added to
+// DatabaseSchemaLister.
+type DatabaseSchemaListerExpansion interface{}
+
+// DatabaseSchemaNamespaceListerExpansion allows custom methods to be added to
+// DatabaseSchemaNamespaceLister.
+type DatabaseSchemaNamespaceListerExpansion interface{}
+
 // FunctionListerExpansion allows custom methods to be added to
 // FunctionLister.
 type FunctionListerExpansion interface{}
@@ -42,6 +50,14 @@ type FunctionListerExpansion interface{}
 // FunctionNamespaceLister.
 type FunctionNamespaceListerExpansion interface{}
 
+// GrantListerExpansion allows custom methods to be added to
+// GrantLister.
+type GrantListerExpansion interface{}
+
+// GrantNamespaceListerExpansion allows custom methods to be added to
+// GrantNamespaceLister.
+type GrantNamespaceListerExpansion interface{}
+
 // MigrationListerExpansion allows custom methods to be added to
 // MigrationLister.
 type MigrationListerExpansion interface{}
@@ -50,6 +66,14 @@ type MigrationListerExpansion interface{}
 // MigrationNamespaceLister.
 type MigrationNamespaceListerExpansion interface{}
 
+// SequenceListerExpansion allows custom methods to be added to
+// SequenceLister.
+type SequenceListerExpansion interface{}
+
+// SequenceNamespaceListerExpansion allows custom methods to be added to
+// SequenceNamespaceLister.
+type SequenceNamespaceListerExpansion interface{}
+
 // TableListerExpansion allows custom methods to be added to
 // TableLister.
 type TableListerExpansion interface{}
@@ -179,6 +179,16 @@ func (r *ReconcileDatabase) reconcileRBACRole(ctx context.Context, databaseInsta
 					Resources: []string{"functions/status"},
 					Verbs:     metav1.Verbs{"get", "update", "patch"},
 				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"grants"},
+					Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"grants/status"},
+					Verbs:     metav1.Verbs{"get", "update", "patch"},
+				},
 				{
 					APIGroups: []string{"schemas.schemahero.io"},
 					Resources: []string{"databaseextensions"},
@@ -199,6 +209,26 @@ func (r *ReconcileDatabase) reconcileRBACRole(ctx context.Context, databaseInsta
 					Resources: []string{"datatypes/status"},
 					Verbs:     metav1.Verbs{"get", "update", "patch"},
 				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"databaseschemas"},
+					Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"databaseschemas/status"},
+					Verbs:     metav1.Verbs{"get", "update", "patch"},
+				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"sequences"},
+					Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+				},
+				{
+					APIGroups: []string{"schemas.schemahero.io"},
+					Resources: []string{"sequences/status"},
+					Verbs:     metav1.Verbs{"get", "update", "patch"},
+				},
 			},
 		}
 
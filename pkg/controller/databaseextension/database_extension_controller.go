@@ -220,6 +220,13 @@ func (r *ReconcileDatabaseExtension) Reconcile(ctx context.Context, request reco
 	// Use PlanExtensionSchema to create statements
 	statements, err := conn.PlanExtensionSchema(databaseExtension.Spec.Postgres.Name, databaseExtension.Spec.Postgres)
 	if err != nil {
+		// the requested version may not be available on the server
+		databaseExtension.Status.Phase = "Failed"
+		databaseExtension.Status.Message = err.Error()
+		if updateErr := r.Status().Update(ctx, databaseExtension); updateErr != nil {
+			logger.Error(updateErr)
+			return reconcile.Result{}, updateErr
+		}
 		logger.Error(err)
 		return reconcile.Result{}, err
 	}
@@ -0,0 +1,58 @@
+package databaseschema
+
+import (
+	"testing"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkDatabaseTypeMatches(t *testing.T) {
+	postgres := &schemasv1alpha4.DatabaseSchemaSpec{Postgres: &schemasv1alpha4.PostgresqlDatabaseSchema{}}
+	keyspace := &schemasv1alpha4.DatabaseSchemaSpec{Cassandra: &schemasv1alpha4.CassandraKeyspaceSchema{}}
+
+	tests := []struct {
+		name       string
+		connection databasesv1alpha4.DatabaseConnection
+		spec       *schemasv1alpha4.DatabaseSchemaSpec
+		expect     bool
+	}{
+		{
+			name:       "postgres schema",
+			connection: databasesv1alpha4.DatabaseConnection{Postgres: &databasesv1alpha4.PostgresConnection{}},
+			spec:       postgres,
+			expect:     true,
+		},
+		{
+			name:       "timescaledb schema",
+			connection: databasesv1alpha4.DatabaseConnection{TimescaleDB: &databasesv1alpha4.PostgresConnection{}},
+			spec:       postgres,
+			expect:     true,
+		},
+		{
+			name:       "cassandra keyspace",
+			connection: databasesv1alpha4.DatabaseConnection{Cassandra: &databasesv1alpha4.CassandraConnection{}},
+			spec:       keyspace,
+			expect:     true,
+		},
+		{
+			name:       "keyspace on postgres",
+			connection: databasesv1alpha4.DatabaseConnection{Postgres: &databasesv1alpha4.PostgresConnection{}},
+			spec:       keyspace,
+			expect:     false,
+		},
+		{
+			name:       "mysql",
+			connection: databasesv1alpha4.DatabaseConnection{Mysql: &databasesv1alpha4.MysqlConnection{}},
+			spec:       postgres,
+			expect:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, checkDatabaseTypeMatches(&test.connection, test.spec))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2019 The SchemaHero Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datatype
+
+import (
+	"context"
+	"time"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"go.uber.org/zap"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileDataType{Client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("datatype-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), &schemasv1alpha4.DataType{}, &handler.TypedEnqueueRequestForObject[*schemasv1alpha4.DataType]{}))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileDataType{}
+
+type ReconcileDataType struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// dataTypeSchemaForDriver returns the schema of the type for the database driver, or nil when
+// the type is not configured for the driver
+func dataTypeSchemaForDriver(driver string, dataType *schemasv1alpha4.DataType) interface{} {
+	if dataType.Spec.Schema == nil {
+		return nil
+	}
+
+	switch driver {
+	case "cassandra":
+		if dataType.Spec.Schema.Cassandra != nil {
+			return dataType.Spec.Schema.Cassandra
+		}
+	}
+
+	return nil
+}
+
+func (r *ReconcileDataType) getDatabaseFromDataType(ctx context.Context, dataType *schemasv1alpha4.DataType) (*databasesv1alpha4.Database, error) {
+	database := &databasesv1alpha4.Database{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      dataType.Spec.Database,
+		Namespace: dataType.Namespace,
+	}, database)
+	if err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+// setFailed records the error in the status of the type so that a type that cannot be
+// planned or applied, such as one that is still in use when it is dropped, is visible
+func (r *ReconcileDataType) setFailed(ctx context.Context, dataType *schemasv1alpha4.DataType, err error) error {
+	dataType.Status.Phase = "Failed"
+	dataType.Status.Message = err.Error()
+	if updateErr := r.Status().Update(ctx, dataType); updateErr != nil {
+		logger.Error(updateErr)
+		return updateErr
+	}
+	logger.Error(err)
+	return err
+}
+
+// Reconcile reads that state of the cluster for a DataType object and makes changes based on the state read
+// and what is in the DataType.Spec
+// +kubebuilder:rbac:groups=schemas.schemahero.io,resources=datatypes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=schemas.schemahero.io,resources=datatypes/status,verbs=get;update;patch
+func (r *ReconcileDataType) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger.Debug("reconciling datatype",
+		zap.String("kind", "datatype"),
+		zap.String("name", request.Name),
+		zap.String("namespace", request.Namespace))
+
+	dataType := &schemasv1alpha4.DataType{}
+	err := r.Get(ctx, request.NamespacedName, dataType)
+	if err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !dataType.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	dbInstance, err := r.getDatabaseFromDataType(ctx, dataType)
+	if err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			logger.Debug("database not found, requeuing", zap.String("database", dataType.Spec.Database))
+			return reconcile.Result{
+				Requeue:      true,
+				RequeueAfter: time.Second * 10,
+			}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	driver, connectionURI, err := dbInstance.GetConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, r.setFailed(ctx, dataType, err)
+	}
+
+	dataTypeSchema := dataTypeSchemaForDriver(driver, dataType)
+	if dataTypeSchema == nil {
+		logger.Debug("no type specified for the database driver, skipping", zap.String("driver", driver))
+		return reconcile.Result{}, nil
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	// Set plugin manager for automatic plugin downloading
+	db.SetPluginManager(plugin.GetGlobalPluginManager())
+
+	// Get a connection to plan the type changes
+	conn, err := db.GetConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, r.setFailed(ctx, dataType, err)
+	}
+	defer conn.Close()
+
+	statements, err := conn.PlanTypeSchema(dataType.Spec.Name, dataTypeSchema)
+	if err != nil {
+		return reconcile.Result{}, r.setFailed(ctx, dataType, err)
+	}
+
+	if err := db.ApplySync(statements); err != nil {
+		return reconcile.Result{}, r.setFailed(ctx, dataType, err)
+	}
+
+	dataType.Status.Phase = "Applied"
+	dataType.Status.AppliedAt = time.Now().Unix()
+	dataType.Status.Message = "DataType successfully applied"
+
+	if err := r.Status().Update(ctx, dataType); err != nil {
+		logger.Error(err)
+		return reconcile.Result{}, err
+	}
+
+	logger.Debug("datatype successfully applied")
+	return reconcile.Result{}, nil
+}
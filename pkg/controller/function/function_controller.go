@@ -85,10 +85,12 @@ func (r *ReconcileFunction) dropFunction(ctx context.Context, function *schemasv
 		return err
 	}
 
-	if driver != "postgres" || function.Spec.Schema.Postgres == nil {
-		logger.Debug("doing nothing since the function is not configured for Postgres",
+	functionSchema := deletedFunctionSchema(driver, function)
+	if functionSchema == nil {
+		logger.Debug("doing nothing since the function is not configured for the database driver",
 			zap.String("name", function.Name),
-			zap.String("namespace", function.Namespace))
+			zap.String("namespace", function.Namespace),
+			zap.String("driver", driver))
 		return nil
 	}
 
@@ -107,17 +109,6 @@ func (r *ReconcileFunction) dropFunction(ctx context.Context, function *schemasv
 	}
 	defer conn.Close()
 
-	// Mark function as deleted for drop operation
-	functionSchema := &schemasv1alpha4.PostgresqlFunctionSchema{
-		Schema:    function.Spec.Schema.Postgres.Schema,
-		Lang:      function.Spec.Schema.Postgres.Lang,
-		Params:    function.Spec.Schema.Postgres.Params,
-		ReturnSet: function.Spec.Schema.Postgres.ReturnSet,
-		Return:    function.Spec.Schema.Postgres.Return,
-		As:        function.Spec.Schema.Postgres.As,
-		IsDeleted: true, // Special marker for drop operation
-	}
-
 	statements, err := conn.PlanFunctionSchema(function.Spec.Name, functionSchema)
 	if err != nil {
 		return err
@@ -126,6 +117,43 @@ func (r *ReconcileFunction) dropFunction(ctx context.Context, function *schemasv
 	return db.ApplySync(statements)
 }
 
+// functionSchemaForDriver returns the schema of the function for the database driver, or nil when
+// the function is not configured for the driver
+func functionSchemaForDriver(driver string, function *schemasv1alpha4.Function) interface{} {
+	if function.Spec.Schema == nil {
+		return nil
+	}
+
+	switch driver {
+	case "postgres":
+		if function.Spec.Schema.Postgres != nil {
+			return function.Spec.Schema.Postgres
+		}
+	case "mysql":
+		if function.Spec.Schema.Mysql != nil {
+			return function.Spec.Schema.Mysql
+		}
+	}
+
+	return nil
+}
+
+// deletedFunctionSchema returns a copy of the function schema for the database driver that is marked for deletion
+func deletedFunctionSchema(driver string, function *schemasv1alpha4.Function) interface{} {
+	switch functionSchema := functionSchemaForDriver(driver, function).(type) {
+	case *schemasv1alpha4.PostgresqlFunctionSchema:
+		deleted := functionSchema.DeepCopy()
+		deleted.IsDeleted = true
+		return deleted
+	case *schemasv1alpha4.MysqlFunctionSchema:
+		deleted := functionSchema.DeepCopy()
+		deleted.IsDeleted = true
+		return deleted
+	}
+
+	return nil
+}
+
 func (r *ReconcileFunction) getDatabaseFromFunction(ctx context.Context, function *schemasv1alpha4.Function) (*databasesv1alpha4.Database, error) {
 	database := &databasesv1alpha4.Database{}
 	err := r.Get(ctx, types.NamespacedName{
@@ -205,8 +233,9 @@ func (r *ReconcileFunction) Reconcile(ctx context.Context, request reconcile.Req
 		return reconcile.Result{}, err
 	}
 
-	if driver != "postgres" || function.Spec.Schema.Postgres == nil {
-		logger.Debug("not a postgres database or no postgres function specified, skipping")
+	functionSchema := functionSchemaForDriver(driver, function)
+	if functionSchema == nil {
+		logger.Debug("no function specified for the database driver, skipping", zap.String("driver", driver))
 		return reconcile.Result{}, nil
 	}
 
@@ -227,7 +256,7 @@ func (r *ReconcileFunction) Reconcile(ctx context.Context, request reconcile.Req
 	defer conn.Close()
 
 	// Use PlanFunctionSchema to create statements
-	statements, err := conn.PlanFunctionSchema(function.Spec.Name, function.Spec.Schema.Postgres)
+	statements, err := conn.PlanFunctionSchema(function.Spec.Name, functionSchema)
 	if err != nil {
 		logger.Error(err)
 		return reconcile.Result{}, err
@@ -0,0 +1,155 @@
+/*
+Copyright 2019 The SchemaHero Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"go.uber.org/zap"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new Grant Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, databaseNames []string) error {
+	return add(mgr, newReconciler(databaseNames, mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(databaseNames []string, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileGrant{
+		Client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		databaseNames: databaseNames,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("grant-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Grant
+	err = c.Watch(source.Kind(mgr.GetCache(), &schemasv1alpha4.Grant{}, &handler.TypedEnqueueRequestForObject[*schemasv1alpha4.Grant]{}))
+	if err != nil {
+		return errors.Wrap(err, "failed to start watch on grants")
+	}
+
+	return nil
+}
+
+// finalizerName is added to grants with revokeOnDeletion so that the privileges are revoked before the grant is removed
+const finalizerName = "grants.schemas.schemahero.io/finalizer"
+
+var _ reconcile.Reconciler = &ReconcileGrant{}
+
+// ReconcileGrant reconciles a Grant object
+type ReconcileGrant struct {
+	client.Client
+	scheme        *runtime.Scheme
+	databaseNames []string
+}
+
+// Reconcile reads that state of the cluster for a Grant object and makes changes based on the state read
+// and what is in the Grant.Spec
+// +kubebuilder:rbac:groups=schemas.schemahero.io,resources=grants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=schemas.schemahero.io,resources=grants/status,verbs=get;update;patch
+func (r *ReconcileGrant) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	instance, err := r.getInstance(request)
+	if err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	isThisController, err := r.isGrantManagedByThisController(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !isThisController {
+		logger.Debug("grant instance is not managed by this controller",
+			zap.String("grant", instance.Name),
+			zap.Strings("databaseNames", r.databaseNames))
+		return reconcile.Result{}, nil
+	}
+
+	if !instance.ObjectMeta.DeletionTimestamp.IsZero() {
+		if slices.Contains(instance.ObjectMeta.Finalizers, finalizerName) {
+			if instance.Spec.RevokeOnDeletion {
+				if err := r.revokeGrant(ctx, instance); err != nil {
+					logger.Error(err)
+					return reconcile.Result{}, err
+				}
+			}
+
+			instance.ObjectMeta.Finalizers = slices.DeleteFunc(instance.ObjectMeta.Finalizers, func(s string) bool {
+				return s == finalizerName
+			})
+			if err := r.Update(ctx, instance); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.RevokeOnDeletion && !slices.Contains(instance.ObjectMeta.Finalizers, finalizerName) {
+		instance.ObjectMeta.Finalizers = append(instance.ObjectMeta.Finalizers, finalizerName)
+		if err := r.Update(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcileGrant(ctx, instance)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return result, err
+}
+
+func (r *ReconcileGrant) isGrantManagedByThisController(instance *schemasv1alpha4.Grant) (bool, error) {
+	databaseName := instance.Spec.Database
+
+	for _, managedDatabaseName := range r.databaseNames {
+		if managedDatabaseName == databaseName {
+			return true, nil
+		}
+
+		if managedDatabaseName == "*" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,295 @@
+package grant
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	databasesclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/databases/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/config"
+	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"go.uber.org/zap"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileGrant is called after filtering events that are not relevant to this
+// controller. this function is the main reconcile loop for the grant type
+func (r *ReconcileGrant) reconcileGrant(ctx context.Context, instance *schemasv1alpha4.Grant) (reconcile.Result, error) {
+	logger.Debug("reconciling grant",
+		zap.String("kind", instance.Kind),
+		zap.String("name", instance.Name),
+		zap.String("database", instance.Spec.Database),
+		zap.String("lastPlannedGrantSpecSHA", instance.Status.LastPlannedGrantSpecSHA))
+
+	// early exit if the sha of the spec hasn't changed
+	currentGrantSpecSHA, err := instance.GetSHA()
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get instance sha")
+	}
+	if instance.Status.LastPlannedGrantSpecSHA == currentGrantSpecSHA {
+		return reconcile.Result{}, nil
+	}
+
+	// get the full database spec from the api
+	database, err := r.getDatabaseInstance(ctx, instance.Namespace, instance.Spec.Database)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get database spec")
+	}
+
+	// the database object might not yet exist
+	// this can happen if the grant was deployed at the same time or before the database object
+	if database == nil {
+		logger.Debug("requeuing grant reconcile request for 10 seconds because database instance was not present",
+			zap.String("database.name", instance.Spec.Database),
+			zap.String("database.namespace", instance.Namespace))
+
+		return reconcile.Result{
+			Requeue:      true,
+			RequeueAfter: time.Second * 10,
+		}, nil
+	}
+
+	if instance.Spec.Schema == nil || !checkDatabaseTypeMatches(&database.Spec.Connection, instance.Spec.Schema) {
+		return reconcile.Result{}, errors.New("unable to deploy grant to connection of different type")
+	}
+
+	// look for an already calculated migration for this grant
+	var existingMigration schemasv1alpha4.Migration
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      currentGrantSpecSHA[:7],
+		Namespace: instance.Namespace,
+	}, &existingMigration)
+	if err == nil {
+		// a migration has already been queued for this exact spec
+		return reconcile.Result{}, nil
+	} else if !kuberneteserrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get existing migration")
+	}
+
+	// at this point, we need to execute a plan
+	return r.plan(ctx, database, instance)
+}
+
+func (r *ReconcileGrant) getInstance(request reconcile.Request) (*schemasv1alpha4.Grant, error) {
+	v1alpha4instance := &schemasv1alpha4.Grant{}
+	err := r.Get(context.Background(), request.NamespacedName, v1alpha4instance)
+	if err != nil {
+		return nil, err // don't wrap
+	}
+
+	return v1alpha4instance, nil
+}
+
+func (r *ReconcileGrant) getDatabaseInstance(ctx context.Context, namespace string, name string) (*databasesv1alpha4.Database, error) {
+	logger.Debug("getting database spec",
+		zap.String("namespace", namespace),
+		zap.String("name", name))
+
+	cfg, err := config.GetRESTConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+	databasesClient, err := databasesclientv1alpha4.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databasesclient")
+	}
+
+	database, err := databasesClient.Databases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		// grants might be deployed before a database... if this is the case
+		// we don't want to crash, we want to re-reconcile later
+		if kuberneteserrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to get database object")
+	}
+
+	return database, nil
+}
+
+func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, grantSchema *schemasv1alpha4.GrantSchema) bool {
+	if connection.Postgres != nil {
+		return grantSchema.Postgres != nil
+	} else if connection.Mysql != nil {
+		return grantSchema.Mysql != nil
+	} else if connection.CockroachDB != nil {
+		return grantSchema.CockroachDB != nil
+	} else if connection.RQLite != nil {
+		return grantSchema.RQLite != nil
+	} else if connection.TimescaleDB != nil {
+		return grantSchema.TimescaleDB != nil
+	} else if connection.SQLite != nil {
+		return grantSchema.SQLite != nil
+	} else if connection.Cassandra != nil {
+		return grantSchema.Cassandra != nil
+	}
+
+	return false
+}
+
+// plan will connect to the database and generate a migration spec, deploying the
+// migration object
+func (r *ReconcileGrant) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, grantInstance *schemasv1alpha4.Grant) (reconcile.Result, error) {
+	logger.Debug("planning migration",
+		zap.String("databaseName", databaseInstance.Name),
+		zap.String("grantName", grantInstance.Name))
+
+	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	// Set plugin manager for automatic plugin downloading
+	db.SetPluginManager(plugin.GetGlobalPluginManager())
+
+	statements, err := db.PlanSyncGrantSpec(&grantInstance.Spec)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to plan migration for grant %s", grantInstance.Name)
+		grantInstance.Status.PlanError = err.Error()
+		if updateErr := r.Status().Update(ctx, grantInstance); updateErr != nil {
+			logger.Error(updateErr)
+		}
+		return reconcile.Result{}, err
+	}
+
+	grantSpecSHA, err := grantInstance.GetSHA()
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get sha of grant")
+	}
+
+	if len(statements) == 0 {
+		logger.Info("grant is already in sync with the desired privileges, no migration needed",
+			zap.String("databaseName", databaseInstance.Name),
+			zap.String("grantName", grantInstance.Name))
+
+		return reconcile.Result{}, r.updateLastPlannedSHA(ctx, grantInstance, grantSpecSHA)
+	}
+
+	migration := schemasv1alpha4.Migration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "schemas.schemahero.io/v1alpha4",
+			Kind:       "Migration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      grantSpecSHA[:7],
+			Namespace: grantInstance.Namespace,
+		},
+		Spec: schemasv1alpha4.MigrationSpec{
+			GeneratedDDL:   strings.Join(statements, ";\n"),
+			DatabaseName:   grantInstance.Spec.Database,
+			TableName:      grantInstance.Name,
+			TableNamespace: grantInstance.Namespace,
+		},
+		Status: schemasv1alpha4.MigrationStatus{
+			PlannedAt: time.Now().Unix(),
+			Phase:     schemasv1alpha4.Planned,
+		},
+	}
+
+	if databaseInstance.Spec.ImmediateDeploy {
+		migration.Status.ApprovedAt = time.Now().Unix()
+	}
+
+	if err := controllerutil.SetControllerReference(grantInstance, &migration, r.scheme); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to set owner on migration")
+	}
+
+	if err := r.Create(ctx, &migration); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to create migration resource")
+	}
+
+	return reconcile.Result{}, r.updateLastPlannedSHA(ctx, grantInstance, grantSpecSHA)
+}
+
+// updateLastPlannedSHA records the planned spec so that the grant is not planned again on startup
+func (r *ReconcileGrant) updateLastPlannedSHA(ctx context.Context, grantInstance *schemasv1alpha4.Grant, grantSpecSHA string) error {
+	grantInstance.Status.LastPlannedGrantSpecSHA = grantSpecSHA
+	grantInstance.Status.PlanError = ""
+	if err := r.Status().Update(ctx, grantInstance); err != nil {
+		return errors.Wrap(err, "failed to update grant status")
+	}
+
+	return nil
+}
+
+// revokeGrant plans the grant as deleted and applies the revoke directly, because the migration
+// would be garbage collected along with the grant that owns it
+func (r *ReconcileGrant) revokeGrant(ctx context.Context, grantInstance *schemasv1alpha4.Grant) error {
+	databaseInstance, err := r.getDatabaseInstance(ctx, grantInstance.Namespace, grantInstance.Spec.Database)
+	if err != nil {
+		return errors.Wrap(err, "failed to get database spec")
+	}
+	if databaseInstance == nil {
+		// there is nothing left to revoke the privileges from
+		return nil
+	}
+
+	deletedSpec := deletedGrantSpec(&grantInstance.Spec)
+	if deletedSpec == nil {
+		return nil
+	}
+
+	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection details for database")
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	// Set plugin manager for automatic plugin downloading
+	db.SetPluginManager(plugin.GetGlobalPluginManager())
+
+	statements, err := db.PlanSyncGrantSpec(deletedSpec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to plan revoke for grant %s", grantInstance.Name)
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+
+	if err := db.ApplySync(statements); err != nil {
+		return errors.Wrapf(err, "failed to revoke grant %s", grantInstance.Name)
+	}
+
+	return nil
+}
+
+// deletedGrantSpec returns a copy of the grant spec with the privileges marked for deletion, or nil
+// when the grant has no schema that can be revoked
+func deletedGrantSpec(spec *schemasv1alpha4.GrantSpec) *schemasv1alpha4.GrantSpec {
+	if spec.Schema == nil {
+		return nil
+	}
+
+	deleted := spec.DeepCopy()
+	switch {
+	case deleted.Schema.Postgres != nil:
+		deleted.Schema.Postgres.IsDeleted = true
+	case deleted.Schema.Mysql != nil:
+		deleted.Schema.Mysql.IsDeleted = true
+	case deleted.Schema.TimescaleDB != nil:
+		deleted.Schema.TimescaleDB.IsDeleted = true
+	default:
+		return nil
+	}
+
+	return deleted
+}
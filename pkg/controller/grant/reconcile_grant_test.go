@@ -0,0 +1,89 @@
+package grant
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_deletedGrantSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   schemasv1alpha4.GrantSpec
+		expect *schemasv1alpha4.GrantSpec
+	}{
+		{
+			name: "postgres",
+			spec: schemasv1alpha4.GrantSpec{
+				Database:         "db",
+				RevokeOnDeletion: true,
+				Schema: &schemasv1alpha4.GrantSchema{
+					Postgres: &schemasv1alpha4.PostgresqlGrantSchema{Role: "reader", Privileges: []string{"SELECT"}},
+				},
+			},
+			expect: &schemasv1alpha4.GrantSpec{
+				Database:         "db",
+				RevokeOnDeletion: true,
+				Schema: &schemasv1alpha4.GrantSchema{
+					Postgres: &schemasv1alpha4.PostgresqlGrantSchema{Role: "reader", Privileges: []string{"SELECT"}, IsDeleted: true},
+				},
+			},
+		},
+		{
+			name: "mysql",
+			spec: schemasv1alpha4.GrantSpec{
+				Database: "db",
+				Schema: &schemasv1alpha4.GrantSchema{
+					Mysql: &schemasv1alpha4.MysqlGrantSchema{User: "reader", Privileges: []string{"SELECT"}},
+				},
+			},
+			expect: &schemasv1alpha4.GrantSpec{
+				Database: "db",
+				Schema: &schemasv1alpha4.GrantSchema{
+					Mysql: &schemasv1alpha4.MysqlGrantSchema{User: "reader", Privileges: []string{"SELECT"}, IsDeleted: true},
+				},
+			},
+		},
+		{
+			name: "timescaledb",
+			spec: schemasv1alpha4.GrantSpec{
+				Database: "db",
+				Schema: &schemasv1alpha4.GrantSchema{
+					TimescaleDB: &schemasv1alpha4.PostgresqlGrantSchema{Role: "reader", Privileges: []string{"SELECT"}},
+				},
+			},
+			expect: &schemasv1alpha4.GrantSpec{
+				Database: "db",
+				Schema: &schemasv1alpha4.GrantSchema{
+					TimescaleDB: &schemasv1alpha4.PostgresqlGrantSchema{Role: "reader", Privileges: []string{"SELECT"}, IsDeleted: true},
+				},
+			},
+		},
+		{
+			name: "not implemented",
+			spec: schemasv1alpha4.GrantSpec{
+				Database: "db",
+				Schema: &schemasv1alpha4.GrantSchema{
+					SQLite: &schemasv1alpha4.NotImplementedGrantSchema{},
+				},
+			},
+			expect: nil,
+		},
+		{
+			name:   "no schema",
+			spec:   schemasv1alpha4.GrantSpec{Database: "db"},
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := deletedGrantSpec(&test.spec)
+			assert.Equal(t, test.expect, actual)
+			if test.spec.Schema != nil && test.spec.Schema.Postgres != nil {
+				assert.False(t, test.spec.Schema.Postgres.IsDeleted, "the spec of the grant is not modified")
+			}
+		})
+	}
+}
@@ -108,3 +108,87 @@ func DatabaseFromView(ctx context.Context, view *schemasv1alpha4.View) (*databas
 
 	return database, nil
 }
+
+func GrantFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*schemasv1alpha4.Grant, error) {
+	schemasClient, err := getSchemasClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schemas client")
+	}
+
+	grant, err := schemasClient.Grants(migration.Spec.TableNamespace).Get(ctx, migration.Spec.TableName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get grant")
+	}
+
+	return grant, nil
+}
+
+func DatabaseFromGrant(ctx context.Context, grant *schemasv1alpha4.Grant) (*databasesv1alpha4.Database, error) {
+	databasesClient, err := getDatabasesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databases client")
+	}
+
+	database, err := databasesClient.Databases(grant.Namespace).Get(ctx, grant.Spec.Database, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+
+	return database, nil
+}
+
+func DatabaseSchemaFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*schemasv1alpha4.DatabaseSchema, error) {
+	schemasClient, err := getSchemasClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schemas client")
+	}
+
+	databaseSchema, err := schemasClient.DatabaseSchemas(migration.Spec.TableNamespace).Get(ctx, migration.Spec.TableName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database schema")
+	}
+
+	return databaseSchema, nil
+}
+
+func DatabaseFromDatabaseSchema(ctx context.Context, databaseSchema *schemasv1alpha4.DatabaseSchema) (*databasesv1alpha4.Database, error) {
+	databasesClient, err := getDatabasesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databases client")
+	}
+
+	database, err := databasesClient.Databases(databaseSchema.Namespace).Get(ctx, databaseSchema.Spec.Database, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+
+	return database, nil
+}
+
+func SequenceFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*schemasv1alpha4.Sequence, error) {
+	schemasClient, err := getSchemasClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schemas client")
+	}
+
+	sequence, err := schemasClient.Sequences(migration.Spec.TableNamespace).Get(ctx, migration.Spec.TableName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sequence")
+	}
+
+	return sequence, nil
+}
+
+func DatabaseFromSequence(ctx context.Context, sequence *schemasv1alpha4.Sequence) (*databasesv1alpha4.Database, error) {
+	databasesClient, err := getDatabasesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databases client")
+	}
+
+	database, err := databasesClient.Databases(sequence.Namespace).Get(ctx, sequence.Spec.Database, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+
+	return database, nil
+}
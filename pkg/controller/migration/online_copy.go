@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const onlineCopyProgressInterval = 10 * time.Second
+
+// reportOnlineCopyProgress polls the progress of the shadow table copies in the migration and
+// records it on the migration status until stop is closed
+func (r *ReconcileMigration) reportOnlineCopyProgress(db *database.Database, migration *schemasv1alpha4.Migration, tableNames []string, stop <-chan struct{}) {
+	conn, err := db.GetConnection(context.Background())
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(onlineCopyProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			// record the final progress once the statements have been applied
+			r.updateOnlineCopyStatus(conn.GetOnlineCopyProgress, migration, tableNames)
+			return
+		case <-ticker.C:
+			r.updateOnlineCopyStatus(conn.GetOnlineCopyProgress, migration, tableNames)
+		}
+	}
+}
+
+func (r *ReconcileMigration) updateOnlineCopyStatus(getProgress func(string) (*databasetypes.OnlineCopyProgress, error), migration *schemasv1alpha4.Migration, tableNames []string) {
+	onlineCopy, err := onlineCopyStatus(getProgress, tableNames)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	if onlineCopy == nil {
+		return
+	}
+
+	migration.Status.OnlineCopy = onlineCopy
+	if err := r.Update(context.Background(), migration); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	logger.Info("online copy progress",
+		zap.String("name", migration.Name),
+		zap.Int64("rowsCopied", onlineCopy.RowsCopied),
+		zap.Int64("totalRows", onlineCopy.TotalRows))
+}
+
+// onlineCopyStatus sums the progress of the tables, it returns nil when no copy has started
+func onlineCopyStatus(getProgress func(string) (*databasetypes.OnlineCopyProgress, error), tableNames []string) (*schemasv1alpha4.OnlineCopyStatus, error) {
+	var status *schemasv1alpha4.OnlineCopyStatus
+	for _, tableName := range tableNames {
+		progress, err := getProgress(tableName)
+		if err != nil {
+			return nil, err
+		}
+		if progress == nil {
+			continue
+		}
+
+		if status == nil {
+			status = &schemasv1alpha4.OnlineCopyStatus{}
+		}
+		status.RowsCopied += progress.RowsCopied
+		status.TotalRows += progress.TotalRows
+	}
+
+	if status != nil {
+		status.UpdatedAt = time.Now().Unix()
+	}
+
+	return status, nil
+}
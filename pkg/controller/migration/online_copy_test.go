@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"testing"
+
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_onlineCopyStatus(t *testing.T) {
+	progress := map[string]*databasetypes.OnlineCopyProgress{
+		"orders":   {RowsCopied: 100, TotalRows: 1000},
+		"payments": {RowsCopied: 50, TotalRows: 50, Completed: true},
+	}
+	getProgress := func(tableName string) (*databasetypes.OnlineCopyProgress, error) {
+		return progress[tableName], nil
+	}
+
+	status, err := onlineCopyStatus(getProgress, []string{"missing"})
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	status, err = onlineCopyStatus(getProgress, []string{"orders", "payments", "missing"})
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, int64(150), status.RowsCopied)
+	assert.Equal(t, int64(1050), status.TotalRows)
+	assert.NotZero(t, status.UpdatedAt)
+}
@@ -9,9 +9,11 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -48,7 +50,22 @@ func (r *ReconcileMigration) reconcileMigration(ctx context.Context, migration *
 
 	statements := db.GetStatementsFromDDL(migration.Spec.GeneratedDDL)
 
-	if err := db.ApplySync(statements); err != nil {
+	if migration.Spec.RequiresOnlineCopy {
+		// copying a large table can take a long time, report the progress on the migration while it runs
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.reportOnlineCopyProgress(&db, migration, databasetypes.OnlineCopyTables(statements), stop)
+		}()
+
+		err := db.ApplySync(statements)
+		close(stop)
+		<-done
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to apply statements")
+		}
+	} else if err := db.ApplySync(statements); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to apply statements")
 	}
 
@@ -88,7 +105,63 @@ func shouldApplyMigration(migration *schemasv1alpha4.Migration) bool {
 	return false
 }
 
+// getDatabaseFromMigration returns the database of the object that planned the migration. The kind of the object is
+// read from the controller reference of the migration, because objects of different kinds can have the same name
 func getDatabaseFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*databasesv1alpha4.Database, error) {
+	owner := metav1.GetControllerOf(migration)
+	if owner == nil {
+		return getDatabaseFromMigrationName(ctx, migration)
+	}
+
+	switch owner.Kind {
+	case "Database":
+		databasesClient, err := getDatabasesClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get databases client")
+		}
+		database, err := databasesClient.Databases(migration.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get database")
+		}
+		return database, nil
+	case "Table":
+		table, err := TableFromMigration(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+		return DatabaseFromTable(ctx, table)
+	case "View":
+		view, err := ViewFromMigration(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+		return DatabaseFromView(ctx, view)
+	case "Grant":
+		grant, err := GrantFromMigration(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+		return DatabaseFromGrant(ctx, grant)
+	case "DatabaseSchema":
+		databaseSchema, err := DatabaseSchemaFromMigration(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+		return DatabaseFromDatabaseSchema(ctx, databaseSchema)
+	case "Sequence":
+		sequence, err := SequenceFromMigration(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+		return DatabaseFromSequence(ctx, sequence)
+	}
+
+	return nil, errors.Errorf("migration %s is owned by unsupported kind %s", migration.Name, owner.Kind)
+}
+
+// getDatabaseFromMigrationName finds the object that planned a migration without a controller reference by its name,
+// trying each kind in turn
+func getDatabaseFromMigrationName(ctx context.Context, migration *schemasv1alpha4.Migration) (*databasesv1alpha4.Database, error) {
 	table, err := TableFromMigration(ctx, migration)
 	if err != nil {
 		if !kuberneteserrors.IsNotFound(err) {
@@ -104,11 +177,50 @@ func getDatabaseFromMigration(ctx context.Context, migration *schemasv1alpha4.Mi
 
 	view, err := ViewFromMigration(ctx, migration)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get view")
+		if !kuberneteserrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "failed to get view")
+		}
+	} else {
+		database, err := DatabaseFromView(ctx, view)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get database from view %s", view.Name)
+		}
+		return database, nil
+	}
+
+	grant, err := GrantFromMigration(ctx, migration)
+	if err != nil {
+		if !kuberneteserrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "failed to get grant")
+		}
+	} else {
+		database, err := DatabaseFromGrant(ctx, grant)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get database from grant %s", grant.Name)
+		}
+		return database, nil
+	}
+
+	databaseSchema, err := DatabaseSchemaFromMigration(ctx, migration)
+	if err != nil {
+		if !kuberneteserrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "failed to get database schema")
+		}
+	} else {
+		database, err := DatabaseFromDatabaseSchema(ctx, databaseSchema)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get database from database schema %s", databaseSchema.Name)
+		}
+		return database, nil
+	}
+
+	sequence, err := SequenceFromMigration(ctx, migration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sequence")
 	}
-	database, err := DatabaseFromView(ctx, view)
+	database, err := DatabaseFromSequence(ctx, sequence)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get database from view %s", view.Name)
+		return nil, errors.Wrapf(err, "failed to get database from sequence %s", sequence.Name)
 	}
 	return database, nil
 }
@@ -38,7 +38,37 @@ func Test_getDatabaseFromMigration(t *testing.T) {
 		},
 	}
 
-	schemasClient = testclient.NewSimpleClientset(table1, view1).SchemasV1alpha4()
+	grant1 := &schemasv1alpha4.Grant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant1",
+			Namespace: "namespace1",
+		},
+		Spec: schemasv1alpha4.GrantSpec{
+			Database: "testdb",
+		},
+	}
+
+	databaseSchema1 := &schemasv1alpha4.DatabaseSchema{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "schema1",
+			Namespace: "namespace1",
+		},
+		Spec: schemasv1alpha4.DatabaseSchemaSpec{
+			Database: "testdb",
+		},
+	}
+
+	sequence1 := &schemasv1alpha4.Sequence{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sequence1",
+			Namespace: "namespace1",
+		},
+		Spec: schemasv1alpha4.SequenceSpec{
+			Database: "testdb",
+		},
+	}
+
+	schemasClient = testclient.NewSimpleClientset(table1, view1, grant1, databaseSchema1, sequence1).SchemasV1alpha4()
 	databasesClient = testclient.NewSimpleClientset(db).DatabasesV1alpha4()
 
 	tests := []struct {
@@ -66,6 +96,36 @@ func Test_getDatabaseFromMigration(t *testing.T) {
 			},
 			want: db,
 		},
+		{
+			name: "db from grant",
+			migration: &schemasv1alpha4.Migration{
+				Spec: schemasv1alpha4.MigrationSpec{
+					TableNamespace: "namespace1",
+					TableName:      "grant1",
+				},
+			},
+			want: db,
+		},
+		{
+			name: "db from database schema",
+			migration: &schemasv1alpha4.Migration{
+				Spec: schemasv1alpha4.MigrationSpec{
+					TableNamespace: "namespace1",
+					TableName:      "schema1",
+				},
+			},
+			want: db,
+		},
+		{
+			name: "db from sequence",
+			migration: &schemasv1alpha4.Migration{
+				Spec: schemasv1alpha4.MigrationSpec{
+					TableNamespace: "namespace1",
+					TableName:      "sequence1",
+				},
+			},
+			want: db,
+		},
 		{
 			name: "unknown db",
 			migration: &schemasv1alpha4.Migration{
@@ -134,3 +194,77 @@ func Test_shouldApplyMigration(t *testing.T) {
 		})
 	}
 }
+
+func Test_getDatabaseFromMigrationOwner(t *testing.T) {
+	testdb := &databasesv1alpha4.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "testdb", Namespace: "namespace1"},
+	}
+	otherdb := &databasesv1alpha4.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "otherdb", Namespace: "namespace1"},
+	}
+	table := &schemasv1alpha4.Table{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "namespace1"},
+		Spec:       schemasv1alpha4.TableSpec{Database: "testdb"},
+	}
+	grant := &schemasv1alpha4.Grant{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "namespace1"},
+		Spec:       schemasv1alpha4.GrantSpec{Database: "otherdb"},
+	}
+
+	schemasClient = testclient.NewSimpleClientset(table, grant).SchemasV1alpha4()
+	databasesClient = testclient.NewSimpleClientset(testdb, otherdb).DatabasesV1alpha4()
+
+	isController := true
+	migration := func(kind string, name string) *schemasv1alpha4.Migration {
+		return &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "abcdefg",
+				Namespace: "namespace1",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: kind, Name: name, Controller: &isController},
+				},
+			},
+			Spec: schemasv1alpha4.MigrationSpec{
+				TableNamespace: "namespace1",
+				TableName:      name,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		migration *schemasv1alpha4.Migration
+		want      *databasesv1alpha4.Database
+	}{
+		{
+			name:      "table with the name of a grant",
+			migration: migration("Table", "users"),
+			want:      testdb,
+		},
+		{
+			name:      "grant with the name of a table",
+			migration: migration("Grant", "users"),
+			want:      otherdb,
+		},
+		{
+			name:      "batch owned by the database",
+			migration: migration("Database", "otherdb"),
+			want:      otherdb,
+		},
+		{
+			name:      "unsupported kind",
+			migration: migration("ConfigMap", "users"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDatabaseFromMigration(context.Background(), tt.migration)
+			if tt.want == nil {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package sequence
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	databasesclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/databases/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/config"
+	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"go.uber.org/zap"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileSequence is called after filtering events that are not relevant to this
+// controller. this function is the main reconcile loop for the sequence type
+func (r *ReconcileSequence) reconcileSequence(ctx context.Context, instance *schemasv1alpha4.Sequence) (reconcile.Result, error) {
+	logger.Debug("reconciling sequence",
+		zap.String("kind", instance.Kind),
+		zap.String("name", instance.Name),
+		zap.String("database", instance.Spec.Database),
+		zap.String("lastPlannedSequenceSpecSHA", instance.Status.LastPlannedSequenceSpecSHA))
+
+	// early exit if the sha of the spec hasn't changed
+	currentSequenceSpecSHA, err := instance.GetSHA()
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get instance sha")
+	}
+	if instance.Status.LastPlannedSequenceSpecSHA == currentSequenceSpecSHA {
+		return reconcile.Result{}, nil
+	}
+
+	// get the full database spec from the api
+	database, err := r.getDatabaseInstance(ctx, instance.Namespace, instance.Spec.Database)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get database spec")
+	}
+
+	// the database object might not yet exist
+	// this can happen if the sequence was deployed at the same time or before the database object
+	if database == nil {
+		logger.Debug("requeuing sequence reconcile request for 10 seconds because database instance was not present",
+			zap.String("database.name", instance.Spec.Database),
+			zap.String("database.namespace", instance.Namespace))
+
+		return reconcile.Result{
+			Requeue:      true,
+			RequeueAfter: time.Second * 10,
+		}, nil
+	}
+
+	if !checkDatabaseTypeMatches(&database.Spec.Connection, &instance.Spec) {
+		return reconcile.Result{}, errors.New("unable to deploy sequence to connection of different type")
+	}
+
+	// look for an already calculated migration for this sequence
+	var existingMigration schemasv1alpha4.Migration
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      currentSequenceSpecSHA[:7],
+		Namespace: instance.Namespace,
+	}, &existingMigration)
+	if err == nil {
+		// a migration has already been queued for this exact spec
+		return reconcile.Result{}, nil
+	} else if !kuberneteserrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get existing migration")
+	}
+
+	// at this point, we need to execute a plan
+	return r.plan(ctx, database, instance)
+}
+
+func (r *ReconcileSequence) getInstance(request reconcile.Request) (*schemasv1alpha4.Sequence, error) {
+	v1alpha4instance := &schemasv1alpha4.Sequence{}
+	err := r.Get(context.Background(), request.NamespacedName, v1alpha4instance)
+	if err != nil {
+		return nil, err // don't wrap
+	}
+
+	return v1alpha4instance, nil
+}
+
+func (r *ReconcileSequence) getDatabaseInstance(ctx context.Context, namespace string, name string) (*databasesv1alpha4.Database, error) {
+	logger.Debug("getting database spec",
+		zap.String("namespace", namespace),
+		zap.String("name", name))
+
+	cfg, err := config.GetRESTConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get config")
+	}
+	databasesClient, err := databasesclientv1alpha4.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databasesclient")
+	}
+
+	database, err := databasesClient.Databases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		// sequences might be deployed before a database... if this is the case
+		// we don't want to crash, we want to re-reconcile later
+		if kuberneteserrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to get database object")
+	}
+
+	return database, nil
+}
+
+// checkDatabaseTypeMatches returns true when the sequence has a block for the type of the connection
+func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, spec *schemasv1alpha4.SequenceSpec) bool {
+	if connection.Postgres != nil || connection.TimescaleDB != nil {
+		return spec.Postgres != nil
+	}
+
+	return false
+}
+
+// plan will connect to the database and generate a migration spec, deploying the
+// migration object
+func (r *ReconcileSequence) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, sequenceInstance *schemasv1alpha4.Sequence) (reconcile.Result, error) {
+	logger.Debug("planning migration",
+		zap.String("databaseName", databaseInstance.Name),
+		zap.String("sequenceName", sequenceInstance.Name))
+
+	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	// Set plugin manager for automatic plugin downloading
+	db.SetPluginManager(plugin.GetGlobalPluginManager())
+
+	statements, err := db.PlanSyncSequenceSpec(&sequenceInstance.Spec)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to plan migration")
+	}
+
+	sequenceSpecSHA, err := sequenceInstance.GetSHA()
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get sha of sequence")
+	}
+
+	if len(statements) == 0 {
+		logger.Info("sequence is already in sync with the database, no migration needed",
+			zap.String("databaseName", databaseInstance.Name),
+			zap.String("sequenceName", sequenceInstance.Name))
+
+		return reconcile.Result{}, r.updateLastPlannedSHA(ctx, sequenceInstance, sequenceSpecSHA)
+	}
+
+	migration := schemasv1alpha4.Migration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "schemas.schemahero.io/v1alpha4",
+			Kind:       "Migration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sequenceSpecSHA[:7],
+			Namespace: sequenceInstance.Namespace,
+		},
+		Spec: schemasv1alpha4.MigrationSpec{
+			GeneratedDDL:   strings.Join(statements, ";\n"),
+			DatabaseName:   sequenceInstance.Spec.Database,
+			TableName:      sequenceInstance.Name,
+			TableNamespace: sequenceInstance.Namespace,
+		},
+		Status: schemasv1alpha4.MigrationStatus{
+			PlannedAt: time.Now().Unix(),
+			Phase:     schemasv1alpha4.Planned,
+		},
+	}
+
+	if databaseInstance.Spec.ImmediateDeploy {
+		migration.Status.ApprovedAt = time.Now().Unix()
+	}
+
+	if err := controllerutil.SetControllerReference(sequenceInstance, &migration, r.scheme); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to set owner on migration")
+	}
+
+	if err := r.Create(ctx, &migration); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to create migration resource")
+	}
+
+	return reconcile.Result{}, r.updateLastPlannedSHA(ctx, sequenceInstance, sequenceSpecSHA)
+}
+
+// updateLastPlannedSHA records the planned spec so that the sequence is not planned again on startup
+func (r *ReconcileSequence) updateLastPlannedSHA(ctx context.Context, sequenceInstance *schemasv1alpha4.Sequence, sequenceSpecSHA string) error {
+	sequenceInstance.Status.LastPlannedSequenceSpecSHA = sequenceSpecSHA
+	if err := r.Status().Update(ctx, sequenceInstance); err != nil {
+		return errors.Wrap(err, "failed to update sequence status")
+	}
+
+	return nil
+}
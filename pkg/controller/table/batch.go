@@ -29,6 +29,7 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
@@ -173,6 +174,7 @@ func (r *ReconcileTable) planBatch(ctx context.Context, databaseInstance *databa
 		Driver:         driver,
 		URI:            connectionURI,
 		DeploySeedData: databaseInstance.Spec.DeploySeedData,
+		MysqlOnlineDDL: mysqlOnlineDDL(databaseInstance),
 	}
 
 	// Set plugin manager for automatic plugin downloading
@@ -260,6 +262,10 @@ func (r *ReconcileTable) planBatch(ctx context.Context, databaseInstance *databa
 			TableName:      processedTables[0].Name, // Primary table for backwards compat
 			TableNamespace: processedTables[0].Namespace,
 			Tables:         tableRefs,
+
+			RequiresOnlineCopy: databasetypes.RequiresOnlineCopy(allStatements),
+			Warnings:           databasetypes.MigrationWarnings(allStatements),
+			Destructive:        databasetypes.IsDestructive(allStatements),
 		},
 		Status: schemasv1alpha4.MigrationStatus{
 			PlannedAt: time.Now().Unix(),
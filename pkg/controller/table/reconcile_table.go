@@ -13,9 +13,12 @@ import (
 	"github.com/schemahero/schemahero/pkg/config"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/database/seeddata"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,6 +35,10 @@ func (r *ReconcileTable) reconcileTable(ctx context.Context, instance *schemasv1
 		zap.String("database", instance.Spec.Database),
 		zap.String("lastPlannedTableSpecSHA", instance.Status.LastPlannedTableSpecSHA))
 
+	if err := r.resolveSeedData(ctx, instance); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to resolve seed data")
+	}
+
 	// early exit if the sha of the spec hasn't changed
 	currentTableSpecSHA, err := instance.GetSHA()
 	if err != nil {
@@ -226,6 +233,71 @@ func (r *ReconcileTable) isDatabaseControllerReady(ctx context.Context, database
 	return true, nil
 }
 
+// resolveSeedData reads the seed data of the valueFrom into rows when the database deploys seed data, so that the
+// sha changes when the data does. only the status of the instance is written back, so the rows are not saved in the
+// table. when the data cannot be read, the failure is recorded in the status and the table is planned without it
+func (r *ReconcileTable) resolveSeedData(ctx context.Context, instance *schemasv1alpha4.Table) error {
+	if instance.Spec.SeedData == nil || instance.Spec.SeedData.ValueFrom == nil {
+		instance.Status.SeedDataError = ""
+		return nil
+	}
+
+	database, err := r.getDatabaseInstance(ctx, instance.Namespace, instance.Spec.Database)
+	if err != nil {
+		return errors.Wrap(err, "failed to get database spec")
+	}
+	if database == nil || !database.Spec.DeploySeedData {
+		instance.Status.SeedDataError = ""
+		return nil
+	}
+
+	seedData, err := seeddata.Resolve(instance.Spec.SeedData, r.seedDataSource(ctx, instance.Namespace))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read seed data for table %s", instance.Name)
+		logger.Error(err)
+		instance.Status.SeedDataError = err.Error()
+		instance.Spec.SeedData = nil
+		return nil
+	}
+
+	instance.Status.SeedDataError = ""
+	instance.Spec.SeedData = seedData
+	return nil
+}
+
+// seedDataSource reads the ConfigMaps and Secrets of seed data valueFrom in the namespace of the table
+func (r *ReconcileTable) seedDataSource(ctx context.Context, namespace string) seeddata.Source {
+	return func(valueFrom *schemasv1alpha4.SeedDataValueFrom) ([]byte, error) {
+		if ref := valueFrom.ConfigMapKeyRef; ref != nil {
+			configMap := &corev1.ConfigMap{}
+			if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, configMap); err != nil {
+				return nil, errors.Wrapf(err, "failed to get configmap %s", ref.Name)
+			}
+			if data, ok := configMap.Data[ref.Key]; ok {
+				return []byte(data), nil
+			}
+			if data, ok := configMap.BinaryData[ref.Key]; ok {
+				return data, nil
+			}
+			return nil, errors.Errorf("configmap %s does not have key %s", ref.Name, ref.Key)
+		}
+
+		if ref := valueFrom.SecretKeyRef; ref != nil {
+			// secrets are read from the api, so that the manager does not cache every secret in the cluster
+			secret := &corev1.Secret{}
+			if err := r.apiReader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+				return nil, errors.Wrapf(err, "failed to get secret %s", ref.Name)
+			}
+			if data, ok := secret.Data[ref.Key]; ok {
+				return data, nil
+			}
+			return nil, errors.Errorf("secret %s does not have key %s", ref.Name, ref.Key)
+		}
+
+		return nil, errors.New("seed data valueFrom of a table must be a configMapKeyRef or a secretKeyRef")
+	}
+}
+
 func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, tableSchema *schemasv1alpha4.TableSchema) bool {
 	if connection.Postgres != nil {
 		return tableSchema.Postgres != nil
@@ -246,6 +318,18 @@ func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection,
 	return false
 }
 
+// mysqlOnlineDDL returns the default online DDL policy from the mysql connection, if one is set
+func mysqlOnlineDDL(databaseInstance *databasesv1alpha4.Database) *schemasv1alpha4.MysqlOnlineDDL {
+	if databaseInstance.Spec.Connection.Mysql == nil || databaseInstance.Spec.Connection.Mysql.OnlineDDL == nil {
+		return nil
+	}
+
+	return &schemasv1alpha4.MysqlOnlineDDL{
+		Algorithm: databaseInstance.Spec.Connection.Mysql.OnlineDDL.Algorithm,
+		Lock:      databaseInstance.Spec.Connection.Mysql.OnlineDDL.Lock,
+	}
+}
+
 // plan will connect to the database and generate a migration spec, deploying the
 // migration object
 func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, tableInstance *schemasv1alpha4.Table) (reconcile.Result, error) {
@@ -262,6 +346,7 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 		Driver:         driver,
 		URI:            connectionURI,
 		DeploySeedData: databaseInstance.Spec.DeploySeedData,
+		MysqlOnlineDDL: mysqlOnlineDDL(databaseInstance),
 	}
 
 	// Set plugin manager for automatic plugin downloading
@@ -270,8 +355,14 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 	// plan the schema
 	schemaStatements, err := db.PlanSyncTableSpec(&tableInstance.Spec)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "failed to plan sync for table %s", tableInstance.Name)
+		err = errors.Wrapf(err, "failed to plan sync for table %s", tableInstance.Name)
+		tableInstance.Status.PlanError = err.Error()
+		if updateErr := r.Status().Update(ctx, tableInstance); updateErr != nil {
+			logger.Error(updateErr)
+		}
+		return reconcile.Result{}, err
 	}
+	tableInstance.Status.PlanError = ""
 
 	// plan the seed data
 	seedStatements := []string{}
@@ -326,6 +417,10 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 			DatabaseName:   tableInstance.Spec.Database,
 			TableName:      tableInstance.Name,
 			TableNamespace: tableInstance.Namespace,
+
+			RequiresOnlineCopy: databasetypes.RequiresOnlineCopy(allGeneratedStatements),
+			Warnings:           databasetypes.MigrationWarnings(allGeneratedStatements),
+			Destructive:        databasetypes.IsDestructive(allGeneratedStatements),
 		},
 		Status: schemasv1alpha4.MigrationStatus{
 			PlannedAt: time.Now().Unix(),
@@ -24,7 +24,9 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +47,7 @@ func Add(mgr manager.Manager, databaseNames []string) error {
 func newReconciler(databaseNames []string, mgr manager.Manager) reconcile.Reconciler {
 	return &ReconcileTable{
 		Client:        mgr.GetClient(),
+		apiReader:     mgr.GetAPIReader(),
 		scheme:        mgr.GetScheme(),
 		databaseNames: databaseNames,
 	}
@@ -64,6 +67,19 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return errors.Wrap(err, "failed to start watch on tables")
 	}
 
+	// Watch for changes to ConfigMaps that tables read seed data from
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, configMap *corev1.ConfigMap) []reconcile.Request {
+		tables := &schemasv1alpha4.TableList{}
+		if err := mgr.GetClient().List(ctx, tables, client.InNamespace(configMap.Namespace)); err != nil {
+			logger.Error(errors.Wrap(err, "failed to list tables"))
+			return nil
+		}
+		return tablesReadingConfigMap(tables.Items, configMap.Name)
+	})))
+	if err != nil {
+		return errors.Wrap(err, "failed to start watch on configmaps")
+	}
+
 	// Add an informer on pods, which are created to deploy schemas. the informer will
 	// update the status of the table custom resource and do a little garbage collection
 	generatedClient := kubernetes.NewForConfigOrDie(mgr.GetConfig())
@@ -83,6 +99,7 @@ var _ reconcile.Reconciler = &ReconcileTable{}
 // ReconcileTable reconciles a Table object
 type ReconcileTable struct {
 	client.Client
+	apiReader     client.Reader
 	scheme        *runtime.Scheme
 	databaseNames []string
 }
@@ -94,6 +111,7 @@ type ReconcileTable struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=tables,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=tables/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 func (r *ReconcileTable) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// This reconcile loop will be called for all Table objects and all pods
 	// because of the informer that we have set up
@@ -124,6 +142,29 @@ func (r *ReconcileTable) Reconcile(ctx context.Context, request reconcile.Reques
 	return result, err
 }
 
+// tablesReadingConfigMap returns requests for the tables that read seed data from the configmap
+func tablesReadingConfigMap(tables []schemasv1alpha4.Table, configMapName string) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for _, table := range tables {
+		seedData := table.Spec.SeedData
+		if seedData == nil || seedData.ValueFrom == nil || seedData.ValueFrom.ConfigMapKeyRef == nil {
+			continue
+		}
+		if seedData.ValueFrom.ConfigMapKeyRef.Name != configMapName {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      table.Name,
+				Namespace: table.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 func (r *ReconcileTable) isTableManagedByThisController(instance *schemasv1alpha4.Table) (bool, error) {
 	databaseName := instance.Spec.Database
 
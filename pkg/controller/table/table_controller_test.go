@@ -0,0 +1,81 @@
+package table
+
+import (
+	"context"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func Test_tablesReadingConfigMap(t *testing.T) {
+	table := func(name string, valueFrom *schemasv1alpha4.SeedDataValueFrom) schemasv1alpha4.Table {
+		return schemasv1alpha4.Table{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: schemasv1alpha4.TableSpec{
+				SeedData: &schemasv1alpha4.SeedData{ValueFrom: valueFrom},
+			},
+		}
+	}
+
+	tables := []schemasv1alpha4.Table{
+		table("countries", &schemasv1alpha4.SeedDataValueFrom{
+			ConfigMapKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "reference-data", Key: "countries.csv"},
+		}),
+		table("flags", &schemasv1alpha4.SeedDataValueFrom{
+			ConfigMapKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "flags", Key: "flags.csv"},
+		}),
+		table("secrets", &schemasv1alpha4.SeedDataValueFrom{
+			SecretKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "reference-data", Key: "countries.csv"},
+		}),
+		table("inline", nil),
+		{ObjectMeta: metav1.ObjectMeta{Name: "no-seed-data", Namespace: "default"}},
+	}
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "countries", Namespace: "default"}},
+	}, tablesReadingConfigMap(tables, "reference-data"))
+
+	assert.Empty(t, tablesReadingConfigMap(tables, "other"))
+}
+
+func Test_seedDataSource(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "reference-data", Namespace: "default"},
+		Data:       map[string]string{"countries.csv": "code\nca\n"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "reference-data", Namespace: "default"},
+		Data:       map[string][]byte{"countries.csv": []byte("code\nus\n")},
+	}
+
+	// the secret is only readable from the api reader, the cached client does not have it
+	r := ReconcileTable{
+		Client:    fake.NewClientBuilder().WithObjects(configMap).Build(),
+		apiReader: fake.NewClientBuilder().WithObjects(secret).Build(),
+	}
+	source := r.seedDataSource(context.Background(), "default")
+
+	b, err := source(&schemasv1alpha4.SeedDataValueFrom{
+		ConfigMapKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "reference-data", Key: "countries.csv"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "code\nca\n", string(b))
+
+	b, err = source(&schemasv1alpha4.SeedDataValueFrom{
+		SecretKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "reference-data", Key: "countries.csv"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "code\nus\n", string(b))
+
+	_, err = source(&schemasv1alpha4.SeedDataValueFrom{
+		SecretKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "reference-data", Key: "states.csv"},
+	})
+	assert.EqualError(t, err, "secret reference-data does not have key states.csv")
+}
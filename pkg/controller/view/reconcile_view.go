@@ -12,6 +12,7 @@ import (
 	"github.com/schemahero/schemahero/pkg/config"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
@@ -141,6 +142,8 @@ func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection,
 		return viewSchema.TimescaleDB != nil
 	} else if connection.SQLite != nil {
 		return viewSchema.SQLite != nil
+	} else if connection.Cassandra != nil {
+		return viewSchema.Cassandra != nil
 	}
 
 	return false
@@ -213,6 +216,9 @@ func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1a
 			DatabaseName:   viewInstance.Spec.Database,
 			TableName:      viewInstance.Name,
 			TableNamespace: viewInstance.Namespace,
+
+			Warnings:    databasetypes.MigrationWarnings(schemaStatements),
+			Destructive: databasetypes.IsDestructive(schemaStatements),
 		},
 		Status: schemasv1alpha4.MigrationStatus{
 			PlannedAt: time.Now().Unix(),
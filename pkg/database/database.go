@@ -14,6 +14,7 @@ import (
 	"github.com/schemahero/schemahero/pkg/client/schemaheroclientset/scheme"
 	"github.com/schemahero/schemahero/pkg/database/interfaces"
 	"github.com/schemahero/schemahero/pkg/database/plugin"
+	"github.com/schemahero/schemahero/pkg/database/seeddata"
 	"github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"go.uber.org/zap"
@@ -30,6 +31,10 @@ type Database struct {
 	Password       string
 	Keyspace       string
 	DeploySeedData bool
+	// MysqlOnlineDDL is the online DDL policy for mysql tables that do not set their own
+	MysqlOnlineDDL *schemasv1alpha4.MysqlOnlineDDL
+	// SeedDataSource reads the data of seed data that has a valueFrom
+	SeedDataSource seeddata.Source
 	pluginManager  *plugin.PluginManager
 }
 
@@ -286,6 +291,24 @@ func (d *Database) PlanSync(specContents []byte, specType string) ([]string, err
 			return nil, errors.Wrapf(err, "failed to plan function sync")
 		}
 		return plan, nil
+	} else if specType == "grant" {
+		plan, err := d.planGrantSync(specContents)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan grant sync")
+		}
+		return plan, nil
+	} else if specType == "schema" {
+		plan, err := d.planDatabaseSchemaSync(specContents)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan schema sync")
+		}
+		return plan, nil
+	} else if specType == "sequence" {
+		plan, err := d.planSequenceSync(specContents)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan sequence sync")
+		}
+		return plan, nil
 	}
 
 	return nil, errors.New("unknown spec type")
@@ -320,6 +343,27 @@ func (d *Database) planGVKSync(specContents []byte) ([]string, error) {
 			return nil, errors.Wrapf(err, "failed to plan extension %s", extension.Name)
 		}
 		return plan, nil
+	} else if gvk.Group == "schemas.schemahero.io" && gvk.Version == "v1alpha4" && gvk.Kind == "Grant" {
+		grant := obj.(*schemasv1alpha4.Grant)
+		plan, err := d.PlanSyncGrantSpec(&grant.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan grant %s", grant.Name)
+		}
+		return plan, nil
+	} else if gvk.Group == "schemas.schemahero.io" && gvk.Version == "v1alpha4" && gvk.Kind == "DatabaseSchema" {
+		databaseSchema := obj.(*schemasv1alpha4.DatabaseSchema)
+		plan, err := d.PlanSyncDatabaseSchemaSpec(&databaseSchema.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan schema %s", databaseSchema.Name)
+		}
+		return plan, nil
+	} else if gvk.Group == "schemas.schemahero.io" && gvk.Version == "v1alpha4" && gvk.Kind == "Sequence" {
+		sequence := obj.(*schemasv1alpha4.Sequence)
+		plan, err := d.PlanSyncSequenceSpec(&sequence.Spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan sequence %s", sequence.Name)
+		}
+		return plan, nil
 	} else {
 		return nil, &specTypeFallbackError{err: errors.Errorf("unknown gvk %s", gvk)}
 	}
@@ -421,6 +465,18 @@ func (d *Database) PlanSyncViewSpec(spec *schemasv1alpha4.ViewSpec) ([]string, e
 		}
 
 		return conn.PlanViewSchema(spec.Name, spec.Schema.Mysql)
+	} else if d.Driver == "cassandra" {
+		if spec.Schema.Cassandra == nil {
+			return []string{}, nil
+		}
+
+		conn, err := d.GetConnection(context.Background())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get database connection")
+		}
+		defer conn.Close()
+
+		return conn.PlanViewSchema(spec.Name, spec.Schema.Cassandra)
 	}
 
 	// Other drivers don't support views yet
@@ -438,7 +494,11 @@ func (d *Database) PlanSyncTableSpec(spec *schemasv1alpha4.TableSpec) ([]string,
 
 	var seedData *schemasv1alpha4.SeedData
 	if d.DeploySeedData {
-		seedData = spec.SeedData
+		resolved, err := seeddata.Resolve(spec.SeedData, d.SeedDataSource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read seed data")
+		}
+		seedData = resolved
 	}
 
 	// Use connection-based planning for postgres, mysql, sqlite, rqlite, timescaledb, and cassandra
@@ -457,7 +517,13 @@ func (d *Database) PlanSyncTableSpec(spec *schemasv1alpha4.TableSpec) ([]string,
 		case "cockroachdb":
 			schema = spec.Schema.CockroachDB
 		case "mysql":
-			schema = spec.Schema.Mysql
+			if spec.Schema.Mysql != nil && spec.Schema.Mysql.OnlineDDL == nil && d.MysqlOnlineDDL != nil {
+				mysqlTableSchema := *spec.Schema.Mysql
+				mysqlTableSchema.OnlineDDL = d.MysqlOnlineDDL
+				schema = &mysqlTableSchema
+			} else {
+				schema = spec.Schema.Mysql
+			}
 		case "timescaledb":
 			schema = spec.Schema.TimescaleDB
 		case "sqlite", "sqlite3":
@@ -490,9 +556,14 @@ func (d *Database) PlanSyncSeedData(spec *schemasv1alpha4.TableSpec) ([]string,
 		}
 		defer conn.Close()
 
+		seedData, err := seeddata.Resolve(spec.SeedData, d.SeedDataSource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read seed data")
+		}
+
 		// When there's no schema, pass nil for the schema and let the plugin handle it
 		// The plugin should retrieve the existing schema from the database
-		return conn.PlanTableSchema(spec.Name, nil, spec.SeedData)
+		return conn.PlanTableSchema(spec.Name, nil, seedData)
 	}
 
 	return nil, errors.Errorf("unknown database driver: %q", d.Driver)
@@ -526,6 +597,10 @@ func (d *Database) PlanSyncTypeSpec(spec *schemasv1alpha4.DataTypeSpec) ([]strin
 
 	// Use plugin for Cassandra
 	if d.Driver == "cassandra" {
+		if spec.Schema.Cassandra == nil {
+			return []string{}, nil
+		}
+
 		conn, err := d.GetConnection(context.Background())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get database connection")
@@ -561,6 +636,9 @@ func (d *Database) ApplySync(statements []string) error {
 	return errors.Errorf("unknown database driver: %q", d.Driver)
 }
 
+// functionBodyTag surrounds a routine or trigger body in generated DDL
+const functionBodyTag = "-- Function body follows"
+
 // Combine lines that don't terminate with a semicolon.
 // Semicolon on the last line is optional.
 func (d *Database) GetStatementsFromDDL(ddl string) []string {
@@ -571,12 +649,28 @@ func (d *Database) GetStatementsFromDDL(ddl string) []string {
 	functionTagOpen := false
 	dollarQuoteTag := ""
 	statement := ""
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
 		if line == "" {
 			continue
 		}
 
+		// we assume the function tag to always have its own line, it may be followed by the statement terminator.
+		// bodies between the tags keep their lines so that line comments and statement terminators inside
+		// of them, such as in a mysql BEGIN ... END block, are sent to the database as they were written
+		if line == functionBodyTag || line == functionBodyTag+";" {
+			functionTagOpen = !functionTagOpen
+			if !functionTagOpen && strings.HasSuffix(line, ";") {
+				statements = append(statements, strings.TrimSpace(statement)+";")
+				statement = ""
+			}
+			continue
+		}
+		if functionTagOpen {
+			statement = statement + "\n" + strings.TrimRight(rawLine, " \t\r")
+			continue
+		}
+
 		// Check for dollar-quoted string delimiters (e.g., $$ or $_SCHEMAHERO_$)
 		// These are used in PostgreSQL functions to avoid escaping issues
 		if dollarQuoteTag == "" {
@@ -599,13 +693,8 @@ func (d *Database) GetStatementsFromDDL(ddl string) []string {
 			}
 		}
 
-		// we assume the function tag to always have its own line and never be the last line for simplicity
-		if line == "-- Function body follows" {
-			functionTagOpen = !functionTagOpen
-		}
-
-		// Don't split on semicolons if we're inside a dollar-quoted string or function tag
-		if !functionTagOpen && dollarQuoteTag == "" && (i == len(lines)-1 || strings.HasSuffix(line, ";")) {
+		// Don't split on semicolons if we're inside a dollar-quoted string
+		if dollarQuoteTag == "" && (i == len(lines)-1 || strings.HasSuffix(line, ";")) {
 			statement = statement + " " + line
 			statements = append(statements, strings.TrimSpace(statement))
 			statement = ""
@@ -688,8 +777,8 @@ func (d *Database) planFunctionSync(specContents []byte) ([]string, error) {
 }
 
 func (d *Database) PlanSyncFunctionSpec(spec *schemasv1alpha4.FunctionSpec) ([]string, error) {
-	// Use connection-based planning for postgres
-	if d.Driver == "postgres" || d.Driver == "cockroachdb" || d.Driver == "timescaledb" {
+	// Use connection-based planning for postgres and mysql
+	if d.Driver == "postgres" || d.Driver == "cockroachdb" || d.Driver == "timescaledb" || d.Driver == "mysql" {
 		conn, err := d.GetConnection(context.Background())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get database connection")
@@ -700,6 +789,8 @@ func (d *Database) PlanSyncFunctionSpec(spec *schemasv1alpha4.FunctionSpec) ([]s
 		var schema interface{}
 		if d.Driver == "postgres" && spec.Schema != nil && spec.Schema.Postgres != nil {
 			schema = spec.Schema.Postgres
+		} else if d.Driver == "mysql" && spec.Schema != nil && spec.Schema.Mysql != nil {
+			schema = spec.Schema.Mysql
 		}
 		// CockroachDB and TimescaleDB would also use postgres schema if they support functions
 
@@ -709,5 +800,151 @@ func (d *Database) PlanSyncFunctionSpec(spec *schemasv1alpha4.FunctionSpec) ([]s
 	return nil, errors.Errorf("planning functions is not supported for driver %q or function database schema not specified", d.Driver)
 }
 
+func (d *Database) planGrantSync(specContents []byte) ([]string, error) {
+	var spec *schemasv1alpha4.GrantSpec
+	parsedK8sObject := schemasv1alpha4.Grant{}
+	if err := yaml.Unmarshal(specContents, &parsedK8sObject); err == nil {
+		if parsedK8sObject.Spec.Database != "" {
+			spec = &parsedK8sObject.Spec
+		}
+	}
+
+	if spec == nil {
+		plainSpec := schemasv1alpha4.GrantSpec{}
+		if err := yaml.Unmarshal(specContents, &plainSpec); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal grant spec")
+		}
+
+		spec = &plainSpec
+	}
+
+	return d.PlanSyncGrantSpec(spec)
+}
+
+func (d *Database) PlanSyncGrantSpec(spec *schemasv1alpha4.GrantSpec) ([]string, error) {
+	if spec.Schema == nil {
+		return []string{}, nil
+	}
+
+	var schema interface{}
+	var grantName string
+	switch d.Driver {
+	case "postgres":
+		if spec.Schema.Postgres == nil {
+			return []string{}, nil
+		}
+		schema = spec.Schema.Postgres
+		grantName = spec.Schema.Postgres.Object.Name
+	case "timescaledb":
+		if spec.Schema.TimescaleDB == nil {
+			return []string{}, nil
+		}
+		schema = spec.Schema.TimescaleDB
+		grantName = spec.Schema.TimescaleDB.Object.Name
+	case "mysql", "mariadb":
+		if spec.Schema.Mysql == nil {
+			return []string{}, nil
+		}
+		schema = spec.Schema.Mysql
+		grantName = spec.Schema.Mysql.Object.Name
+	default:
+		return nil, errors.Errorf("driver %s does not support grants", d.Driver)
+	}
+
+	conn, err := d.GetConnection(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database connection")
+	}
+	defer conn.Close()
+
+	return conn.PlanGrantSchema(grantName, schema)
+}
+
+func (d *Database) planDatabaseSchemaSync(specContents []byte) ([]string, error) {
+	var spec *schemasv1alpha4.DatabaseSchemaSpec
+	parsedK8sObject := schemasv1alpha4.DatabaseSchema{}
+	if err := yaml.Unmarshal(specContents, &parsedK8sObject); err == nil {
+		if parsedK8sObject.Spec.Database != "" {
+			spec = &parsedK8sObject.Spec
+		}
+	}
+
+	if spec == nil {
+		plainSpec := schemasv1alpha4.DatabaseSchemaSpec{}
+		if err := yaml.Unmarshal(specContents, &plainSpec); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal schema spec")
+		}
+
+		spec = &plainSpec
+	}
+
+	return d.PlanSyncDatabaseSchemaSpec(spec)
+}
+
+func (d *Database) PlanSyncDatabaseSchemaSpec(spec *schemasv1alpha4.DatabaseSchemaSpec) ([]string, error) {
+	var databaseSchema interface{}
+	switch d.Driver {
+	case "postgres", "timescaledb":
+		if spec.Postgres == nil {
+			return []string{}, nil
+		}
+		databaseSchema = spec.Postgres
+	case "cassandra":
+		// cassandra schemas are keyspaces
+		if spec.Cassandra == nil {
+			return []string{}, nil
+		}
+		databaseSchema = spec.Cassandra
+	default:
+		return nil, errors.Errorf("driver %s does not support schemas", d.Driver)
+	}
+
+	conn, err := d.GetConnection(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database connection")
+	}
+	defer conn.Close()
+
+	return conn.PlanDatabaseSchema(spec.Name, databaseSchema)
+}
+
+func (d *Database) planSequenceSync(specContents []byte) ([]string, error) {
+	var spec *schemasv1alpha4.SequenceSpec
+	parsedK8sObject := schemasv1alpha4.Sequence{}
+	if err := yaml.Unmarshal(specContents, &parsedK8sObject); err == nil {
+		if parsedK8sObject.Spec.Database != "" {
+			spec = &parsedK8sObject.Spec
+		}
+	}
+
+	if spec == nil {
+		plainSpec := schemasv1alpha4.SequenceSpec{}
+		if err := yaml.Unmarshal(specContents, &plainSpec); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal sequence spec")
+		}
+
+		spec = &plainSpec
+	}
+
+	return d.PlanSyncSequenceSpec(spec)
+}
+
+func (d *Database) PlanSyncSequenceSpec(spec *schemasv1alpha4.SequenceSpec) ([]string, error) {
+	if d.Driver != "postgres" && d.Driver != "timescaledb" {
+		return nil, errors.Errorf("driver %s does not support sequences", d.Driver)
+	}
+	if spec.Postgres == nil {
+		return []string{}, nil
+	}
+
+	conn, err := d.GetConnection(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database connection")
+	}
+	defer conn.Close()
+
+	return conn.PlanSequenceSchema(spec.Name, spec.Postgres)
+}
+
 // generateFixturesFromLib generates fixtures using the plugin lib packages directly
 // without requiring a database connection. This is used for fixture generation.
@@ -62,6 +62,27 @@ language PLpgSQL;`,
 				`create function test.get_user_count() returns bigint as $_SCHEMAHERO_$ DECLARE user_count bigint; BEGIN SELECT COUNT(*) INTO user_count FROM users; RETURN user_count; END; $_SCHEMAHERO_$ language PLpgSQL;`,
 			},
 		},
+		{
+			name: "mysql procedure with tagged body",
+			ddl: "create procedure `archive_orders` (in `cutoff` datetime) modifies sql data\n" +
+				"-- Function body follows\n" +
+				"BEGIN\n" +
+				"    -- move old orders\n" +
+				"    INSERT INTO orders_archive SELECT * FROM orders WHERE created_at < cutoff;\n" +
+				"    DELETE FROM orders WHERE created_at < cutoff;\n" +
+				"END\n" +
+				"-- Function body follows;\n" +
+				"drop table `orders_tmp`;\n",
+			wantStatements: []string{
+				"create procedure `archive_orders` (in `cutoff` datetime) modifies sql data\n" +
+					"BEGIN\n" +
+					"    -- move old orders\n" +
+					"    INSERT INTO orders_archive SELECT * FROM orders WHERE created_at < cutoff;\n" +
+					"    DELETE FROM orders WHERE created_at < cutoff;\n" +
+					"END;",
+				"drop table `orders_tmp`;",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -205,6 +226,94 @@ spec:{}`,
 				},
 			},
 		},
+		{
+			name:   "sort schemas and sequences before tables",
+			driver: "postgres",
+			specs: []types.Spec{
+				{
+					SourceFilename: "a-table.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: a-table
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "b-view.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: View
+metadata:
+  name: b-view
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "c-sequence.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: Sequence
+metadata:
+  name: c-sequence
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "d-schema.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: DatabaseSchema
+metadata:
+  name: d-schema
+spec: {}`,
+					),
+				},
+			},
+			want: []types.Spec{
+				{
+					SourceFilename: "d-schema.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: DatabaseSchema
+metadata:
+  name: d-schema
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "c-sequence.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: Sequence
+metadata:
+  name: c-sequence
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "a-table.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: a-table
+spec: {}`,
+					),
+				},
+				{
+					SourceFilename: "b-view.yaml",
+					Spec: []byte(`
+apiVersion: schemas.schemahero.io/v1alpha4
+kind: View
+metadata:
+  name: b-view
+spec: {}`,
+					),
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
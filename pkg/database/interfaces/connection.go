@@ -24,9 +24,14 @@ type SchemaHeroDatabaseConnection interface {
 	PlanViewSchema(viewName string, viewSchema interface{}) ([]string, error)
 	PlanFunctionSchema(functionName string, functionSchema interface{}) ([]string, error)
 	PlanExtensionSchema(extensionName string, extensionSchema interface{}) ([]string, error)
+	PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error)
+	PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error)
+	PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error)
 
 	// Deployment methods - execute SQL statements
 	DeployStatements(statements []string) error
+	// GetOnlineCopyProgress returns the progress of a running shadow table copy, or nil when none is running
+	GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error)
 
 	// Fixture generation
 	GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error)
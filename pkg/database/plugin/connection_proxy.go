@@ -312,6 +312,81 @@ func (c *ConnectionProxy) PlanExtensionSchema(extensionName string, extensionSch
 	return reply.Statements, nil
 }
 
+// PlanGrantSchema implements interfaces.SchemaHeroDatabaseConnection.PlanGrantSchema()
+func (c *ConnectionProxy) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	var reply ConnectionPlanGrantSchemaReply
+	err := c.client.Call("Plugin.ConnectionPlanGrantSchema", &ConnectionPlanGrantSchemaArgs{
+		ConnectionID: c.connectionID,
+		GrantName:    grantName,
+		GrantSchema:  grantSchema,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.Error != "" {
+		return nil, &BasicError{Message: reply.Error}
+	}
+
+	return reply.Statements, nil
+}
+
+// PlanDatabaseSchema implements interfaces.SchemaHeroDatabaseConnection.PlanDatabaseSchema()
+func (c *ConnectionProxy) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	var reply ConnectionPlanDatabaseSchemaReply
+	err := c.client.Call("Plugin.ConnectionPlanDatabaseSchema", &ConnectionPlanDatabaseSchemaArgs{
+		ConnectionID:   c.connectionID,
+		SchemaName:     schemaName,
+		DatabaseSchema: databaseSchema,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.Error != "" {
+		return nil, &BasicError{Message: reply.Error}
+	}
+
+	return reply.Statements, nil
+}
+
+// PlanSequenceSchema implements interfaces.SchemaHeroDatabaseConnection.PlanSequenceSchema()
+func (c *ConnectionProxy) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	var reply ConnectionPlanSequenceSchemaReply
+	err := c.client.Call("Plugin.ConnectionPlanSequenceSchema", &ConnectionPlanSequenceSchemaArgs{
+		ConnectionID:   c.connectionID,
+		SequenceName:   sequenceName,
+		SequenceSchema: sequenceSchema,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.Error != "" {
+		return nil, &BasicError{Message: reply.Error}
+	}
+
+	return reply.Statements, nil
+}
+
+// GetOnlineCopyProgress implements interfaces.SchemaHeroDatabaseConnection.GetOnlineCopyProgress()
+func (c *ConnectionProxy) GetOnlineCopyProgress(table string) (*types.OnlineCopyProgress, error) {
+	var reply ConnectionGetOnlineCopyProgressReply
+	err := c.client.Call("Plugin.ConnectionGetOnlineCopyProgress", &ConnectionGetOnlineCopyProgressArgs{
+		ConnectionID: c.connectionID,
+		Table:        table,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply.Error != "" {
+		return nil, &BasicError{Message: reply.Error}
+	}
+
+	return reply.Progress, nil
+}
+
 // DeployStatements implements interfaces.SchemaHeroDatabaseConnection.DeployStatements()
 func (c *ConnectionProxy) DeployStatements(statements []string) error {
 	var reply ConnectionDeployStatementsReply
@@ -551,6 +626,45 @@ type ConnectionPlanExtensionSchemaReply struct {
 	Error      string
 }
 
+// ConnectionPlanGrantSchemaArgs represents the arguments for the ConnectionPlanGrantSchema RPC call.
+type ConnectionPlanGrantSchemaArgs struct {
+	ConnectionID string
+	GrantName    string
+	GrantSchema  interface{}
+}
+
+// ConnectionPlanGrantSchemaReply represents the response for the ConnectionPlanGrantSchema RPC call.
+type ConnectionPlanGrantSchemaReply struct {
+	Statements []string
+	Error      string
+}
+
+// ConnectionPlanDatabaseSchemaArgs represents the arguments for the ConnectionPlanDatabaseSchema RPC call.
+type ConnectionPlanDatabaseSchemaArgs struct {
+	ConnectionID   string
+	SchemaName     string
+	DatabaseSchema interface{}
+}
+
+// ConnectionPlanDatabaseSchemaReply represents the response for the ConnectionPlanDatabaseSchema RPC call.
+type ConnectionPlanDatabaseSchemaReply struct {
+	Statements []string
+	Error      string
+}
+
+// ConnectionPlanSequenceSchemaArgs represents the arguments for the ConnectionPlanSequenceSchema RPC call.
+type ConnectionPlanSequenceSchemaArgs struct {
+	ConnectionID   string
+	SequenceName   string
+	SequenceSchema interface{}
+}
+
+// ConnectionPlanSequenceSchemaReply represents the response for the ConnectionPlanSequenceSchema RPC call.
+type ConnectionPlanSequenceSchemaReply struct {
+	Statements []string
+	Error      string
+}
+
 // ConnectionDeployStatementsArgs represents the arguments for the ConnectionDeployStatements RPC call.
 type ConnectionDeployStatementsArgs struct {
 	ConnectionID string
@@ -574,6 +688,18 @@ type ConnectionGenerateFixturesReply struct {
 	Error      string
 }
 
+// ConnectionGetOnlineCopyProgressArgs represents the arguments for the ConnectionGetOnlineCopyProgress RPC call.
+type ConnectionGetOnlineCopyProgressArgs struct {
+	ConnectionID string
+	Table        string
+}
+
+// ConnectionGetOnlineCopyProgressReply represents the response for the ConnectionGetOnlineCopyProgress RPC call.
+type ConnectionGetOnlineCopyProgressReply struct {
+	Progress *types.OnlineCopyProgress
+	Error    string
+}
+
 // BasicError represents a basic error that can be transmitted over RPC.
 type BasicError struct {
 	Message string
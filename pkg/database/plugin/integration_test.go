@@ -242,6 +242,30 @@ func (c *TestConnection) PlanExtensionSchema(extensionName string, extensionSche
 	return []string{fmt.Sprintf("CREATE EXTENSION %s", extensionName)}, nil
 }
 
+// PlanGrantSchema implements interfaces.SchemaHeroDatabaseConnection.PlanGrantSchema()
+func (c *TestConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	// Test implementation - return sample statements
+	return []string{fmt.Sprintf("GRANT SELECT ON %s TO test", grantName)}, nil
+}
+
+// PlanDatabaseSchema implements interfaces.SchemaHeroDatabaseConnection.PlanDatabaseSchema()
+func (c *TestConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	// Test implementation - return sample statements
+	return []string{fmt.Sprintf("CREATE SCHEMA %s", schemaName)}, nil
+}
+
+// PlanSequenceSchema implements interfaces.SchemaHeroDatabaseConnection.PlanSequenceSchema()
+func (c *TestConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	// Test implementation - return sample statements
+	return []string{fmt.Sprintf("CREATE SEQUENCE %s", sequenceName)}, nil
+}
+
+// GetOnlineCopyProgress implements interfaces.SchemaHeroDatabaseConnection.GetOnlineCopyProgress()
+func (c *TestConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	// Test implementation - no copy is running
+	return nil, nil
+}
+
 // DeployStatements implements interfaces.SchemaHeroDatabaseConnection.DeployStatements()
 func (c *TestConnection) DeployStatements(statements []string) error {
 	// Test implementation - just return success
@@ -526,6 +526,69 @@ func (s *RPCServer) ConnectionPlanExtensionSchema(args *ConnectionPlanExtensionS
 	return nil
 }
 
+// ConnectionPlanGrantSchema handles RPC calls for planning grant schema changes.
+func (s *RPCServer) ConnectionPlanGrantSchema(args *ConnectionPlanGrantSchemaArgs, reply *ConnectionPlanGrantSchemaReply) error {
+	s.connectionsMutex.RLock()
+	conn, exists := s.connections[args.ConnectionID]
+	s.connectionsMutex.RUnlock()
+
+	if !exists {
+		reply.Error = fmt.Sprintf("connection %s not found", args.ConnectionID)
+		return nil
+	}
+
+	statements, err := conn.PlanGrantSchema(args.GrantName, args.GrantSchema)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.Statements = statements
+	return nil
+}
+
+// ConnectionPlanDatabaseSchema handles RPC calls for planning database schema changes.
+func (s *RPCServer) ConnectionPlanDatabaseSchema(args *ConnectionPlanDatabaseSchemaArgs, reply *ConnectionPlanDatabaseSchemaReply) error {
+	s.connectionsMutex.RLock()
+	conn, exists := s.connections[args.ConnectionID]
+	s.connectionsMutex.RUnlock()
+
+	if !exists {
+		reply.Error = fmt.Sprintf("connection %s not found", args.ConnectionID)
+		return nil
+	}
+
+	statements, err := conn.PlanDatabaseSchema(args.SchemaName, args.DatabaseSchema)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.Statements = statements
+	return nil
+}
+
+// ConnectionPlanSequenceSchema handles RPC calls for planning sequence changes.
+func (s *RPCServer) ConnectionPlanSequenceSchema(args *ConnectionPlanSequenceSchemaArgs, reply *ConnectionPlanSequenceSchemaReply) error {
+	s.connectionsMutex.RLock()
+	conn, exists := s.connections[args.ConnectionID]
+	s.connectionsMutex.RUnlock()
+
+	if !exists {
+		reply.Error = fmt.Sprintf("connection %s not found", args.ConnectionID)
+		return nil
+	}
+
+	statements, err := conn.PlanSequenceSchema(args.SequenceName, args.SequenceSchema)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.Statements = statements
+	return nil
+}
+
 // ConnectionDeployStatements handles RPC calls for deploying SQL statements.
 func (s *RPCServer) ConnectionDeployStatements(args *ConnectionDeployStatementsArgs, reply *ConnectionDeployStatementsReply) error {
 	s.connectionsMutex.RLock()
@@ -720,3 +783,24 @@ func (c *RPCClient) Shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+// ConnectionGetOnlineCopyProgress handles RPC calls for getting the progress of a shadow table copy.
+func (s *RPCServer) ConnectionGetOnlineCopyProgress(args *ConnectionGetOnlineCopyProgressArgs, reply *ConnectionGetOnlineCopyProgressReply) error {
+	s.connectionsMutex.RLock()
+	conn, exists := s.connections[args.ConnectionID]
+	s.connectionsMutex.RUnlock()
+
+	if !exists {
+		reply.Error = fmt.Sprintf("connection %s not found", args.ConnectionID)
+		return nil
+	}
+
+	progress, err := conn.GetOnlineCopyProgress(args.Table)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.Progress = progress
+	return nil
+}
@@ -39,11 +39,24 @@ func RegisterSchemaTypes() {
 	// Register view schema types
 	gob.Register(&schemasv1alpha4.NotImplementedViewSchema{})
 	gob.Register(&schemasv1alpha4.TimescaleDBViewSchema{})
+	gob.Register(&schemasv1alpha4.CassandraViewSchema{})
 
 	// Register function schema types
 	gob.Register(&schemasv1alpha4.PostgresqlFunctionSchema{})
+	gob.Register(&schemasv1alpha4.MysqlFunctionSchema{})
+	gob.Register(&schemasv1alpha4.MysqlFunctionParameter{})
 	gob.Register(&schemasv1alpha4.NotImplementedFunctionSchema{})
 
+	// Register grant schema types
+	gob.Register(&schemasv1alpha4.PostgresqlGrantSchema{})
+	gob.Register(&schemasv1alpha4.MysqlGrantSchema{})
+	gob.Register(&schemasv1alpha4.NotImplementedGrantSchema{})
+
+	// Register database schema and sequence types
+	gob.Register(&schemasv1alpha4.PostgresqlDatabaseSchema{})
+	gob.Register(&schemasv1alpha4.CassandraKeyspaceSchema{})
+	gob.Register(&schemasv1alpha4.PostgresqlSequenceSchema{})
+
 	// Register extension and utility types
 	gob.Register(&schemasv1alpha4.PostgresDatabaseExtension{})
 	gob.Register(&schemasv1alpha4.SeedData{})
@@ -55,6 +68,8 @@ func RegisterSchemaTypes() {
 	gob.Register(&schemasv1alpha4.PostgresqlTableForeignKey{})
 	gob.Register(&schemasv1alpha4.PostgresqlTableIndex{})
 	gob.Register(&schemasv1alpha4.PostgresqlTableTrigger{})
+	gob.Register(&schemasv1alpha4.PostgresqlTableRowLevelSecurity{})
+	gob.Register(&schemasv1alpha4.PostgresqlTablePolicy{})
 
 	// Register MySQL nested types
 	gob.Register(&schemasv1alpha4.MysqlTableColumn{})
@@ -62,12 +77,17 @@ func RegisterSchemaTypes() {
 	gob.Register(&schemasv1alpha4.MysqlTableColumnAttributes{})
 	gob.Register(&schemasv1alpha4.MysqlTableForeignKey{})
 	gob.Register(&schemasv1alpha4.MysqlTableIndex{})
+	gob.Register(&schemasv1alpha4.MysqlTableTrigger{})
 
 	// Register SQLite nested types
 	gob.Register(&schemasv1alpha4.SqliteTableColumn{})
 	gob.Register(&schemasv1alpha4.SqliteTableColumnConstraints{})
 	gob.Register(&schemasv1alpha4.SqliteTableForeignKey{})
 	gob.Register(&schemasv1alpha4.SqliteTableIndex{})
+	gob.Register(&schemasv1alpha4.SqliteTableColumnGenerated{})
+	gob.Register(&schemasv1alpha4.SqliteTableCheck{})
+	gob.Register(&schemasv1alpha4.SqliteTableTrigger{})
+	gob.Register(&schemasv1alpha4.SqliteTableVirtual{})
 
 	// Register RQLite nested types
 	gob.Register(&schemasv1alpha4.RqliteTableColumn{})
@@ -76,10 +96,15 @@ func RegisterSchemaTypes() {
 	gob.Register(&schemasv1alpha4.RqliteTableForeignKey{})
 	gob.Register(&schemasv1alpha4.RqliteTableForeignKeyReferences{})
 	gob.Register(&schemasv1alpha4.RqliteTableIndex{})
+	gob.Register(&schemasv1alpha4.RqliteTableColumnGenerated{})
+	gob.Register(&schemasv1alpha4.RqliteTableCheck{})
+	gob.Register(&schemasv1alpha4.RqliteTableTrigger{})
 
 	// Register Cassandra nested types
 	gob.Register(&schemasv1alpha4.CassandraColumn{})
 	gob.Register(&schemasv1alpha4.CassandraClusteringOrder{})
+	gob.Register(&schemasv1alpha4.CassandraTableIndex{})
+	gob.Register(&schemasv1alpha4.CassandraPrimaryKeyChange{})
 	gob.Register(&schemasv1alpha4.CassandraTableProperties{})
 	gob.Register(&schemasv1alpha4.CassandraField{})
 	gob.Register(&schemasv1alpha4.CassandraDataTypeSchema{})
@@ -0,0 +1,245 @@
+package rebuild
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var (
+	checkConstraintRegexp = regexp.MustCompile(`(?is)^(?:constraint\s+("[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\S+)\s+)?check\s*\(`)
+	generatedRegexp       = regexp.MustCompile(`(?is)\s(?:generated\s+always\s+)?as\s*\(`)
+	storageRegexp         = regexp.MustCompile(`(?is)^\s*(stored|virtual)\b`)
+	operatorSpaceRegexp   = regexp.MustCompile(`\s*([^\w\s])\s*`)
+	withoutRowidRegexp    = regexp.MustCompile(`(?i)\bwithout\s+rowid\b`)
+)
+
+// Check is a check constraint of a table
+type Check struct {
+	Name       string
+	Expression string
+}
+
+// Generated is the expression of a generated column
+type Generated struct {
+	Expression string
+	Stored     bool
+}
+
+// Definition is the part of a table that is only recorded in the create table statement in sqlite_master
+type Definition struct {
+	Checks []*Check
+	// Generated are the generated columns, by name
+	Generated    map[string]*Generated
+	WithoutRowid bool
+}
+
+// SplitTopLevel splits the definitions in the parentheses of a create table statement on the commas that
+// are not in parentheses or quotes
+func SplitTopLevel(body string) []string {
+	parts := []string{}
+	depth := 0
+	var quote rune
+	start := 0
+	for i, c := range body {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(body[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(body[start:]))
+}
+
+// EnclosedExpression returns the expression in the parentheses that start at the beginning of s
+// and the rest of s after the closing parenthesis
+func EnclosedExpression(s string) (string, string) {
+	depth := 0
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(s[1:i]), s[i+1:]
+			}
+		}
+	}
+	return strings.TrimSpace(strings.TrimPrefix(s, "(")), ""
+}
+
+// UnquoteIdentifier removes the double quotes, backticks or brackets around an identifier
+func UnquoteIdentifier(name string) string {
+	if len(name) >= 2 && (name[0] == '"' || name[0] == '`' || name[0] == '[') {
+		return name[1 : len(name)-1]
+	}
+	return name
+}
+
+// ParseDefinition reads the table checks, the generated columns and the table options from a create table statement
+func ParseDefinition(createTableSQL string) *Definition {
+	definition := Definition{
+		Checks:    []*Check{},
+		Generated: map[string]*Generated{},
+	}
+
+	open := strings.Index(createTableSQL, "(")
+	if open < 0 {
+		return &definition
+	}
+	body, options := EnclosedExpression(createTableSQL[open:])
+	definition.WithoutRowid = withoutRowidRegexp.MatchString(options)
+
+	for _, part := range SplitTopLevel(body) {
+		if match := checkConstraintRegexp.FindStringSubmatchIndex(part); match != nil {
+			check := Check{}
+			if match[2] >= 0 {
+				check.Name = UnquoteIdentifier(part[match[2]:match[3]])
+			}
+			check.Expression, _ = EnclosedExpression(part[match[1]-1:])
+			definition.Checks = append(definition.Checks, &check)
+			continue
+		}
+
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToLower(fields[0]) {
+		case "constraint", "primary", "foreign", "unique":
+			continue
+		}
+
+		columnName := UnquoteIdentifier(fields[0])
+		if match := generatedRegexp.FindStringIndex(part); match != nil {
+			expression, rest := EnclosedExpression(part[match[1]-1:])
+			generated := Generated{
+				Expression: expression,
+			}
+			if storage := storageRegexp.FindStringSubmatch(rest); storage != nil {
+				generated.Stored = strings.EqualFold(storage[1], "stored")
+			}
+			definition.Generated[columnName] = &generated
+		}
+	}
+
+	return &definition
+}
+
+// NormalizeExpression returns an expression for comparison, ignoring case, identifier quotes and the whitespace
+// between words and operators
+func NormalizeExpression(expression string) string {
+	expression = strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(strings.ToLower(expression))
+	expression = strings.Join(strings.Fields(expression), " ")
+	return operatorSpaceRegexp.ReplaceAllString(expression, "$1")
+}
+
+func checkKeys(checks []*Check) []string {
+	keys := []string{}
+	for _, check := range checks {
+		keys = append(keys, check.Name+":"+NormalizeExpression(check.Expression))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TableSchema is the schema of a sqlite or rqlite table
+type TableSchema interface {
+	*schemasv1alpha4.SqliteTableSchema | *schemasv1alpha4.RqliteTableSchema
+}
+
+// DefinitionChanged returns true when the checks, the generated columns or the rowid of the existing table are
+// different from the schema. sqlite cannot alter any of them, so the table has to be recreated. A generated column
+// that is not in the table is only a change when it is stored, because virtual columns can be added.
+func DefinitionChanged[T TableSchema](tableSchema T, existing *Definition, existingColumns map[string]bool) bool {
+	desired := Definition{
+		Checks:    []*Check{},
+		Generated: map[string]*Generated{},
+	}
+	columns := []string{}
+
+	switch schema := any(tableSchema).(type) {
+	case *schemasv1alpha4.SqliteTableSchema:
+		desired.WithoutRowid = schema.WithoutRowid
+		for _, check := range schema.Checks {
+			desired.Checks = append(desired.Checks, &Check{Name: check.Name, Expression: check.Expression})
+		}
+		for _, column := range schema.Columns {
+			columns = append(columns, column.Name)
+			if column.Generated != nil {
+				desired.Generated[column.Name] = &Generated{Expression: column.Generated.Expression, Stored: column.Generated.Stored}
+			}
+		}
+	case *schemasv1alpha4.RqliteTableSchema:
+		// rqlite tables do not set the rowid option, so it is left as it is
+		desired.WithoutRowid = existing.WithoutRowid
+		for _, check := range schema.Checks {
+			desired.Checks = append(desired.Checks, &Check{Name: check.Name, Expression: check.Expression})
+		}
+		for _, column := range schema.Columns {
+			columns = append(columns, column.Name)
+			if column.Generated != nil {
+				desired.Generated[column.Name] = &Generated{Expression: column.Generated.Expression, Stored: column.Generated.Stored}
+			}
+		}
+	}
+
+	return definitionChanged(&desired, columns, existing, existingColumns)
+}
+
+func definitionChanged(desired *Definition, columns []string, existing *Definition, existingColumns map[string]bool) bool {
+	if desired.WithoutRowid != existing.WithoutRowid {
+		return true
+	}
+
+	if strings.Join(checkKeys(desired.Checks), "\n") != strings.Join(checkKeys(existing.Checks), "\n") {
+		return true
+	}
+
+	for _, column := range columns {
+		generated := desired.Generated[column]
+		existingGenerated, isGenerated := existing.Generated[column]
+		if !existingColumns[column] {
+			if generated != nil && generated.Stored {
+				return true
+			}
+			continue
+		}
+		if generated == nil {
+			if isGenerated {
+				return true
+			}
+			continue
+		}
+		if !isGenerated {
+			return true
+		}
+		if generated.Stored != existingGenerated.Stored || NormalizeExpression(generated.Expression) != NormalizeExpression(existingGenerated.Expression) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package rebuild
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDefinition(t *testing.T) {
+	sql := `CREATE TABLE "orders" ("id" integer, "price" real, "quantity" integer, "label" text default 'a, (b)',
+	"total" real GENERATED ALWAYS AS (price * (quantity + 1)) STORED,
+	discounted real as (price * 0.9),
+	primary key ("id"), CONSTRAINT "positive_price" CHECK (price > 0), check (quantity >= 0))`
+
+	definition := ParseDefinition(sql)
+
+	assert.Equal(t, []*Check{
+		{Name: "positive_price", Expression: "price > 0"},
+		{Expression: "quantity >= 0"},
+	}, definition.Checks)
+	assert.Equal(t, map[string]*Generated{
+		"total":      {Expression: "price * (quantity + 1)", Stored: true},
+		"discounted": {Expression: "price * 0.9"},
+	}, definition.Generated)
+	assert.False(t, definition.WithoutRowid)
+
+	definition = ParseDefinition(`CREATE TABLE "tags" ("name" text, primary key ("name")) WITHOUT ROWID, STRICT`)
+	assert.True(t, definition.WithoutRowid)
+}
+
+func Test_DefinitionChanged(t *testing.T) {
+	existing := &Definition{
+		Checks: []*Check{
+			{Name: "positive_price", Expression: "price > 0"},
+		},
+		Generated: map[string]*Generated{
+			"total": {Expression: `"price" * "quantity"`, Stored: true},
+		},
+	}
+	checks := []*schemasv1alpha4.SqliteTableCheck{
+		{Name: "positive_price", Expression: "price > 0"},
+	}
+	existingColumns := map[string]bool{"price": true, "quantity": true, "total": true}
+
+	tests := []struct {
+		name        string
+		tableSchema *schemasv1alpha4.SqliteTableSchema
+		expect      bool
+	}{
+		{
+			name: "unchanged",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "price", Type: "real"},
+					{Name: "total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price*quantity", Stored: true}},
+					{Name: "virtual_total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * quantity"}},
+				},
+				Checks: []*schemasv1alpha4.SqliteTableCheck{
+					{Name: "positive_price", Expression: "PRICE > 0"},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "check changed",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * quantity", Stored: true}},
+				},
+				Checks: []*schemasv1alpha4.SqliteTableCheck{
+					{Name: "positive_price", Expression: "price >= 0"},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "generated expression changed",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * quantity * 2", Stored: true}},
+				},
+				Checks: checks,
+			},
+			expect: true,
+		},
+		{
+			name: "column no longer generated",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "total", Type: "real"},
+				},
+				Checks: checks,
+			},
+			expect: true,
+		},
+		{
+			name: "new stored generated column",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * quantity", Stored: true}},
+					{Name: "tax", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * 0.2", Stored: true}},
+				},
+				Checks: checks,
+			},
+			expect: true,
+		},
+		{
+			name: "without rowid",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "total", Type: "real", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "price * quantity", Stored: true}},
+				},
+				Checks:       checks,
+				WithoutRowid: true,
+			},
+			expect: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, DefinitionChanged(test.tableSchema, existing, existingColumns))
+		})
+	}
+
+	rqliteTableSchema := &schemasv1alpha4.RqliteTableSchema{
+		Columns: []*schemasv1alpha4.RqliteTableColumn{
+			{Name: "total", Type: "real", Generated: &schemasv1alpha4.RqliteTableColumnGenerated{Expression: "price * quantity", Stored: true}},
+		},
+		Checks: []*schemasv1alpha4.RqliteTableCheck{
+			{Name: "positive_price", Expression: "price > 0"},
+		},
+	}
+	assert.False(t, DefinitionChanged(rqliteTableSchema, existing, existingColumns))
+	assert.False(t, DefinitionChanged(rqliteTableSchema, &Definition{Checks: existing.Checks, Generated: existing.Generated, WithoutRowid: true}, existingColumns))
+
+	rqliteTableSchema.Checks[0].Expression = "price >= 0"
+	assert.True(t, DefinitionChanged(rqliteTableSchema, existing, existingColumns))
+}
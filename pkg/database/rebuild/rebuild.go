@@ -0,0 +1,264 @@
+// Package rebuild plans the rebuild of a sqlite table, for the changes that sqlite cannot make with alter table,
+// and reads the checks, generated columns and triggers that only a rebuild or a drop can change.
+// It is shared by the sqlite and rqlite plugins.
+package rebuild
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+const (
+	// ForeignKeysOff is the statement that turns off foreign key constraints before a rebuild. sqlite ignores it in a
+	// transaction, so it is the first statement
+	ForeignKeysOff = "pragma foreign_keys = off"
+	// ForeignKeysOn is the statement that turns on foreign key constraints after a rebuild
+	ForeignKeysOn = "pragma foreign_keys = on"
+)
+
+var foreignKeyCheckRegexp = regexp.MustCompile(`(?i)^\s*pragma\s+foreign_key_check\s*\(\s*"(.*)"\s*\)\s*$`)
+
+// Object is a trigger or view read from sqlite_master
+type Object struct {
+	Type string
+	Name string
+	SQL  string
+}
+
+// Column is a column of the table before or after the rebuild
+type Column struct {
+	Name    string
+	Type    string
+	NotNull bool
+	// HasDefault is true when the column has a default value
+	HasDefault bool
+	// Generated columns are computed, they cannot be copied
+	Generated bool
+}
+
+// Existing is the state of the table before the rebuild
+type Existing struct {
+	Columns []Column
+	// Dependents are the triggers on the table and the views that use it
+	Dependents []*Object
+	// ForeignKeys is true when the connection enforces foreign key constraints
+	ForeignKeys bool
+	// ReferencingTables are the other tables with a foreign key to the table
+	ReferencingTables []string
+}
+
+// Table is the table that the rebuild creates
+type Table struct {
+	Name string
+	// TempName is the name that the new table is created with, before it replaces the existing table
+	TempName string
+	// CreateTable is the statement that creates the new table with TempName
+	CreateTable string
+	Columns     []Column
+	// Indexes are the statements that create the indexes of the table
+	Indexes []string
+	// Triggers are the statements that create the triggers of the table. When nil, the existing triggers are
+	// created again
+	Triggers []string
+	// Transaction adds the statements that run the rebuild in a transaction. rqlite does not allow them, its plugin
+	// runs all the statements of a deploy in a transaction instead
+	Transaction bool
+}
+
+// TempTableName returns the name to create the new table with. To make the statements deterministic (and testable),
+// the name includes a hash of the new schema
+func TempTableName(tableName string, schema interface{}) (string, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal")
+	}
+	sum := sha256.Sum256(b)
+
+	return fmt.Sprintf("%s_%x", tableName, sum), nil
+}
+
+// Statements returns the statements that rebuild the table, following the procedure that sqlite documents for
+// changes that alter table cannot make (https://www.sqlite.org/lang_altertable.html#otheralter):
+// the new table is created with a temporary name, the rows are copied to it, the existing table is dropped and
+// the new table is renamed. Renaming the existing table instead would rewrite the foreign keys of the tables
+// that reference it. The views that use the table are dropped first, because sqlite checks them when a table is
+// renamed, and are created again with the indexes and triggers. When the connection enforces foreign keys, they
+// are turned off for the rebuild so that dropping the table does not delete or fail on referencing rows, and are
+// checked before the rebuild is committed.
+func Statements(table *Table, existing *Existing) []string {
+	views := Filter(existing.Dependents, "view")
+	triggers := Filter(existing.Dependents, "trigger")
+
+	statements := []string{}
+	if existing.ForeignKeys {
+		statements = append(statements, ForeignKeysOff)
+	}
+	if table.Transaction {
+		statements = append(statements, "begin transaction")
+	}
+
+	for _, view := range views {
+		statements = append(statements, fmt.Sprintf(`drop view "%s"`, view.Name))
+	}
+
+	statements = append(statements, table.CreateTable)
+
+	columnNames := CopiedColumns(table.Columns, existing.Columns)
+	if len(columnNames) > 0 {
+		quoted := []string{}
+		for _, columnName := range columnNames {
+			quoted = append(quoted, fmt.Sprintf(`"%s"`, columnName))
+		}
+		insert := fmt.Sprintf(`insert into "%s" (%s) select %s from "%s"`, table.TempName, strings.Join(quoted, ", "), strings.Join(quoted, ", "), table.Name)
+
+		// the data that the rebuild drops or converts is reported in the plan
+		for _, warning := range DataWarnings(table.Name, table.Columns, existing.Columns) {
+			insert = fmt.Sprintf("%s\n%s", types.WarningComment(warning), insert)
+		}
+		statements = append(statements, insert)
+	}
+
+	// the triggers of the table are dropped with it
+	statements = append(statements,
+		fmt.Sprintf(`%s drop table "%s"`, types.RebuildMarker, table.Name),
+		fmt.Sprintf(`alter table "%s" rename to "%s"`, table.TempName, table.Name),
+	)
+
+	statements = append(statements, table.Indexes...)
+	if table.Triggers != nil {
+		statements = append(statements, table.Triggers...)
+	} else {
+		for _, trigger := range triggers {
+			statements = append(statements, trigger.SQL)
+		}
+	}
+	for _, view := range views {
+		statements = append(statements, view.SQL)
+	}
+
+	if existing.ForeignKeys {
+		statements = append(statements, ForeignKeyCheckStatement(table.Name))
+		for _, referencingTable := range existing.ReferencingTables {
+			statements = append(statements, ForeignKeyCheckStatement(referencingTable))
+		}
+	}
+
+	if table.Transaction {
+		statements = append(statements, "commit")
+	}
+	if existing.ForeignKeys {
+		statements = append(statements, ForeignKeysOn)
+	}
+
+	return statements
+}
+
+// CopiedColumns returns the columns of the new table that the rows of the existing table are copied to
+func CopiedColumns(columns []Column, existingColumns []Column) []string {
+	columnNames := []string{}
+	for _, column := range columns {
+		if column.Generated {
+			continue
+		}
+		for _, existingColumn := range existingColumns {
+			if existingColumn.Name == column.Name {
+				columnNames = append(columnNames, column.Name)
+				break
+			}
+		}
+	}
+	return columnNames
+}
+
+// DataWarnings returns the data of the existing table that the rebuild drops or converts, and the changes that
+// fail the rebuild when a row does not fit the new table
+func DataWarnings(tableName string, columns []Column, existingColumns []Column) []string {
+	warnings := []string{}
+
+	for _, existingColumn := range existingColumns {
+		if existingColumn.Generated {
+			continue
+		}
+		found := false
+		for _, column := range columns {
+			if column.Name == existingColumn.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("the data in column %s of table %s is dropped", existingColumn.Name, tableName))
+		}
+	}
+
+	for _, column := range columns {
+		var existingColumn *Column
+		for i := range existingColumns {
+			if existingColumns[i].Name == column.Name {
+				existingColumn = &existingColumns[i]
+				break
+			}
+		}
+
+		if existingColumn == nil {
+			if column.NotNull && !column.HasDefault && !column.Generated {
+				warnings = append(warnings, fmt.Sprintf("column %s of table %s is added as not null without a default, the rebuild fails if the table has rows", column.Name, tableName))
+			}
+			continue
+		}
+
+		if column.Generated {
+			if !existingColumn.Generated {
+				warnings = append(warnings, fmt.Sprintf("the data in column %s of table %s is replaced by the generated value", column.Name, tableName))
+			}
+			continue
+		}
+
+		if column.Type != "" && existingColumn.Type != "" && !strings.EqualFold(strings.TrimSpace(column.Type), strings.TrimSpace(existingColumn.Type)) {
+			warnings = append(warnings, fmt.Sprintf("the data in column %s of table %s is converted from %s to %s", column.Name, tableName, existingColumn.Type, column.Type))
+		}
+		if column.NotNull && !existingColumn.NotNull {
+			warnings = append(warnings, fmt.Sprintf("column %s of table %s becomes not null, the rebuild fails if a row has a null value in it", column.Name, tableName))
+		}
+	}
+
+	return warnings
+}
+
+// ForeignKeyCheckStatement returns the statement that checks the foreign keys of the table. It returns a row for
+// each violation, so it has to be run as a query and fail when there are rows
+func ForeignKeyCheckStatement(tableName string) string {
+	return fmt.Sprintf(`pragma foreign_key_check("%s")`, tableName)
+}
+
+// IsForeignKeyCheck returns true when the statement is a foreign key check
+func IsForeignKeyCheck(statement string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(statement)), "pragma foreign_key_check")
+}
+
+// ForeignKeyCheckTableName returns the table of a foreign key check statement, or an empty string when the
+// statement is not a foreign key check of a table
+func ForeignKeyCheckTableName(statement string) string {
+	matches := foreignKeyCheckRegexp.FindStringSubmatch(statement)
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
+// Filter returns the objects of the type
+func Filter(objects []*Object, objectType string) []*Object {
+	filtered := []*Object{}
+	for _, object := range objects {
+		if object.Type == objectType {
+			filtered = append(filtered, object)
+		}
+	}
+	return filtered
+}
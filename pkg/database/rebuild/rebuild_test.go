@@ -0,0 +1,131 @@
+package rebuild
+
+import (
+	"testing"
+
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Statements(t *testing.T) {
+	table := &Table{
+		Name:        "users",
+		TempName:    "users_new",
+		CreateTable: `create table "users_new" ("id" integer, "email" text not null, primary key ("id"))`,
+		Columns: []Column{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text", NotNull: true},
+		},
+		Indexes: []string{
+			`create unique index idx_users_email on users (email)`,
+		},
+	}
+	existing := &Existing{
+		Columns: []Column{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text", NotNull: true},
+		},
+		Dependents: []*Object{
+			{Type: "trigger", Name: "users_audit", SQL: `CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`},
+			{Type: "view", Name: "user_emails", SQL: `CREATE VIEW user_emails AS select email from users`},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		transaction        bool
+		foreignKeys        bool
+		triggers           []string
+		expectedStatements []string
+	}{
+		{
+			name:        "transaction with foreign keys",
+			transaction: true,
+			foreignKeys: true,
+			expectedStatements: []string{
+				"pragma foreign_keys = off",
+				"begin transaction",
+				`drop view "user_emails"`,
+				`create table "users_new" ("id" integer, "email" text not null, primary key ("id"))`,
+				`insert into "users_new" ("id", "email") select "id", "email" from "users"`,
+				types.RebuildMarker + ` drop table "users"`,
+				`alter table "users_new" rename to "users"`,
+				`create unique index idx_users_email on users (email)`,
+				`CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`,
+				`CREATE VIEW user_emails AS select email from users`,
+				`pragma foreign_key_check("users")`,
+				`pragma foreign_key_check("orders")`,
+				"commit",
+				"pragma foreign_keys = on",
+			},
+		},
+		{
+			name:     "without a transaction or foreign keys, with declared triggers",
+			triggers: []string{},
+			expectedStatements: []string{
+				`drop view "user_emails"`,
+				`create table "users_new" ("id" integer, "email" text not null, primary key ("id"))`,
+				`insert into "users_new" ("id", "email") select "id", "email" from "users"`,
+				types.RebuildMarker + ` drop table "users"`,
+				`alter table "users_new" rename to "users"`,
+				`create unique index idx_users_email on users (email)`,
+				`CREATE VIEW user_emails AS select email from users`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table.Transaction = test.transaction
+			table.Triggers = test.triggers
+			existing.ForeignKeys = test.foreignKeys
+			existing.ReferencingTables = []string{"orders"}
+
+			assert.Equal(t, test.expectedStatements, Statements(table, existing))
+		})
+	}
+}
+
+func Test_DataWarnings(t *testing.T) {
+	existingColumns := []Column{
+		{Name: "id", Type: "integer"},
+		{Name: "email", Type: "text"},
+		{Name: "price", Type: "text"},
+		{Name: "total", Type: "real"},
+		{Name: "domain", Type: "text", Generated: true},
+	}
+	columns := []Column{
+		{Name: "id", Type: "INTEGER", NotNull: true},
+		{Name: "price", Type: "real"},
+		{Name: "total", Type: "real", Generated: true},
+		{Name: "status", Type: "text", NotNull: true},
+		{Name: "created_at", Type: "text", NotNull: true, HasDefault: true},
+	}
+
+	assert.Equal(t, []string{
+		"the data in column email of table orders is dropped",
+		"column id of table orders becomes not null, the rebuild fails if a row has a null value in it",
+		"the data in column price of table orders is converted from text to real",
+		"the data in column total of table orders is replaced by the generated value",
+		"column status of table orders is added as not null without a default, the rebuild fails if the table has rows",
+	}, DataWarnings("orders", columns, existingColumns))
+	assert.Equal(t, []string{"id", "price"}, CopiedColumns(columns, existingColumns))
+
+	statements := Statements(&Table{
+		Name:        "orders",
+		TempName:    "orders_new",
+		CreateTable: `create table "orders_new" ("id" integer)`,
+		Columns:     columns,
+	}, &Existing{Columns: existingColumns})
+	assert.Len(t, types.MigrationWarnings(statements), 5)
+}
+
+func Test_IsForeignKeyCheck(t *testing.T) {
+	assert.True(t, IsForeignKeyCheck(ForeignKeyCheckStatement("users")))
+	assert.True(t, IsForeignKeyCheck("  PRAGMA foreign_key_check"))
+	assert.False(t, IsForeignKeyCheck(ForeignKeysOff))
+
+	assert.Equal(t, "users", ForeignKeyCheckTableName(ForeignKeyCheckStatement("users")))
+	assert.Equal(t, "user orders", ForeignKeyCheckTableName(`PRAGMA foreign_key_check ("user orders")`))
+	assert.Equal(t, "", ForeignKeyCheckTableName("pragma foreign_key_check"))
+}
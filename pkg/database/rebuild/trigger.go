@@ -0,0 +1,121 @@
+package rebuild
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var triggerEventRegexp = regexp.MustCompile(`(?i)^(before|after|instead\s+of)\s+(insert|delete|update)(\s+of\s+.+)?$`)
+
+// TriggerSchema is a trigger of a sqlite or rqlite table schema
+type TriggerSchema interface {
+	schemasv1alpha4.SqliteTableTrigger | schemasv1alpha4.RqliteTableTrigger
+}
+
+// tableTrigger is a trigger that runs for each row of a table, the fields that the trigger schemas share
+type tableTrigger struct {
+	Name       string
+	Event      string
+	Condition  *string
+	Statements []string
+}
+
+// CreateTriggerStatement returns the statement to create a trigger that runs for each row of the table
+func CreateTriggerStatement[T TriggerSchema](tableName string, triggerSchema *T) (string, error) {
+	trigger := tableTrigger(*triggerSchema)
+
+	if trigger.Name == "" {
+		return "", errors.Errorf("trigger on table %s requires a name", tableName)
+	}
+
+	event := strings.Join(strings.Fields(trigger.Event), " ")
+	if !triggerEventRegexp.MatchString(event) {
+		return "", errors.Errorf("trigger %s has an invalid event %q, expected before, after or instead of insert, delete or update", trigger.Name, trigger.Event)
+	}
+
+	body := []string{}
+	for _, statement := range trigger.Statements {
+		statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+		if statement != "" {
+			body = append(body, fmt.Sprintf("%s;", statement))
+		}
+	}
+	if len(body) == 0 {
+		return "", errors.Errorf("trigger %s requires at least one statement", trigger.Name)
+	}
+
+	stmt := fmt.Sprintf(`create trigger "%s" %s on "%s" for each row`, trigger.Name, strings.ToLower(event), tableName)
+	if trigger.Condition != nil {
+		stmt = fmt.Sprintf("%s when %s", stmt, *trigger.Condition)
+	}
+
+	return fmt.Sprintf("%s begin %s end", stmt, strings.Join(body, " ")), nil
+}
+
+// normalizeSQL returns a statement for comparison, ignoring case, whitespace and identifier quotes
+func normalizeSQL(sql string) string {
+	return strings.TrimSuffix(NormalizeExpression(sql), ";")
+}
+
+// TriggerStatements returns the statements to move the triggers of the table from the existing triggers to the schema.
+// A trigger cannot be altered, so a changed trigger is dropped and created again. When the schema does not list
+// triggers, the triggers of the table are not managed and are left as they are.
+func TriggerStatements[T TriggerSchema](tableName string, triggerSchemas []*T, existing []*Object) ([]string, error) {
+	if triggerSchemas == nil {
+		return []string{}, nil
+	}
+
+	desired := map[string]bool{}
+	for _, triggerSchema := range triggerSchemas {
+		desired[tableTrigger(*triggerSchema).Name] = true
+	}
+
+	statements := []string{}
+	existingByName := map[string]*Object{}
+	for _, existingTrigger := range existing {
+		if !desired[existingTrigger.Name] {
+			statements = append(statements, fmt.Sprintf(`drop trigger "%s"`, existingTrigger.Name))
+			continue
+		}
+		existingByName[existingTrigger.Name] = existingTrigger
+	}
+
+	for _, triggerSchema := range triggerSchemas {
+		trigger := tableTrigger(*triggerSchema)
+		statement, err := CreateTriggerStatement(tableName, triggerSchema)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingTrigger, ok := existingByName[trigger.Name]; ok {
+			if normalizeSQL(existingTrigger.SQL) == normalizeSQL(statement) {
+				continue
+			}
+			statements = append(statements, fmt.Sprintf(`drop trigger "%s"`, trigger.Name))
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+// DependentsQuery reads the triggers and views from sqlite_master, in the order that they were created
+const DependentsQuery = `select type, name, tbl_name, sql from sqlite_master where type in ('trigger', 'view') and sql is not null order by rowid`
+
+// IsDependent returns true when a row of DependentsQuery is a trigger on the table or a view that uses it
+func IsDependent(objectType string, objectTableName string, sql string, tableName string) bool {
+	if objectType == "trigger" {
+		return objectTableName == tableName
+	}
+	return objectType == "view" && referencesTable(sql, tableName)
+}
+
+// referencesTable returns true when the statement uses the table
+func referencesTable(sql string, tableName string) bool {
+	tableRegexp := regexp.MustCompile(fmt.Sprintf("(?i)(^|[^\\w])[\"`\\[]?%s[\"`\\]]?($|[^\\w])", regexp.QuoteMeta(tableName)))
+	return tableRegexp.MatchString(sql)
+}
@@ -0,0 +1,156 @@
+package rebuild
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsDependent(t *testing.T) {
+	tests := []struct {
+		name            string
+		objectType      string
+		objectTableName string
+		sql             string
+		expect          bool
+	}{
+		{
+			name:            "trigger on the table",
+			objectType:      "trigger",
+			objectTableName: "users",
+			sql:             `CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`,
+			expect:          true,
+		},
+		{
+			name:            "trigger on another table that uses the table",
+			objectType:      "trigger",
+			objectTableName: "orders",
+			sql:             `CREATE TRIGGER "orders_audit" AFTER INSERT ON "orders" BEGIN update users set n = 1; END`,
+			expect:          false,
+		},
+		{
+			name:            "view that uses the table",
+			objectType:      "view",
+			objectTableName: "user_domains",
+			sql:             "CREATE VIEW user_domains AS select domain from [users]",
+			expect:          true,
+		},
+		{
+			name:            "view on a table with a longer name",
+			objectType:      "view",
+			objectTableName: "user_names",
+			sql:             "CREATE VIEW user_names AS select name from users_archive",
+			expect:          false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, IsDependent(test.objectType, test.objectTableName, test.sql, "users"))
+		})
+	}
+}
+
+func Test_TriggerStatements(t *testing.T) {
+	condition := "new.email is not null"
+
+	audit := &schemasv1alpha4.SqliteTableTrigger{
+		Name:       "users_audit",
+		Event:      "after insert",
+		Statements: []string{"insert into audit (user_id) values (new.id)"},
+	}
+	existingAudit := &Object{
+		Type: "trigger",
+		Name: "users_audit",
+		SQL:  `CREATE TRIGGER "users_audit" AFTER INSERT ON "users" FOR EACH ROW BEGIN insert into audit (user_id) values (new.id); END`,
+	}
+
+	tests := []struct {
+		name               string
+		triggers           []*schemasv1alpha4.SqliteTableTrigger
+		existing           []*Object
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name:               "unmanaged",
+			existing:           []*Object{existingAudit},
+			expectedStatements: []string{},
+		},
+		{
+			name:               "unchanged",
+			triggers:           []*schemasv1alpha4.SqliteTableTrigger{audit},
+			existing:           []*Object{existingAudit},
+			expectedStatements: []string{},
+		},
+		{
+			name: "changed and added",
+			triggers: []*schemasv1alpha4.SqliteTableTrigger{
+				{
+					Name:       "users_audit",
+					Event:      "after update of email",
+					Condition:  &condition,
+					Statements: []string{"insert into audit (user_id) values (new.id)", "update users set updated_at = current_timestamp where id = new.id"},
+				},
+				{
+					Name:       "users_delete",
+					Event:      "before delete",
+					Statements: []string{"delete from sessions where user_id = old.id"},
+				},
+			},
+			existing: []*Object{existingAudit},
+			expectedStatements: []string{
+				`drop trigger "users_audit"`,
+				`create trigger "users_audit" after update of email on "users" for each row when new.email is not null begin insert into audit (user_id) values (new.id); update users set updated_at = current_timestamp where id = new.id; end`,
+				`create trigger "users_delete" before delete on "users" for each row begin delete from sessions where user_id = old.id; end`,
+			},
+		},
+		{
+			name:     "removed",
+			triggers: []*schemasv1alpha4.SqliteTableTrigger{},
+			existing: []*Object{existingAudit},
+			expectedStatements: []string{
+				`drop trigger "users_audit"`,
+			},
+		},
+		{
+			name: "invalid event",
+			triggers: []*schemasv1alpha4.SqliteTableTrigger{
+				{
+					Name:       "users_audit",
+					Event:      "insert",
+					Statements: []string{"select 1"},
+				},
+			},
+			wantErr: `trigger users_audit has an invalid event "insert", expected before, after or instead of insert, delete or update`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := TriggerStatements("users", test.triggers, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+
+	statements, err := TriggerStatements("users", []*schemasv1alpha4.RqliteTableTrigger{
+		{
+			Name:       "users_audit",
+			Event:      "after insert",
+			Statements: []string{"insert into audit (user_id) values (new.id)"},
+		},
+	}, []*Object{existingAudit})
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, statements)
+}
@@ -0,0 +1,321 @@
+package seeddata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"sigs.k8s.io/yaml"
+)
+
+// Source reads the data that a seed data valueFrom refers to
+type Source func(valueFrom *schemasv1alpha4.SeedDataValueFrom) ([]byte, error)
+
+// FileSource reads the file of a valueFrom, relative to dir
+func FileSource(dir string) Source {
+	return func(valueFrom *schemasv1alpha4.SeedDataValueFrom) ([]byte, error) {
+		if valueFrom.File == "" {
+			return nil, errors.New("seed data valueFrom must be a file when planning from a spec file")
+		}
+
+		filename := valueFrom.File
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(dir, filename)
+		}
+
+		b, err := os.ReadFile(filepath.Clean(filename))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read seed data file")
+		}
+		return b, nil
+	}
+}
+
+// Resolve returns the seed data with the rows of its valueFrom appended to the inline rows, and without the valueFrom
+func Resolve(seedData *schemasv1alpha4.SeedData, source Source) (*schemasv1alpha4.SeedData, error) {
+	if seedData == nil || seedData.ValueFrom == nil {
+		return seedData, nil
+	}
+
+	if source == nil {
+		return nil, errors.New("seed data valueFrom cannot be read here")
+	}
+
+	b, err := source(seedData.ValueFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := Rows(b, seedData.ValueFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &schemasv1alpha4.SeedData{Mode: seedData.Mode}
+	resolved.Rows = append(resolved.Rows, seedData.Rows...)
+	resolved.Rows = append(resolved.Rows, rows...)
+	return resolved, nil
+}
+
+// Rows parses the data of a valueFrom into rows of seed data
+func Rows(b []byte, valueFrom *schemasv1alpha4.SeedDataValueFrom) ([]schemasv1alpha4.SeedDataRow, error) {
+	format, err := dataFormat(valueFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []record
+	switch format {
+	case "csv":
+		records, err = csvRecords(b)
+	case "jsonl":
+		records, err = jsonlRecords(b)
+	case "yaml":
+		records, err = yamlRecords(b)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s seed data", format)
+	}
+
+	rows := []schemasv1alpha4.SeedDataRow{}
+	for i, r := range records {
+		row, err := r.row(valueFrom.Columns, format == "csv")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read row %d of seed data", i+1)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func dataFormat(valueFrom *schemasv1alpha4.SeedDataValueFrom) (string, error) {
+	if valueFrom.Format != "" {
+		return valueFrom.Format, nil
+	}
+
+	name := valueFrom.File
+	if valueFrom.ConfigMapKeyRef != nil {
+		name = valueFrom.ConfigMapKeyRef.Key
+	} else if valueFrom.SecretKeyRef != nil {
+		name = valueFrom.SecretKeyRef.Key
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return "csv", nil
+	case ".jsonl", ".ndjson":
+		return "jsonl", nil
+	case ".yaml", ".yml", ".json":
+		return "yaml", nil
+	}
+
+	return "", errors.Errorf("seed data format is not set and cannot be found from %q", name)
+}
+
+// record is a row of the data, with its fields in the order they are written to columns when there is no mapping
+type record struct {
+	fields []string
+	values map[string]interface{}
+}
+
+func csvRecords(b []byte) ([]record, error) {
+	reader := csv.NewReader(bytes.NewReader(b))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := []record{}
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		r := record{fields: header, values: map[string]interface{}{}}
+		for i, field := range header {
+			r.values[field] = line[i]
+		}
+		records = append(records, r)
+	}
+}
+
+func jsonlRecords(b []byte) ([]record, error) {
+	records := []record{}
+	for i, line := range strings.Split(string(b), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		values := map[string]interface{}{}
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&values); err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+		records = append(records, newRecord(values))
+	}
+
+	return records, nil
+}
+
+func yamlRecords(b []byte) ([]record, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(j))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, errors.Wrap(err, "expected a list of rows")
+	}
+
+	records := []record{}
+	for _, values := range rows {
+		records = append(records, newRecord(values))
+	}
+	return records, nil
+}
+
+func newRecord(values map[string]interface{}) record {
+	fields := []string{}
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return record{fields: fields, values: values}
+}
+
+func (r record) row(columns []schemasv1alpha4.SeedDataColumnMapping, isCSV bool) (schemasv1alpha4.SeedDataRow, error) {
+	if len(columns) == 0 {
+		for _, field := range r.fields {
+			columns = append(columns, schemasv1alpha4.SeedDataColumnMapping{Column: field})
+		}
+	}
+
+	row := schemasv1alpha4.SeedDataRow{}
+	for _, column := range columns {
+		field := column.Field
+		if field == "" {
+			field = column.Column
+		}
+
+		value, ok := r.values[field]
+		if !ok {
+			return schemasv1alpha4.SeedDataRow{}, errors.Errorf("field %q is missing", field)
+		}
+
+		seedDataValue, err := convert(value, column.Type, isCSV)
+		if err != nil {
+			return schemasv1alpha4.SeedDataRow{}, errors.Wrapf(err, "field %q", field)
+		}
+
+		row.Columns = append(row.Columns, schemasv1alpha4.Column{
+			Column: column.Column,
+			Value:  seedDataValue,
+		})
+	}
+
+	return row, nil
+}
+
+// convert returns the seed data value of a field. csv values are always strings, and an empty one is null unless
+// it is a str
+func convert(value interface{}, valueType string, isCSV bool) (schemasv1alpha4.SeedDataValue, error) {
+	if value == nil {
+		return schemasv1alpha4.SeedDataValue{Null: true}, nil
+	}
+
+	if isCSV && value == "" && valueType != "" && valueType != "str" {
+		return schemasv1alpha4.SeedDataValue{Null: true}, nil
+	}
+
+	if valueType == "" {
+		valueType = inferType(value)
+	}
+
+	s, err := stringValue(value)
+	if err != nil {
+		return schemasv1alpha4.SeedDataValue{}, err
+	}
+
+	switch valueType {
+	case "int":
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return schemasv1alpha4.SeedDataValue{}, errors.Errorf("%q is not an int", s)
+		}
+		return schemasv1alpha4.SeedDataValue{Int: &i}, nil
+	case "str":
+		return schemasv1alpha4.SeedDataValue{Str: &s}, nil
+	case "bool":
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return schemasv1alpha4.SeedDataValue{}, errors.Errorf("%q is not a bool", s)
+		}
+		return schemasv1alpha4.SeedDataValue{Bool: &b}, nil
+	case "float":
+		f := schemasv1alpha4.SeedDataFloat(strings.TrimSpace(s))
+		if !f.IsValid() {
+			return schemasv1alpha4.SeedDataValue{}, errors.Errorf("%q is not a float", s)
+		}
+		return schemasv1alpha4.SeedDataValue{Float: &f}, nil
+	case "json":
+		return schemasv1alpha4.SeedDataValue{JSON: &s}, nil
+	case "bytes":
+		return schemasv1alpha4.SeedDataValue{Bytes: &s}, nil
+	}
+
+	return schemasv1alpha4.SeedDataValue{}, errors.Errorf("unknown type %q", valueType)
+}
+
+func inferType(value interface{}) string {
+	switch v := value.(type) {
+	case json.Number:
+		if _, err := strconv.Atoi(v.String()); err == nil {
+			return "int"
+		}
+		return "float"
+	case bool:
+		return "bool"
+	case map[string]interface{}, []interface{}:
+		return "json"
+	}
+
+	return "str"
+}
+
+func stringValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal value")
+	}
+	return string(b), nil
+}
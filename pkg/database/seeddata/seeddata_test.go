@@ -0,0 +1,210 @@
+package seeddata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intValue(i int) schemasv1alpha4.SeedDataValue {
+	return schemasv1alpha4.SeedDataValue{Int: &i}
+}
+
+func strValue(s string) schemasv1alpha4.SeedDataValue {
+	return schemasv1alpha4.SeedDataValue{Str: &s}
+}
+
+func boolValue(b bool) schemasv1alpha4.SeedDataValue {
+	return schemasv1alpha4.SeedDataValue{Bool: &b}
+}
+
+func floatValue(f string) schemasv1alpha4.SeedDataValue {
+	float := schemasv1alpha4.SeedDataFloat(f)
+	return schemasv1alpha4.SeedDataValue{Float: &float}
+}
+
+func jsonValue(s string) schemasv1alpha4.SeedDataValue {
+	return schemasv1alpha4.SeedDataValue{JSON: &s}
+}
+
+func Test_Rows(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		valueFrom       schemasv1alpha4.SeedDataValueFrom
+		expect          []schemasv1alpha4.SeedDataRow
+		expectErrSubstr string
+	}{
+		{
+			name: "csv without columns",
+			data: "code,name\nCA,Canada\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				ConfigMapKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "countries", Key: "countries.csv"},
+			},
+			expect: []schemasv1alpha4.SeedDataRow{
+				{
+					Columns: []schemasv1alpha4.Column{
+						{Column: "code", Value: strValue("CA")},
+						{Column: "name", Value: strValue("Canada")},
+					},
+				},
+			},
+		},
+		{
+			name: "csv with columns and types",
+			data: "id,enabled,rollout,note\n1,true,0.50,\n2,false,,off\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				File: "flags.csv",
+				Columns: []schemasv1alpha4.SeedDataColumnMapping{
+					{Column: "flag_id", Field: "id", Type: "int"},
+					{Column: "enabled", Type: "bool"},
+					{Column: "rollout", Type: "float"},
+					{Column: "note"},
+				},
+			},
+			expect: []schemasv1alpha4.SeedDataRow{
+				{
+					Columns: []schemasv1alpha4.Column{
+						{Column: "flag_id", Value: intValue(1)},
+						{Column: "enabled", Value: boolValue(true)},
+						{Column: "rollout", Value: floatValue("0.50")},
+						{Column: "note", Value: strValue("")},
+					},
+				},
+				{
+					Columns: []schemasv1alpha4.Column{
+						{Column: "flag_id", Value: intValue(2)},
+						{Column: "enabled", Value: boolValue(false)},
+						{Column: "rollout", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+						{Column: "note", Value: strValue("off")},
+					},
+				},
+			},
+		},
+		{
+			name: "json lines",
+			data: `{"id": 1, "ratio": 0.5, "active": true, "meta": {"a": 1}, "deleted_at": null}` + "\n\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				Format: "jsonl",
+				File:   "rows",
+			},
+			expect: []schemasv1alpha4.SeedDataRow{
+				{
+					Columns: []schemasv1alpha4.Column{
+						{Column: "active", Value: boolValue(true)},
+						{Column: "deleted_at", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+						{Column: "id", Value: intValue(1)},
+						{Column: "meta", Value: jsonValue(`{"a":1}`)},
+						{Column: "ratio", Value: floatValue("0.5")},
+					},
+				},
+			},
+		},
+		{
+			name: "yaml with a type hint",
+			data: "- id: 1\n  code: 007\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				SecretKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "codes", Key: "codes.yaml"},
+				Columns: []schemasv1alpha4.SeedDataColumnMapping{
+					{Column: "id", Type: "str"},
+				},
+			},
+			expect: []schemasv1alpha4.SeedDataRow{
+				{
+					Columns: []schemasv1alpha4.Column{
+						{Column: "id", Value: strValue("1")},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown format",
+			data: "id\n1\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				File: "rows.txt",
+			},
+			expectErrSubstr: `seed data format is not set and cannot be found from "rows.txt"`,
+		},
+		{
+			name: "missing field",
+			data: "id\n1\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				Format: "csv",
+				Columns: []schemasv1alpha4.SeedDataColumnMapping{
+					{Column: "name"},
+				},
+			},
+			expectErrSubstr: `failed to read row 1 of seed data: field "name" is missing`,
+		},
+		{
+			name: "invalid int",
+			data: "id\none\n",
+			valueFrom: schemasv1alpha4.SeedDataValueFrom{
+				Format: "csv",
+				Columns: []schemasv1alpha4.SeedDataColumnMapping{
+					{Column: "id", Type: "int"},
+				},
+			},
+			expectErrSubstr: `field "id": "one" is not an int`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := Rows([]byte(test.data), &test.valueFrom)
+			if test.expectErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expect, rows)
+		})
+	}
+}
+
+func Test_ResolveFileSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "countries.csv"), []byte("code\nCA\n"), 0o600))
+
+	seedData := &schemasv1alpha4.SeedData{
+		Mode: schemasv1alpha4.SeedDataModeExact,
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "code", Value: strValue("US")},
+				},
+			},
+		},
+		ValueFrom: &schemasv1alpha4.SeedDataValueFrom{
+			File: "countries.csv",
+		},
+	}
+
+	resolved, err := Resolve(seedData, FileSource(dir))
+	require.NoError(t, err)
+	assert.Nil(t, resolved.ValueFrom)
+	assert.Equal(t, schemasv1alpha4.SeedDataModeExact, resolved.Mode)
+	assert.Equal(t, []schemasv1alpha4.SeedDataRow{
+		seedData.Rows[0],
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "code", Value: strValue("CA")},
+			},
+		},
+	}, resolved.Rows)
+
+	_, err = Resolve(seedData, nil)
+	assert.EqualError(t, err, "seed data valueFrom cannot be read here")
+
+	seedData.ValueFrom = &schemasv1alpha4.SeedDataValueFrom{
+		ConfigMapKeyRef: &schemasv1alpha4.SeedDataKeyRef{Name: "countries", Key: "countries.csv"},
+	}
+	_, err = Resolve(seedData, FileSource(dir))
+	assert.EqualError(t, err, "seed data valueFrom must be a file when planning from a spec file")
+}
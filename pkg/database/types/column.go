@@ -29,6 +29,7 @@ type Column struct {
 	Charset       string
 	Collation     string
 	IsStatic      bool
+	Comment       *string
 }
 
 func ColumnToMysqlSchemaColumn(column *Column) (*schemasv1alpha4.MysqlTableColumn, error) {
@@ -50,6 +51,7 @@ func ColumnToMysqlSchemaColumn(column *Column) (*schemasv1alpha4.MysqlTableColum
 	}
 
 	schemaColumn.Default = column.ColumnDefault
+	schemaColumn.Comment = column.Comment
 
 	schemaColumn.Charset = column.Charset
 	schemaColumn.Collation = column.Collation
@@ -76,6 +78,7 @@ func ColumnToPostgresqlSchemaColumn(column *Column) (*schemasv1alpha4.Postgresql
 	}
 
 	schemaColumn.Default = column.ColumnDefault
+	schemaColumn.Comment = column.Comment
 
 	return schemaColumn, nil
 }
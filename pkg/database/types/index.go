@@ -2,16 +2,92 @@ package types
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
+var charsetIntroducerRegex = regexp.MustCompile(`_[a-z0-9]+'`)
+
 type Index struct {
 	Columns  []string
 	Name     string
 	IsUnique bool
 	With     map[string]string
+
+	// Type is FULLTEXT or SPATIAL, other index methods are not compared
+	Type   string
+	Parser string
+	// Parts are set when the index has key parts that are more than a column name
+	Parts []*IndexPart
+}
+
+// IndexPart is a key part of an index, either a column or an expression
+type IndexPart struct {
+	Column     string
+	Length     int
+	Expression string
+	Descending bool
+}
+
+// keyParts returns the parts of the index, or a part for each column when the index has no parts
+func (idx *Index) keyParts() []*IndexPart {
+	if len(idx.Parts) > 0 {
+		return idx.Parts
+	}
+
+	parts := []*IndexPart{}
+	for _, column := range idx.Columns {
+		parts = append(parts, &IndexPart{Column: column})
+	}
+	return parts
+}
+
+func indexKind(indexType string) string {
+	kind := strings.ToUpper(indexType)
+	if kind == "FULLTEXT" || kind == "SPATIAL" {
+		return kind
+	}
+	return ""
+}
+
+// NormalizeIndexExpression removes the quoting, character set introducers, whitespace and enclosing
+// parentheses that the server adds when it stores an index expression
+func NormalizeIndexExpression(expression string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(expression, "`", ""))
+	normalized = charsetIntroducerRegex.ReplaceAllString(normalized, "'")
+	normalized = strings.Join(strings.Fields(normalized), "")
+
+	for strings.HasPrefix(normalized, "(") && strings.HasSuffix(normalized, ")") && enclosedInParentheses(normalized) {
+		normalized = normalized[1 : len(normalized)-1]
+	}
+
+	return normalized
+}
+
+// enclosedInParentheses returns true when the opening parenthesis of the expression closes at its end
+func enclosedInParentheses(expression string) bool {
+	depth := 0
+	for i, c := range expression {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(expression)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func (part *IndexPart) Equals(other *IndexPart) bool {
+	return part.Column == other.Column &&
+		part.Length == other.Length &&
+		part.Descending == other.Descending &&
+		NormalizeIndexExpression(part.Expression) == NormalizeIndexExpression(other.Expression)
 }
 
 func (idx *Index) Equals(other *Index) bool {
@@ -23,20 +99,30 @@ func (idx *Index) Equals(other *Index) bool {
 		return false
 	}
 
-	if len(idx.Columns) != len(other.Columns) {
+	if indexKind(idx.Type) != indexKind(other.Type) {
+		return false
+	}
+
+	// the parser is not available from information_schema, so it's only compared when both are known
+	if idx.Parser != "" && other.Parser != "" && !strings.EqualFold(idx.Parser, other.Parser) {
+		return false
+	}
+
+	parts, otherParts := idx.keyParts(), other.keyParts()
+	if len(parts) != len(otherParts) {
 		return false
 	}
 
-	for _, otherColumn := range other.Columns {
-		for _, col := range idx.Columns {
-			if col == otherColumn {
-				goto NextColumn
+	for _, otherPart := range otherParts {
+		for _, part := range parts {
+			if part.Equals(otherPart) {
+				goto NextPart
 			}
 		}
 
 		return false
 
-	NextColumn:
+	NextPart:
 	}
 
 	return true
@@ -47,6 +133,24 @@ func IndexToMysqlSchemaIndex(index *Index) *schemasv1alpha4.MysqlTableIndex {
 		Columns:  index.Columns,
 		Name:     index.Name,
 		IsUnique: index.IsUnique,
+		Type:     indexKind(index.Type),
+		Parser:   index.Parser,
+	}
+
+	// parts are only needed when a key part is more than a column name
+	for _, part := range index.Parts {
+		if part.Length > 0 || part.Descending || part.Expression != "" {
+			schemaIndex.Columns = nil
+			for _, part := range index.Parts {
+				schemaIndex.Parts = append(schemaIndex.Parts, &schemasv1alpha4.MysqlTableIndexPart{
+					Column:     part.Column,
+					Length:     part.Length,
+					Expression: part.Expression,
+					Descending: part.Descending,
+				})
+			}
+			break
+		}
 	}
 
 	return &schemaIndex
@@ -87,6 +191,17 @@ func MysqlSchemaIndexToIndex(schemaIndex *schemasv1alpha4.MysqlTableIndex) *Inde
 		Columns:  schemaIndex.Columns,
 		Name:     schemaIndex.Name,
 		IsUnique: schemaIndex.IsUnique,
+		Type:     schemaIndex.Type,
+		Parser:   schemaIndex.Parser,
+	}
+
+	for _, part := range schemaIndex.Parts {
+		index.Parts = append(index.Parts, &IndexPart{
+			Column:     part.Column,
+			Length:     part.Length,
+			Expression: part.Expression,
+			Descending: part.Descending,
+		})
 	}
 
 	return &index
@@ -123,7 +238,18 @@ func RqliteSchemaIndexToIndex(schemaIndex *schemasv1alpha4.RqliteTableIndex) *In
 }
 
 func GenerateMysqlIndexName(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
-	indexName := fmt.Sprintf("idx_%s_%s", tableName, strings.Join(schemaIndex.Columns, "_"))
+	columns := schemaIndex.Columns
+	if len(columns) == 0 {
+		for _, part := range schemaIndex.Parts {
+			if part.Column != "" {
+				columns = append(columns, part.Column)
+			} else {
+				columns = append(columns, "expr")
+			}
+		}
+	}
+
+	indexName := fmt.Sprintf("idx_%s_%s", tableName, strings.Join(columns, "_"))
 	if len(indexName) > 64 {
 		indexName = indexName[:64]
 	}
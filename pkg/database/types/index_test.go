@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_GenerateMysqlIndexName(t *testing.T) {
@@ -40,6 +41,17 @@ func Test_GenerateMysqlIndexName(t *testing.T) {
 			},
 			want: "idx_very_very_very_long_table_name_collumn_1_collumn_2_collumn_3",
 		},
+		{
+			name:      "parts",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+					{Column: "name", Length: 20},
+					{Expression: "lower(email)"},
+				},
+			},
+			want: "idx_users_name_expr",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,3 +61,95 @@ func Test_GenerateMysqlIndexName(t *testing.T) {
 		})
 	}
 }
+
+func TestIndex_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		index *Index
+		other *Index
+		want  bool
+	}{
+		{
+			name:  "same columns in a different order",
+			index: &Index{Name: "idx", Columns: []string{"a", "b"}},
+			other: &Index{Name: "idx", Columns: []string{"b", "a"}},
+			want:  true,
+		},
+		{
+			name:  "columns match plain parts",
+			index: &Index{Name: "idx", Columns: []string{"a", "b"}},
+			other: &Index{Name: "idx", Columns: []string{"a", "b"}, Parts: []*IndexPart{{Column: "a"}, {Column: "b"}}},
+			want:  true,
+		},
+		{
+			name:  "prefix length differs",
+			index: &Index{Name: "idx", Parts: []*IndexPart{{Column: "a", Length: 10}}},
+			other: &Index{Name: "idx", Columns: []string{"a"}, Parts: []*IndexPart{{Column: "a", Length: 20}}},
+			want:  false,
+		},
+		{
+			name:  "descending differs",
+			index: &Index{Name: "idx", Parts: []*IndexPart{{Column: "a", Descending: true}}},
+			other: &Index{Name: "idx", Columns: []string{"a"}},
+			want:  false,
+		},
+		{
+			name:  "expression as stored by the server",
+			index: &Index{Name: "idx", Parts: []*IndexPart{{Expression: "LOWER(email)"}}},
+			other: &Index{Name: "idx", Parts: []*IndexPart{{Expression: "(lower(`email`))"}}},
+			want:  true,
+		},
+		{
+			name:  "fulltext and a regular index",
+			index: &Index{Name: "idx", Type: "FULLTEXT", Columns: []string{"body"}},
+			other: &Index{Name: "idx", Columns: []string{"body"}},
+			want:  false,
+		},
+		{
+			name:  "btree is a regular index",
+			index: &Index{Name: "idx", Type: "BTREE", Columns: []string{"a"}},
+			other: &Index{Name: "idx", Columns: []string{"a"}},
+			want:  true,
+		},
+		{
+			name:  "unknown parser",
+			index: &Index{Name: "idx", Type: "fulltext", Parser: "ngram", Columns: []string{"body"}},
+			other: &Index{Name: "idx", Type: "FULLTEXT", Columns: []string{"body"}},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.index.Equals(tt.other))
+		})
+	}
+}
+
+func Test_NormalizeIndexExpression(t *testing.T) {
+	assert.Equal(t, "lower(email)", NormalizeIndexExpression("(lower(`email`))"))
+	assert.Equal(t, "(a+b)*2", NormalizeIndexExpression("(`a` + `b`) * 2"))
+	assert.Equal(t, "cast(json_extract(doc,'$.id')aschar(10))", NormalizeIndexExpression("cast(json_extract(`doc`,_utf8mb4'$.id') as char(10))"))
+}
+
+func Test_IndexToMysqlSchemaIndex(t *testing.T) {
+	plain := IndexToMysqlSchemaIndex(&Index{
+		Name:    "idx",
+		Columns: []string{"a"},
+		Parts:   []*IndexPart{{Column: "a"}},
+	})
+	assert.Equal(t, []string{"a"}, plain.Columns)
+	assert.Nil(t, plain.Parts)
+
+	prefixed := IndexToMysqlSchemaIndex(&Index{
+		Name:    "idx",
+		Columns: []string{"a"},
+		Parts:   []*IndexPart{{Column: "a", Length: 20}, {Expression: "lower(`b`)"}},
+	})
+	assert.Nil(t, prefixed.Columns)
+	assert.Equal(t, []*schemasv1alpha4.MysqlTableIndexPart{{Column: "a", Length: 20}, {Expression: "lower(`b`)"}}, prefixed.Parts)
+	assert.True(t, MysqlSchemaIndexToIndex(prefixed).Equals(&Index{
+		Name:    "idx",
+		Columns: []string{"a"},
+		Parts:   []*IndexPart{{Column: "a", Length: 20}, {Expression: "lower(`b`)"}},
+	}))
+}
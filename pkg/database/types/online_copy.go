@@ -0,0 +1,49 @@
+package types
+
+import (
+	"strings"
+)
+
+// OnlineCopyMarker starts a statement that copies the rows of a table into its shadow table.
+// Plugins that support online schema changes run the statement in throttled chunks, anything
+// else that executes it runs it as a single insert.
+const OnlineCopyMarker = "/* schemahero:online-copy"
+
+// RebuildMarker starts a statement that drops a table after its rows were copied into the table that replaces it.
+// The statement does not lose data, so it does not make the migration destructive
+const RebuildMarker = "/* schemahero:rebuild */"
+
+// OnlineCopyProgress is the progress of copying rows into a shadow table
+type OnlineCopyProgress struct {
+	RowsCopied int64
+	// TotalRows is estimated from the table statistics when the copy starts
+	TotalRows int64
+	Completed bool
+}
+
+// RequiresOnlineCopy returns true when the statements rebuild a table through a shadow table copy
+func RequiresOnlineCopy(statements []string) bool {
+	return len(OnlineCopyTables(statements)) > 0
+}
+
+// OnlineCopyTables returns the names of the tables that the statements copy into shadow tables
+func OnlineCopyTables(statements []string) []string {
+	tables := []string{}
+	for _, statement := range statements {
+		statement = strings.TrimSpace(statement)
+		if !strings.HasPrefix(statement, OnlineCopyMarker) {
+			continue
+		}
+
+		end := strings.Index(statement, "*/")
+		if end < 0 {
+			continue
+		}
+		for _, param := range strings.Fields(statement[len(OnlineCopyMarker):end]) {
+			if strings.HasPrefix(param, "table=") {
+				tables = append(tables, strings.TrimPrefix(param, "table="))
+			}
+		}
+	}
+	return tables
+}
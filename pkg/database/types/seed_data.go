@@ -0,0 +1,167 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// SeedDataLiterals write the values of seed data as the literals of a database
+type SeedDataLiterals struct {
+	String func(s string) string
+	Bool   func(b bool) string
+	Bytes  func(b []byte) string
+	// JSON writes a json document, which is valid
+	JSON func(s string) string
+}
+
+// SeedDataLiteral returns the literal of the value of the column
+func SeedDataLiteral(column string, value schemasv1alpha4.SeedDataValue, literals SeedDataLiterals) (string, error) {
+	set := 0
+	for _, isSet := range []bool{value.Int != nil, value.Str != nil, value.Bool != nil, value.Float != nil, value.Null, value.JSON != nil, value.Bytes != nil, value.Expr != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", errors.Errorf("seed data for column %s has more than one value", column)
+	}
+
+	switch {
+	case value.Int != nil:
+		return strconv.Itoa(*value.Int), nil
+	case value.Str != nil:
+		return literals.String(*value.Str), nil
+	case value.Bool != nil:
+		return literals.Bool(*value.Bool), nil
+	case value.Float != nil:
+		if !value.Float.IsValid() {
+			return "", errors.Errorf("seed data for column %s has an invalid float %q", column, *value.Float)
+		}
+		return string(*value.Float), nil
+	case value.Null:
+		return "null", nil
+	case value.JSON != nil:
+		if !json.Valid([]byte(*value.JSON)) {
+			return "", errors.Errorf("seed data for column %s has invalid json", column)
+		}
+		return literals.JSON(*value.JSON), nil
+	case value.Bytes != nil:
+		b, err := base64.StdEncoding.DecodeString(*value.Bytes)
+		if err != nil {
+			return "", errors.Wrapf(err, "seed data for column %s has invalid base64 bytes", column)
+		}
+		return literals.Bytes(b), nil
+	case value.Expr != nil:
+		return *value.Expr, nil
+	}
+
+	// an empty str used to reach the plugins without a value
+	return literals.String(""), nil
+}
+
+// SeedDataRowLiterals is a row of seed data with the literal of each column
+type SeedDataRowLiterals struct {
+	Columns []string
+	Values  []string
+}
+
+// SeedDataRowsLiterals returns the literals of the rows of the seed data
+func SeedDataRowsLiterals(seedData *schemasv1alpha4.SeedData, literals SeedDataLiterals) ([]SeedDataRowLiterals, error) {
+	rows := []SeedDataRowLiterals{}
+	for _, row := range seedData.Rows {
+		rowLiterals := SeedDataRowLiterals{}
+		for _, col := range row.Columns {
+			val, err := SeedDataLiteral(col.Column, col.Value, literals)
+			if err != nil {
+				return nil, err
+			}
+			rowLiterals.Columns = append(rowLiterals.Columns, col.Column)
+			rowLiterals.Values = append(rowLiterals.Values, val)
+		}
+		rows = append(rows, rowLiterals)
+	}
+
+	return rows, nil
+}
+
+// Condition compares each of the columns to its value in the row with the operator, joined with and
+func (r SeedDataRowLiterals) Condition(columns []string, operator string) (string, error) {
+	conditions := []string{}
+	for _, column := range columns {
+		value, ok := r.value(column)
+		if !ok {
+			return "", errors.Errorf("seed data row does not set primary key column %s", column)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", column, operator, value))
+	}
+
+	return strings.Join(conditions, " and "), nil
+}
+
+// Assignments returns column = value for the columns of the row that are not in the primary key
+func (r SeedDataRowLiterals) Assignments(primaryKey []string) []string {
+	assignments := []string{}
+	for i, column := range r.Columns {
+		if !containsString(primaryKey, column) {
+			assignments = append(assignments, fmt.Sprintf("%s = %s", column, r.Values[i]))
+		}
+	}
+
+	return assignments
+}
+
+func (r SeedDataRowLiterals) value(column string) (string, bool) {
+	for i, c := range r.Columns {
+		if c == column {
+			return r.Values[i], true
+		}
+	}
+
+	return "", false
+}
+
+// SeedDataKeysCondition returns a condition that matches the primary keys of the rows, or an empty string when
+// there are no rows
+func SeedDataKeysCondition(rows []SeedDataRowLiterals, primaryKey []string) (string, error) {
+	conditions := []string{}
+	for _, row := range rows {
+		condition, err := row.Condition(primaryKey, "=")
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s)", condition))
+	}
+
+	return strings.Join(conditions, " or "), nil
+}
+
+// SeedDataReadsTable returns true when the mode of the seed data is planned from the rows in the table
+func SeedDataReadsTable(seedData *schemasv1alpha4.SeedData) bool {
+	return seedData != nil && seedData.Mode != ""
+}
+
+// SeedDataRequiresPrimaryKey returns an error when the mode of the seed data matches rows by a primary key that the
+// table does not have
+func SeedDataRequiresPrimaryKey(tableName string, seedData *schemasv1alpha4.SeedData, primaryKey []string) error {
+	if SeedDataReadsTable(seedData) && len(primaryKey) == 0 {
+		return errors.Errorf("seed data mode %s requires table %s to have a primary key", seedData.Mode, tableName)
+	}
+
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SeedDataLiteral(t *testing.T) {
+	literals := SeedDataLiterals{
+		String: func(s string) string { return fmt.Sprintf("'%s'", s) },
+		Bool:   func(b bool) string { return fmt.Sprintf("%t", b) },
+		Bytes:  func(b []byte) string { return fmt.Sprintf("x'%x'", b) },
+		JSON:   func(s string) string { return fmt.Sprintf("json('%s')", s) },
+	}
+
+	zero, str, yes, expr := 0, "a", true, "now()"
+	float, invalidFloat := schemasv1alpha4.SeedDataFloat("-1.5e3"), schemasv1alpha4.SeedDataFloat("0x10")
+	doc, invalidDoc := `{"a": [1, 2]}`, `{"a": `
+	bytes, invalidBytes := "Af8=", "not base64"
+
+	tests := []struct {
+		name      string
+		value     schemasv1alpha4.SeedDataValue
+		expect    string
+		expectErr string
+	}{
+		{name: "int", value: schemasv1alpha4.SeedDataValue{Int: &zero}, expect: "0"},
+		{name: "str", value: schemasv1alpha4.SeedDataValue{Str: &str}, expect: "'a'"},
+		{name: "bool", value: schemasv1alpha4.SeedDataValue{Bool: &yes}, expect: "true"},
+		{name: "float", value: schemasv1alpha4.SeedDataValue{Float: &float}, expect: "-1.5e3"},
+		{name: "null", value: schemasv1alpha4.SeedDataValue{Null: true}, expect: "null"},
+		{name: "json", value: schemasv1alpha4.SeedDataValue{JSON: &doc}, expect: `json('{"a": [1, 2]}')`},
+		{name: "bytes", value: schemasv1alpha4.SeedDataValue{Bytes: &bytes}, expect: "x'01ff'"},
+		{name: "expr", value: schemasv1alpha4.SeedDataValue{Expr: &expr}, expect: "now()"},
+		{name: "none", value: schemasv1alpha4.SeedDataValue{}, expect: "''"},
+		{name: "more than one", value: schemasv1alpha4.SeedDataValue{Str: &str, Null: true}, expectErr: "seed data for column c has more than one value"},
+		{name: "invalid float", value: schemasv1alpha4.SeedDataValue{Float: &invalidFloat}, expectErr: `seed data for column c has an invalid float "0x10"`},
+		{name: "invalid json", value: schemasv1alpha4.SeedDataValue{JSON: &invalidDoc}, expectErr: "seed data for column c has invalid json"},
+		{name: "invalid bytes", value: schemasv1alpha4.SeedDataValue{Bytes: &invalidBytes}, expectErr: "seed data for column c has invalid base64 bytes: illegal base64 data at input byte 3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			literal, err := SeedDataLiteral("c", test.value, literals)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, literal)
+		})
+	}
+}
+
+func Test_SeedDataRowsLiterals(t *testing.T) {
+	literals := SeedDataLiterals{
+		String: func(s string) string { return fmt.Sprintf("'%s'", s) },
+	}
+
+	tenant, one, two, name := "acme", 1, 2, "a"
+	seedData := &schemasv1alpha4.SeedData{
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "tenant", Value: schemasv1alpha4.SeedDataValue{Str: &tenant}},
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &one}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+				},
+			},
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "tenant", Value: schemasv1alpha4.SeedDataValue{Str: &tenant}},
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &two}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+				},
+			},
+		},
+	}
+	primaryKey := []string{"tenant", "id"}
+
+	rows, err := SeedDataRowsLiterals(seedData, literals)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	where, err := rows[0].Condition(primaryKey, "=")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant = 'acme' and id = 1", where)
+
+	same, err := rows[1].Condition(rows[1].Columns, "is")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant is 'acme' and id is 2 and name is null", same)
+
+	assert.Equal(t, []string{"name = 'a'"}, rows[0].Assignments(primaryKey))
+
+	keys, err := SeedDataKeysCondition(rows, primaryKey)
+	require.NoError(t, err)
+	assert.Equal(t, "(tenant = 'acme' and id = 1) or (tenant = 'acme' and id = 2)", keys)
+
+	keys, err = SeedDataKeysCondition(nil, primaryKey)
+	require.NoError(t, err)
+	assert.Equal(t, "", keys)
+
+	_, err = rows[0].Condition([]string{"region"}, "=")
+	assert.EqualError(t, err, "seed data row does not set primary key column region")
+}
+
+func Test_SeedDataRequiresPrimaryKey(t *testing.T) {
+	assert.NoError(t, SeedDataRequiresPrimaryKey("users", &schemasv1alpha4.SeedData{}, nil))
+	assert.NoError(t, SeedDataRequiresPrimaryKey("users", &schemasv1alpha4.SeedData{Mode: schemasv1alpha4.SeedDataModeUpsert}, []string{"id"}))
+	assert.EqualError(t, SeedDataRequiresPrimaryKey("users", &schemasv1alpha4.SeedData{Mode: schemasv1alpha4.SeedDataModeExact}, nil),
+		"seed data mode exact requires table users to have a primary key")
+}
@@ -20,7 +20,22 @@ func (s Specs) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
-// Ensure tables are processed before views, secondary sort is by file name
+// specKindOrder is the order that kinds are processed in. Schemas and sequences are created
+// before the tables that live in or use them, and tables before views and other kinds.
+var specKindOrder = map[string]int{
+	"DatabaseSchema": 0,
+	"Sequence":       1,
+	"Table":          2,
+}
+
+func specKindRank(kind string) int {
+	if rank, ok := specKindOrder[kind]; ok {
+		return rank
+	}
+	return len(specKindOrder)
+}
+
+// Ensure schemas, sequences and tables are processed before views, secondary sort is by file name
 func (s Specs) Less(i, j int) bool {
 	decode := scheme.Codecs.UniversalDeserializer().Decode
 
@@ -35,14 +50,9 @@ func (s Specs) Less(i, j int) bool {
 	}
 
 	if gvkI.Group == "schemas.schemahero.io" && gvkJ.Group == "schemas.schemahero.io" {
-		if gvkI.Kind == gvkJ.Kind {
-			return s[i].SourceFilename < s[j].SourceFilename
-		}
-		if gvkI.Kind == "Table" {
-			return true
-		}
-		if gvkJ.Kind == "Table" {
-			return false
+		rankI, rankJ := specKindRank(gvkI.Kind), specKindRank(gvkJ.Kind)
+		if rankI != rankJ {
+			return rankI < rankJ
 		}
 	}
 
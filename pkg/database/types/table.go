@@ -5,4 +5,5 @@ type Table struct {
 	Schema    string
 	Charset   string
 	Collation string
+	Comment   string
 }
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// warningCommentRegexp matches the comments that a plugin adds to a statement to report a warning in the plan.
+// They are block comments so that the statement is unchanged when its lines are joined
+var warningCommentRegexp = regexp.MustCompile(`/\* schemahero:warning (.*?) \*/`)
+
+// WarningComment returns the comment that reports the warning in the plan when it is added to a statement
+func WarningComment(warning string) string {
+	return fmt.Sprintf("/* schemahero:warning %s */", strings.ReplaceAll(warning, "*/", "* /"))
+}
+
+// MigrationWarnings returns the warnings that the statements report and the actions that need to be taken after
+// the statements are executed
+func MigrationWarnings(statements []string) []string {
+	warnings := StatementWarnings(statements)
+	for _, keyspace := range ReplicationChangedKeyspaces(statements) {
+		warnings = append(warnings, fmt.Sprintf("the replication of keyspace %s changed, run a full repair (nodetool repair -full %s) on each node so that the new replicas have the data", keyspace, keyspace))
+	}
+	for _, view := range RecreatedMaterializedViews(statements) {
+		warnings = append(warnings, fmt.Sprintf("materialized view %s is dropped and created again, its data is unavailable until it is materialized again", view))
+	}
+	return warnings
+}
+
+// StatementWarnings returns the warnings in the comments of the statements
+func StatementWarnings(statements []string) []string {
+	warnings := []string{}
+	for _, statement := range statements {
+		for _, match := range warningCommentRegexp.FindAllStringSubmatch(statement, -1) {
+			warnings = append(warnings, match[1])
+		}
+	}
+	return warnings
+}
+
+// IsDestructive returns true when the statements drop a table or a materialized view, and the data stored in it.
+// The statements of a rebuild that copies the rows into a new table first are not destructive
+func IsDestructive(statements []string) bool {
+	for _, statement := range statements {
+		statement = strings.TrimSpace(statement)
+		if strings.HasPrefix(statement, OnlineCopyMarker) || strings.HasPrefix(statement, RebuildMarker) {
+			continue
+		}
+		fields := strings.Fields(strings.ToLower(statement))
+		if len(fields) < 3 || fields[0] != "drop" {
+			continue
+		}
+		if fields[1] == "table" || (fields[1] == "materialized" && fields[2] == "view") {
+			return true
+		}
+	}
+	return false
+}
+
+// RecreatedMaterializedViews returns the names of the materialized views that the statements drop and create again
+func RecreatedMaterializedViews(statements []string) []string {
+	dropped := map[string]bool{}
+	views := []string{}
+	for _, statement := range statements {
+		fields := strings.Fields(statement)
+		if len(fields) < 4 || !strings.EqualFold(fields[1], "materialized") || !strings.EqualFold(fields[2], "view") {
+			continue
+		}
+		name := fields[3]
+		if strings.EqualFold(name, "if") && len(fields) > 5 {
+			name = fields[5]
+		}
+		name = strings.Trim(name, `"`)
+
+		if strings.EqualFold(fields[0], "drop") {
+			dropped[name] = true
+		} else if strings.EqualFold(fields[0], "create") && dropped[name] {
+			views = append(views, name)
+		}
+	}
+	return views
+}
+
+// ReplicationChangedKeyspaces returns the names of the keyspaces that the statements change the replication of
+func ReplicationChangedKeyspaces(statements []string) []string {
+	keyspaces := []string{}
+	for _, statement := range statements {
+		fields := strings.Fields(statement)
+		if len(fields) < 4 || !strings.EqualFold(fields[0], "alter") || !strings.EqualFold(fields[1], "keyspace") {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(statement), "replication") {
+			continue
+		}
+		keyspaces = append(keyspaces, strings.Trim(fields[2], `"`))
+	}
+	return keyspaces
+}
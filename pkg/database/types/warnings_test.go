@@ -0,0 +1,78 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MigrationWarnings(t *testing.T) {
+	statements := []string{
+		`create table "events" ("id" uuid, primary key ("id"))`,
+		`alter keyspace "metrics" with durable_writes = false`,
+		`alter keyspace "events" with replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3} and durable_writes = true`,
+	}
+
+	assert.Equal(t, []string{"events"}, ReplicationChangedKeyspaces(statements))
+	assert.Equal(t, []string{
+		"the replication of keyspace events changed, run a full repair (nodetool repair -full events) on each node so that the new replicas have the data",
+	}, MigrationWarnings(statements))
+	assert.Empty(t, MigrationWarnings(statements[:2]))
+}
+
+func Test_IsDestructive(t *testing.T) {
+	statements := []string{
+		`select remove_continuous_aggregate_policy('daily', if_exists => true)`,
+		`drop materialized view "daily"`,
+		`create materialized view "daily" with (timescaledb.continuous) as select 1 with data`,
+	}
+
+	assert.True(t, IsDestructive(statements))
+	assert.False(t, IsDestructive(statements[:1]))
+	assert.True(t, IsDestructive([]string{`drop table "events"`}))
+	assert.False(t, IsDestructive([]string{`alter table "events" drop column "a"`}))
+
+	// the table that a rebuild copied the rows out of is dropped without losing data
+	assert.False(t, IsDestructive([]string{
+		`create table "events_1234" ("id" integer)`,
+		`insert into "events_1234" ("id") select "id" from "events"`,
+		RebuildMarker + ` drop table "events"`,
+		`alter table "events_1234" rename to "events"`,
+	}))
+	assert.False(t, IsDestructive([]string{
+		OnlineCopyMarker + " table=events shadow=_events_new key=id chunkSize=1000 throttleMilliseconds=0 */ insert ignore into `_events_new` (id) select id from `events`",
+		"rename table `events` to `_events_old`, `_events_new` to `events`",
+		RebuildMarker + " drop table `_events_old`",
+	}))
+	assert.True(t, IsDestructive([]string{
+		RebuildMarker + ` drop table "events_1234"`,
+		`drop table "events"`,
+	}))
+
+	assert.Equal(t, []string{"daily"}, RecreatedMaterializedViews(statements))
+	assert.Empty(t, RecreatedMaterializedViews(statements[:2]))
+	assert.Equal(t, []string{
+		"materialized view daily is dropped and created again, its data is unavailable until it is materialized again",
+	}, MigrationWarnings(statements))
+}
+
+func Test_StatementWarnings(t *testing.T) {
+	statements := []string{
+		`create table "users_new" ("id" integer)`,
+		WarningComment("the data in column email of table users is dropped") + "\n" +
+			WarningComment("column id of table users becomes not null, the rebuild fails if a row has a null value in it") + "\n" +
+			`insert into "users_new" ("id") select "id" from "users"`,
+	}
+
+	assert.Equal(t, []string{
+		"the data in column email of table users is dropped",
+		"column id of table users becomes not null, the rebuild fails if a row has a null value in it",
+	}, MigrationWarnings(statements))
+	assert.Empty(t, StatementWarnings(statements[:1]))
+
+	// the lines of a statement are joined when it is read from a ddl file
+	joined := strings.Join(strings.Split(statements[1], "\n"), " ")
+	assert.Len(t, StatementWarnings([]string{joined}), 2)
+	assert.True(t, strings.HasSuffix(joined, `*/ insert into "users_new" ("id") select "id" from "users"`))
+}
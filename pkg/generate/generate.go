@@ -215,6 +215,10 @@ func generateMysqlTableYAML(dbName string, table *types.Table, primaryKey []stri
 		Collation:      table.Collation,
 	}
 
+	if table.Comment != "" {
+		tableSchema.Comment = &table.Comment
+	}
+
 	schema := &schemasv1alpha4.TableSchema{}
 
 	schema.Mysql = tableSchema
@@ -278,12 +282,24 @@ func generatePostgresqlTableYAML(driver string, dbName string, table *types.Tabl
 		Indexes:     schemaIndexes,
 	}
 
+	if table.Comment != "" {
+		tableSchema.Comment = &table.Comment
+	}
+
 	schema := &schemasv1alpha4.TableSchema{}
 
 	if driver == "postgres" {
 		schema.Postgres = tableSchema
 	} else if driver == "cockroachdb" {
 		schema.CockroachDB = tableSchema
+	} else if driver == "timescaledb" {
+		schema.TimescaleDB = &schemasv1alpha4.TimescaleDBTableSchema{
+			PrimaryKey:  tableSchema.PrimaryKey,
+			Columns:     tableSchema.Columns,
+			ForeignKeys: tableSchema.ForeignKeys,
+			Indexes:     tableSchema.Indexes,
+			Comment:     tableSchema.Comment,
+		}
 	}
 
 	schemaHeroResource := schemasv1alpha4.TableSpec{
@@ -468,6 +484,12 @@ func generateCassandraTableYAML(dbName string, table *types.Table, primaryKey []
 		Columns:    schemaTableColumns,
 	}
 
+	if table.Comment != "" {
+		tableSchema.Properties = &schemasv1alpha4.CassandraTableProperties{
+			Comment: table.Comment,
+		}
+	}
+
 	schema := &schemasv1alpha4.TableSchema{}
 	schema.Cassandra = tableSchema
 
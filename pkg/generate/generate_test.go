@@ -10,6 +10,7 @@ import (
 
 var (
 	trueValue = true
+	idComment = "the id's comment"
 )
 
 func Test_sanitizeName(t *testing.T) {
@@ -209,6 +210,80 @@ spec:
         type: integer
         attributes:
           autoIncrement: true
+`,
+		},
+		{
+			name:   "postgres -- with comments",
+			driver: "postgres",
+			dbName: "db",
+			table: types.Table{
+				Name:    "simple",
+				Comment: "a simple table",
+			},
+			primaryKey: []string{"id"},
+			columns: []*types.Column{
+				{
+					Name:     "id",
+					DataType: "integer",
+					Comment:  &idComment,
+				},
+				{
+					Name:     "other",
+					DataType: "text",
+				},
+			},
+			expectedYAML: `apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: simple
+spec:
+  database: db
+  name: simple
+  schema:
+    postgres:
+      primaryKey:
+      - id
+      columns:
+      - name: id
+        type: integer
+        comment: the id's comment
+      - name: other
+        type: text
+      comment: a simple table
+`,
+		},
+		{
+			name:   "mysql -- with comments",
+			driver: "mysql",
+			dbName: "db",
+			table: types.Table{
+				Name:    "simple",
+				Comment: "a simple table",
+			},
+			primaryKey: []string{"id"},
+			columns: []*types.Column{
+				{
+					Name:     "id",
+					DataType: "integer",
+					Comment:  &idComment,
+				},
+			},
+			expectedYAML: `apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: simple
+spec:
+  database: db
+  name: simple
+  schema:
+    mysql:
+      primaryKey:
+      - id
+      columns:
+      - name: id
+        type: integer
+        comment: the id's comment
+      comment: a simple table
 `,
 		},
 		{
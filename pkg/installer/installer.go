@@ -47,6 +47,30 @@ func GenerateOperatorYAML(namespace string) (map[string][]byte, error) {
 	}
 	manifests["functions_crd.yaml"] = manifest
 
+	manifest, err = grantsCRDYAML()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get grants crd")
+	}
+	manifests["grants_crd.yaml"] = manifest
+
+	manifest, err = databaseSchemasCRDYAML()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database schemas crd")
+	}
+	manifests["database_schemas_crd.yaml"] = manifest
+
+	manifest, err = sequencesCRDYAML()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sequences crd")
+	}
+	manifests["sequences_crd.yaml"] = manifest
+
+	manifest, err = dataTypesCRDYAML()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get data types crd")
+	}
+	manifests["data_types_crd.yaml"] = manifest
+
 	manifest, err = clusterRoleYAML()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get cluster role")
@@ -124,6 +148,22 @@ func InstallOperator(namespace string) (bool, error) {
 		return false, errors.Wrap(err, "failed to create functions crd")
 	}
 
+	if err := ensureGrantsCRD(ctx, cfg); err != nil {
+		return false, errors.Wrap(err, "failed to create grants crd")
+	}
+
+	if err := ensureDatabaseSchemasCRD(ctx, cfg); err != nil {
+		return false, errors.Wrap(err, "failed to create database schemas crd")
+	}
+
+	if err := ensureSequencesCRD(ctx, cfg); err != nil {
+		return false, errors.Wrap(err, "failed to create sequences crd")
+	}
+
+	if err := ensureDataTypesCRD(ctx, cfg); err != nil {
+		return false, errors.Wrap(err, "failed to create data types crd")
+	}
+
 	if err := ensureClusterRole(ctx, client); err != nil {
 		return false, errors.Wrap(err, "failed to create cluster role")
 	}
@@ -74,6 +74,11 @@ func clusterRole() *rbacv1.ClusterRole {
 				Resources: []string{"secrets"},
 				Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     metav1.Verbs{"get", "list", "watch"},
+			},
 			{
 				APIGroups: []string{""},
 				Resources: []string{"serviceaccounts"},
@@ -139,6 +144,16 @@ func clusterRole() *rbacv1.ClusterRole {
 				Resources: []string{"functions/status"},
 				Verbs:     metav1.Verbs{"get", "update", "patch"},
 			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"grants"},
+				Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"grants/status"},
+				Verbs:     metav1.Verbs{"get", "update", "patch"},
+			},
 			{
 				APIGroups: []string{"schemas.schemahero.io"},
 				Resources: []string{"databaseextensions"},
@@ -159,6 +174,26 @@ func clusterRole() *rbacv1.ClusterRole {
 				Resources: []string{"datatypes/status"},
 				Verbs:     metav1.Verbs{"get", "update", "patch"},
 			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"databaseschemas"},
+				Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"databaseschemas/status"},
+				Verbs:     metav1.Verbs{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"sequences"},
+				Verbs:     metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"schemas.schemahero.io"},
+				Resources: []string{"sequences/status"},
+				Verbs:     metav1.Verbs{"get", "update", "patch"},
+			},
 		},
 	}
 
@@ -2,7 +2,6 @@ package cassandra
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
@@ -31,6 +30,11 @@ func Connect(hosts []string, username string, password string, keyspace string)
 	}
 
 	session, err := cluster.CreateSession()
+	if err != nil && keyspace != "" {
+		// the keyspace might not exist yet when it's managed as a schema,
+		// so connect without it to be able to plan and create it
+		session, err = connectWithoutKeyspace(cluster, keyspace, err)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create cassandra session")
 	}
@@ -43,6 +47,24 @@ func Connect(hosts []string, username string, password string, keyspace string)
 	return &cassandraConnection, nil
 }
 
+// connectWithoutKeyspace creates a session that is not scoped to the keyspace when the keyspace does not exist,
+// otherwise it returns the error from connecting to the keyspace
+func connectWithoutKeyspace(cluster *gocql.ClusterConfig, keyspace string, keyspaceErr error) (*gocql.Session, error) {
+	cluster.Keyspace = ""
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, keyspaceErr
+	}
+
+	existing, err := readKeyspace(session, keyspace)
+	if err != nil || existing != nil {
+		session.Close()
+		return nil, keyspaceErr
+	}
+
+	return session, nil
+}
+
 func (c *CassandraConnection) Close() error {
 	if c.session == nil {
 		return nil
@@ -82,12 +104,13 @@ func (c *CassandraConnection) EngineVersion() string {
 
 // ListTables returns all tables in the keyspace
 func (c *CassandraConnection) ListTables() ([]*types.Table, error) {
-	iter := c.session.Query("SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?", c.keyspace).Iter()
+	iter := c.session.Query("SELECT table_name, comment FROM system_schema.tables WHERE keyspace_name = ?", c.keyspace).Iter()
 	tables := []*types.Table{}
-	var tableName string
-	for iter.Scan(&tableName) {
+	var tableName, comment string
+	for iter.Scan(&tableName, &comment) {
 		tables = append(tables, &types.Table{
-			Name: tableName,
+			Name:    tableName,
+			Comment: comment,
 		})
 	}
 	if err := iter.Close(); err != nil {
@@ -201,51 +224,42 @@ func (c *CassandraConnection) PlanTableSchema(tableName string, tableSchema inte
 	return c.planCassandraTable(tableName, schema, seedData)
 }
 
-// PlanTypeSchema generates SQL statements for type (UDT) schema changes
+// PlanTypeSchema generates CQL statements for user defined type changes
 func (c *CassandraConnection) PlanTypeSchema(typeName string, typeSchema interface{}) ([]string, error) {
-	// Type assert to CassandraDataTypeSchema
 	schema, ok := typeSchema.(*schemasv1alpha4.CassandraDataTypeSchema)
 	if !ok {
 		return nil, fmt.Errorf("expected CassandraDataTypeSchema, got %T", typeSchema)
 	}
-	
-	// Check if the type exists
-	query := `select count(1) from system_schema.types where keyspace_name=? and type_name = ?`
-	row := c.session.Query(query, c.keyspace, typeName)
-	typeExists := 0
-	if err := row.Scan(&typeExists); err != nil {
-		return nil, errors.Wrap(err, "failed to check if type exists")
-	}
-	
-	if typeExists == 0 && schema.IsDeleted {
-		return []string{}, nil
-	} else if typeExists > 0 && schema.IsDeleted {
-		return []string{
-			fmt.Sprintf(`drop type "%s"`, typeName),
-		}, nil
+
+	existing, err := readType(c.session, c.keyspace, typeName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing type")
 	}
-	
-	if typeExists == 0 {
-		// Create the type
-		fields := []string{}
-		for _, field := range schema.Fields {
-			fields = append(fields, fmt.Sprintf("%s %s", field.Name, field.Type))
+
+	dependents := []string{}
+	if existing != nil && schema.IsDeleted {
+		dependents, err = readTypeDependents(c.session, c.keyspace, typeName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read type dependents")
 		}
-		
-		return []string{
-			fmt.Sprintf(`create type "%s" (%s)`, typeName, strings.Join(fields, ", ")),
-		}, nil
 	}
-	
-	// Cassandra doesn't support altering types
-	// You can only drop and recreate them
-	// For now, return empty if type exists and matches
-	return []string{}, nil
+
+	return TypeStatements(typeName, schema, existing, dependents)
 }
 
-// PlanViewSchema - Cassandra views are not yet implemented
+// PlanViewSchema generates CQL statements for materialized view changes
 func (c *CassandraConnection) PlanViewSchema(viewName string, viewSchema interface{}) ([]string, error) {
-	return nil, errors.New("cassandra view planning not yet implemented")
+	schema, ok := viewSchema.(*schemasv1alpha4.CassandraViewSchema)
+	if !ok {
+		return nil, fmt.Errorf("expected CassandraViewSchema, got %T", viewSchema)
+	}
+
+	existing, err := readView(c.session, c.keyspace, viewName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing materialized view")
+	}
+
+	return ViewStatements(viewName, schema, existing)
 }
 
 // PlanFunctionSchema - Cassandra doesn't support stored functions
@@ -258,19 +272,40 @@ func (c *CassandraConnection) PlanExtensionSchema(extensionName string, extensio
 	return nil, errors.New("cassandra does not support extensions")
 }
 
+// PlanGrantSchema - Cassandra grants are not yet implemented
+func (c *CassandraConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	return nil, errors.New("cassandra grant planning not yet implemented")
+}
+
+// PlanDatabaseSchema generates CQL statements for keyspace changes, Cassandra keyspaces are its schemas
+func (c *CassandraConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	schema, ok := databaseSchema.(*schemasv1alpha4.CassandraKeyspaceSchema)
+	if !ok {
+		return nil, fmt.Errorf("expected CassandraKeyspaceSchema, got %T", databaseSchema)
+	}
+
+	existing, err := readKeyspace(c.session, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing keyspace")
+	}
+
+	return KeyspaceStatements(schemaName, schema, existing)
+}
+
+// PlanSequenceSchema - Cassandra does not have sequences
+func (c *CassandraConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	return nil, errors.New("cassandra does not support sequences")
+}
+
 // DeployStatements executes the provided SQL statements
 func (c *CassandraConnection) DeployStatements(statements []string) error {
-	// Execute statements directly using the connection
-	for _, statement := range statements {
-		if statement == "" {
-			continue
-		}
-		// Statement is already printed by the main process
-		if err := c.session.Query(statement).Exec(); err != nil {
-			return errors.Wrapf(err, "failed to execute statement: %s", statement)
-		}
-	}
-	return nil
+	// Statements are already printed by the main process
+	return executeStatements(c, statements)
+}
+
+// GetOnlineCopyProgress returns the progress of copying the rows of a table that is recreated in this process
+func (c *CassandraConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	return getCopyProgress(c.keyspace, tableName), nil
 }
 
 // planCassandraTableSeedDataOnly generates SQL statements for seed data without a schema definition.
@@ -329,6 +364,23 @@ func (c *CassandraConnection) planCassandraTable(tableName string, cassandraTabl
 		return queries, nil
 	}
 
+	existingColumns, existingPrimaryKey, err := readPrimaryKey(c.session, c.keyspace, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing primary key")
+	}
+	views, err := readTableViews(c.session, c.keyspace, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read materialized views")
+	}
+	primaryKeyStatements, err := PrimaryKeyStatements(tableName, cassandraTableSchema, existingPrimaryKey, existingColumns, views)
+	if err != nil {
+		return nil, err
+	}
+	if len(primaryKeyStatements) > 0 {
+		// the table is created again with the schema
+		return primaryKeyStatements, nil
+	}
+
 	statements := []string{}
 
 	columnStatements, err := buildColumnStatements(c, tableName, cassandraTableSchema)
@@ -337,12 +389,26 @@ func (c *CassandraConnection) planCassandraTable(tableName string, cassandraTabl
 	}
 	statements = append(statements, columnStatements...)
 
-	propertiesStatements, err := buildPropertiesStatements(c, tableName, cassandraTableSchema)
+	existingProperties, err := readProperties(c.session, c.keyspace, "table", tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing properties")
+	}
+	propertiesStatements, err := PropertiesStatements("table", tableName, cassandraTableSchema.Properties, existingProperties)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build properties statements")
 	}
 	statements = append(statements, propertiesStatements...)
 
+	existingIndexes, err := readIndexes(c.session, c.keyspace, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing indexes")
+	}
+	indexStatements, err := IndexStatements(tableName, cassandraTableSchema.Indexes, existingIndexes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build index statements")
+	}
+	statements = append(statements, indexStatements...)
+
 	return statements, nil
 }
 
@@ -0,0 +1,148 @@
+package cassandra
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+var copyParamsRegex = regexp.MustCompile(`(\w+)=(\S+)`)
+
+var (
+	copyProgressMu sync.Mutex
+	copyProgress   = map[string]*types.OnlineCopyProgress{}
+)
+
+// tableCopy is the parsed form of a copy statement
+type tableCopy struct {
+	Table                string
+	Shadow               string
+	ChunkSize            int
+	ThrottleMilliseconds int
+	// Select reads the rows of the table as json
+	Select string
+}
+
+// parseCopyStatement reads the parameters from the comment of a copy statement
+func parseCopyStatement(statement string) (*tableCopy, error) {
+	statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+	if !strings.HasPrefix(statement, types.OnlineCopyMarker) {
+		return nil, errors.New("statement is not a copy")
+	}
+
+	end := strings.Index(statement, "*/")
+	if end < 0 {
+		return nil, errors.New("copy statement has no closing comment")
+	}
+
+	c := tableCopy{
+		ChunkSize:            defaultCopyChunkSize,
+		ThrottleMilliseconds: defaultCopyThrottleMilliseconds,
+		Select:               strings.TrimSpace(statement[end+2:]),
+	}
+
+	params := statement[len(types.OnlineCopyMarker):end]
+	for _, match := range copyParamsRegex.FindAllStringSubmatch(params, -1) {
+		switch match[1] {
+		case "table":
+			c.Table = match[2]
+		case "shadow":
+			c.Shadow = match[2]
+		case "chunkSize":
+			chunkSize, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse chunk size")
+			}
+			c.ChunkSize = chunkSize
+		case "throttleMilliseconds":
+			throttle, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse throttle")
+			}
+			c.ThrottleMilliseconds = throttle
+		}
+	}
+
+	if c.Table == "" || c.Shadow == "" || c.Select == "" {
+		return nil, errors.Errorf("copy statement is missing parameters: %q", statement)
+	}
+	if c.ChunkSize <= 0 {
+		return nil, errors.Errorf("copy chunk size must be positive, got %d", c.ChunkSize)
+	}
+
+	return &c, nil
+}
+
+func copyProgressKey(keyspace string, tableName string) string {
+	return fmt.Sprintf("%s.%s", keyspace, tableName)
+}
+
+func setCopyProgress(keyspace string, tableName string, progress types.OnlineCopyProgress) {
+	copyProgressMu.Lock()
+	defer copyProgressMu.Unlock()
+
+	copyProgress[copyProgressKey(keyspace, tableName)] = &progress
+}
+
+// getCopyProgress returns the progress of the last copy of the table run by this process
+func getCopyProgress(keyspace string, tableName string) *types.OnlineCopyProgress {
+	copyProgressMu.Lock()
+	defer copyProgressMu.Unlock()
+
+	progress, ok := copyProgress[copyProgressKey(keyspace, tableName)]
+	if !ok {
+		return nil
+	}
+	result := *progress
+	return &result
+}
+
+// runCopy reads the rows of a table as json one page at a time and inserts them into the other table.
+// Cassandra does not count the rows of a table, so the total is the number of rows copied so far.
+func runCopy(c *CassandraConnection, statement string) error {
+	tc, err := parseCopyStatement(statement)
+	if err != nil {
+		return err
+	}
+
+	progress := types.OnlineCopyProgress{}
+	setCopyProgress(c.keyspace, tc.Table, progress)
+
+	insert := fmt.Sprintf(`insert into "%s" json ?`, tc.Shadow)
+
+	var pageState []byte
+	for {
+		iter := c.session.Query(tc.Select).PageSize(tc.ChunkSize).PageState(pageState).Iter()
+		nextPageState := iter.PageState()
+
+		var row string
+		for iter.Scan(&row) {
+			if err := c.session.Query(insert, row).Exec(); err != nil {
+				iter.Close()
+				return errors.Wrapf(err, "failed to copy row into %s", tc.Shadow)
+			}
+			progress.RowsCopied++
+		}
+		if err := iter.Close(); err != nil {
+			return errors.Wrapf(err, "failed to read rows from %s", tc.Table)
+		}
+		progress.TotalRows = progress.RowsCopied
+
+		if len(nextPageState) == 0 {
+			progress.Completed = true
+			setCopyProgress(c.keyspace, tc.Table, progress)
+			return nil
+		}
+
+		setCopyProgress(c.keyspace, tc.Table, progress)
+		pageState = nextPageState
+
+		time.Sleep(time.Duration(tc.ThrottleMilliseconds) * time.Millisecond)
+	}
+}
@@ -2,10 +2,12 @@ package cassandra
 
 import (
 	"fmt"
-	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
 func CreateTypeStatement(keyspace string, typeName string, typeSchema *schemasv1alpha4.CassandraDataTypeSchema) (string, error) {
@@ -35,19 +37,7 @@ func CreateTableStatements(keyspace string, tableName string, tableSchema *schem
 
 	// primary key
 	if tableSchema.PrimaryKey != nil {
-		compoundedKeys := []string{}
-		for _, primaryKey := range tableSchema.PrimaryKey {
-			if len(primaryKey) == 1 {
-				compoundedKeys = append(compoundedKeys, primaryKey[0])
-				continue
-			}
-
-			keyComponent := fmt.Sprintf("(%s)", strings.Join(primaryKey, ", "))
-			compoundedKeys = append(compoundedKeys, keyComponent)
-		}
-
-		pk := fmt.Sprintf("primary key (%s)", strings.Join(compoundedKeys, ", "))
-		columns = append(columns, pk)
+		columns = append(columns, primaryKeyClause(tableSchema.PrimaryKey))
 	}
 
 	// Don't include keyspace in table name since it's already set in the session
@@ -55,125 +45,50 @@ func CreateTableStatements(keyspace string, tableName string, tableSchema *schem
 	_ = keyspace
 	query := fmt.Sprintf(`create table "%s" (%s)`, tableName, strings.Join(columns, ", "))
 
-	// clustering
-	if tableSchema.ClusteringOrder != nil {
-		order := ""
-		if tableSchema.ClusteringOrder.IsDescending != nil && *tableSchema.ClusteringOrder.IsDescending {
-			order = " desc"
-		}
-		clustering := fmt.Sprintf("with clustering order by (%s%s)", tableSchema.ClusteringOrder.Column, order)
+	// clustering and any specified properties
+	tableProperties := []string{}
+	if len(tableSchema.ClusteringOrder) > 0 {
+		tableProperties = append(tableProperties, clusteringOrderClause(tableSchema.ClusteringOrder))
+	}
+	tableProperties = append(tableProperties, tablePropertyClauses(tableSchema.Properties)...)
+	if len(tableProperties) > 0 {
+		query = fmt.Sprintf("%s with %s", query, strings.Join(tableProperties, " AND "))
+	}
+
+	statements := []string{query}
 
-		query = fmt.Sprintf("%s %s", query, clustering)
+	indexStatements, err := IndexStatements(tableName, tableSchema.Indexes, nil)
+	if err != nil {
+		return nil, err
 	}
+	statements = append(statements, indexStatements...)
 
-	// any specified properties
-	tableProperties := []string{}
-	if tableSchema.Properties != nil {
-		if tableSchema.Properties.BloomFilterFPChance != "" {
-			tableProperty := fmt.Sprintf(`bloom_filter_fp_chance = %s`, tableSchema.Properties.BloomFilterFPChance)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.Caching != nil {
-			// Cassandra expects map properties with single quotes: {'key':'value'}
-			// Sort keys for consistent output
-			keys := make([]string, 0, len(tableSchema.Properties.Caching))
-			for k := range tableSchema.Properties.Caching {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			
-			parts := []string{}
-			for _, k := range keys {
-				v := tableSchema.Properties.Caching[k]
-				parts = append(parts, fmt.Sprintf("'%s':'%s'", k, v))
-			}
-			tableProperty := fmt.Sprintf(`caching = {%s}`, strings.Join(parts, ","))
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.Comment != "" {
-			tableProperty := fmt.Sprintf(`comment = '%s'`, tableSchema.Properties.Comment)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.Compaction != nil {
-			// Cassandra expects map properties with single quotes: {'key':'value'}
-			// Sort keys for consistent output
-			keys := make([]string, 0, len(tableSchema.Properties.Compaction))
-			for k := range tableSchema.Properties.Compaction {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			
-			parts := []string{}
-			for _, k := range keys {
-				v := tableSchema.Properties.Compaction[k]
-				parts = append(parts, fmt.Sprintf("'%s':'%s'", k, v))
-			}
-			tableProperty := fmt.Sprintf(`compaction = {%s}`, strings.Join(parts, ","))
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.Compression != nil {
-			// Cassandra expects map properties with single quotes: {'key':'value'}
-			// Sort keys for consistent output
-			keys := make([]string, 0, len(tableSchema.Properties.Compression))
-			for k := range tableSchema.Properties.Compression {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			
-			parts := []string{}
-			for _, k := range keys {
-				v := tableSchema.Properties.Compression[k]
-				parts = append(parts, fmt.Sprintf("'%s':'%s'", k, v))
-			}
-			tableProperty := fmt.Sprintf(`compression = {%s}`, strings.Join(parts, ","))
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.CRCCheckChance != "" {
-			tableProperty := fmt.Sprintf(`crc_check_chance = %s`, tableSchema.Properties.CRCCheckChance)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.DCLocalReadRepairChance != "" {
-			tableProperty := fmt.Sprintf(`dclocal_read_repair_chance = %s`, tableSchema.Properties.DCLocalReadRepairChance)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.DefaultTTL != nil {
-			tableProperty := fmt.Sprintf(`default_time_to_live = %d`, *tableSchema.Properties.DefaultTTL)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.GCGraceSeconds != nil {
-			tableProperty := fmt.Sprintf(`gc_grace_seconds = %d`, *tableSchema.Properties.GCGraceSeconds)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.MaxIndexInterval != nil {
-			tableProperty := fmt.Sprintf(`max_index_interval = %d`, *tableSchema.Properties.MaxIndexInterval)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.MemtableFlushPeriodMS != nil {
-			tableProperty := fmt.Sprintf(`memtable_flush_period_in_ms = %d`, *tableSchema.Properties.MemtableFlushPeriodMS)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.MinIndexInterval != nil {
-			tableProperty := fmt.Sprintf(`min_index_interval = %d`, *tableSchema.Properties.MinIndexInterval)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.ReadRepairChance != "" {
-			tableProperty := fmt.Sprintf(`read_repair_chance = %s`, tableSchema.Properties.ReadRepairChance)
-			tableProperties = append(tableProperties, tableProperty)
-		}
-		if tableSchema.Properties.SpeculativeRetry != "" {
-			tableProperty := fmt.Sprintf(`speculative_retry = '%s'`, tableSchema.Properties.SpeculativeRetry)
-			tableProperties = append(tableProperties, tableProperty)
+	return statements, nil
+}
+
+// primaryKeyClause returns the primary key of a table or materialized view, the first key is the partition key
+func primaryKeyClause(primaryKey [][]string) string {
+	compoundedKeys := []string{}
+	for _, key := range primaryKey {
+		if len(key) == 1 {
+			compoundedKeys = append(compoundedKeys, key[0])
+			continue
 		}
-	}
 
-	if len(tableProperties) > 0 {
-		query = fmt.Sprintf("%s with %s", query, strings.Join(tableProperties, " AND "))
+		keyComponent := fmt.Sprintf("(%s)", strings.Join(key, ", "))
+		compoundedKeys = append(compoundedKeys, keyComponent)
 	}
 
-	return []string{query}, nil
+	return fmt.Sprintf("primary key (%s)", strings.Join(compoundedKeys, ", "))
 }
 
+// SeedDataStatements returns the inserts of the rows. An insert in cassandra updates a row that exists, so upsert
+// is the same as not setting a mode, and insertOnly uses a lightweight transaction
 func SeedDataStatements(keyspace string, tableName string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		return nil, errors.Errorf("seed data mode %s is not supported by cassandra", seedData.Mode)
+	}
+
 	statements := []string{}
 
 	for _, row := range seedData.Rows {
@@ -181,17 +96,38 @@ func SeedDataStatements(keyspace string, tableName string, seedData *schemasv1al
 		vals := []string{}
 		for _, col := range row.Columns {
 			cols = append(cols, col.Column)
-			if col.Value.Int != nil {
-				vals = append(vals, fmt.Sprintf("%d", *col.Value.Int))
-			} else if col.Value.Str != nil {
-				vals = append(vals, fmt.Sprintf("'%s'", *col.Value.Str))
+			val, err := types.SeedDataLiteral(col.Column, col.Value, seedDataLiterals)
+			if err != nil {
+				return nil, err
 			}
+			vals = append(vals, val)
 		}
 
 		statement := fmt.Sprintf(`INSERT INTO %s.%s (%s) VALUES (%s)`, keyspace, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "))
+		if seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly {
+			statement = fmt.Sprintf("%s IF NOT EXISTS", statement)
+		}
 		statements = append(statements, statement)
 	}
 
 	return statements, nil
 }
 
+// seedDataLiterals are the literals of seed data values. A json document is text
+var seedDataLiterals = types.SeedDataLiterals{
+	String: cqlString,
+	Bool:   strconv.FormatBool,
+	Bytes: func(b []byte) string {
+		return fmt.Sprintf("0x%x", b)
+	},
+	JSON: cqlString,
+}
+
+func cqlString(s string) string {
+	return fmt.Sprintf("'%s'", escapeString(s))
+}
+
+// escapeString escapes single quotes for use in a cql string literal
+func escapeString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
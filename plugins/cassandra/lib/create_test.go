@@ -119,8 +119,10 @@ func Test_CreateTableStatements(t *testing.T) {
 			keyspace:  "k",
 			tableName: "t",
 			tableSchema: schemasv1alpha4.CassandraTableSchema{
-				ClusteringOrder: &schemasv1alpha4.CassandraClusteringOrder{
-					Column: "a",
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{
+						Column: "a",
+					},
 				},
 				Columns: []*schemasv1alpha4.CassandraColumn{
 					{
@@ -130,7 +132,7 @@ func Test_CreateTableStatements(t *testing.T) {
 				},
 			},
 			expectedStatements: []string{
-				`create table "t" (a int) with clustering order by (a)`,
+				`create table "t" (a int) with clustering order by (a asc)`,
 			},
 		},
 		{
@@ -138,9 +140,11 @@ func Test_CreateTableStatements(t *testing.T) {
 			keyspace:  "k",
 			tableName: "t",
 			tableSchema: schemasv1alpha4.CassandraTableSchema{
-				ClusteringOrder: &schemasv1alpha4.CassandraClusteringOrder{
-					Column:       "a",
-					IsDescending: &trueValue,
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{
+						Column:       "a",
+						IsDescending: &trueValue,
+					},
 				},
 				Columns: []*schemasv1alpha4.CassandraColumn{
 					{
@@ -153,6 +157,52 @@ func Test_CreateTableStatements(t *testing.T) {
 				`create table "t" (a int) with clustering order by (a desc)`,
 			},
 		},
+		{
+			name:      "with comment",
+			keyspace:  "k",
+			tableName: "t",
+			tableSchema: schemasv1alpha4.CassandraTableSchema{
+				Columns: []*schemasv1alpha4.CassandraColumn{
+					{
+						Name: "a",
+						Type: "int",
+					},
+				},
+				Properties: &schemasv1alpha4.CassandraTableProperties{
+					Comment: "the table's comment",
+				},
+			},
+			expectedStatements: []string{
+				`create table "t" (a int) with comment = 'the table''s comment'`,
+			},
+		},
+		{
+			name:      "with indexes",
+			keyspace:  "k",
+			tableName: "t",
+			tableSchema: schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey: [][]string{{"a"}},
+				Columns: []*schemasv1alpha4.CassandraColumn{
+					{
+						Name: "a",
+						Type: "int",
+					},
+					{
+						Name: "b",
+						Type: "text",
+					},
+				},
+				Indexes: []*schemasv1alpha4.CassandraTableIndex{
+					{
+						Column: "b",
+					},
+				},
+			},
+			expectedStatements: []string{
+				`create table "t" (a int, b text, primary key (a))`,
+				`create index "t_b_idx" on "t" (b)`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -165,3 +215,89 @@ func Test_CreateTableStatements(t *testing.T) {
 		})
 	}
 }
+
+func Test_SeedDataStatements(t *testing.T) {
+	id, name, active, doc, data, createdAt := 1, "it's\nhere", true, `{"a": 1}`, "Af8=", "toTimestamp(now())"
+	price := schemasv1alpha4.SeedDataFloat("19.90")
+	seedData := &schemasv1alpha4.SeedData{
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &id}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+					{Column: "active", Value: schemasv1alpha4.SeedDataValue{Bool: &active}},
+					{Column: "price", Value: schemasv1alpha4.SeedDataValue{Float: &price}},
+					{Column: "deleted_at", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+					{Column: "doc", Value: schemasv1alpha4.SeedDataValue{JSON: &doc}},
+					{Column: "data", Value: schemasv1alpha4.SeedDataValue{Bytes: &data}},
+					{Column: "created_at", Value: schemasv1alpha4.SeedDataValue{Expr: &createdAt}},
+				},
+			},
+		},
+	}
+
+	statements, err := SeedDataStatements("schemahero", "users", seedData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"INSERT INTO schemahero.users (id, name, active, price, deleted_at, doc, data, created_at) VALUES (1, 'it''s\nhere', true, 19.90, null, '{\"a\": 1}', 0x01ff, toTimestamp(now()))",
+	}, statements)
+
+	seedData.Rows[0].Columns[3].Value.Null = true
+	_, err = SeedDataStatements("schemahero", "users", seedData)
+	assert.EqualError(t, err, "seed data for column price has more than one value")
+}
+
+func Test_SeedDataStatementsModes(t *testing.T) {
+	one, two, name := 1, 2, "a"
+	rows := []schemasv1alpha4.SeedDataRow{
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &one}},
+				{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+			},
+		},
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &two}},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode            schemasv1alpha4.SeedDataMode
+		expect          []string
+		expectErrSubstr string
+	}{
+		{
+			mode: schemasv1alpha4.SeedDataModeInsertOnly,
+			expect: []string{
+				`INSERT INTO schemahero.users (id, name) VALUES (1, 'a') IF NOT EXISTS`,
+				`INSERT INTO schemahero.users (id) VALUES (2) IF NOT EXISTS`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeUpsert,
+			expect: []string{
+				`INSERT INTO schemahero.users (id, name) VALUES (1, 'a')`,
+				`INSERT INTO schemahero.users (id) VALUES (2)`,
+			},
+		},
+		{
+			mode:            schemasv1alpha4.SeedDataModeExact,
+			expectErrSubstr: "seed data mode exact is not supported by cassandra",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.mode), func(t *testing.T) {
+			statements, err := SeedDataStatements("schemahero", "users", &schemasv1alpha4.SeedData{Mode: test.mode, Rows: rows})
+			if test.expectErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, statements)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package cassandra
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+const (
+	saiIndexClass  = "StorageAttachedIndex"
+	sasiIndexClass = "org.apache.cassandra.index.sasi.SASIIndex"
+)
+
+// indexTargetRegexp matches an index on a collection, such as keys(m)
+var indexTargetRegexp = regexp.MustCompile(`^(?i)(keys|values|entries|full)\s*\(\s*("?[^()"]+"?)\s*\)$`)
+
+// existingIndex is an index read from system_schema.indexes
+type existingIndex struct {
+	Name    string
+	Kind    string
+	Options map[string]string
+}
+
+func validateIndex(tableName string, index *schemasv1alpha4.CassandraTableIndex) error {
+	if strings.TrimSpace(index.Column) == "" {
+		return errors.Errorf("index on table %s requires a column", tableName)
+	}
+
+	switch index.Type {
+	case "":
+		if len(index.Options) > 0 {
+			return errors.Errorf("index %s on table %s sets options, options are only supported on SAI, SASI and CUSTOM indexes", indexName(tableName, index), tableName)
+		}
+	case "SAI", "SASI":
+	case "CUSTOM":
+		if index.Class == "" {
+			return errors.Errorf("custom index %s on table %s requires a class", indexName(tableName, index), tableName)
+		}
+	default:
+		return errors.Errorf("unsupported index type %q on table %s", index.Type, tableName)
+	}
+
+	if index.Class != "" && index.Type != "CUSTOM" {
+		return errors.Errorf("index %s on table %s sets a class, the class is only used by CUSTOM indexes", indexName(tableName, index), tableName)
+	}
+
+	return nil
+}
+
+// indexBaseColumn returns the column of the index target without a collection function
+func indexBaseColumn(target string) string {
+	target = strings.TrimSpace(target)
+	if matches := indexTargetRegexp.FindStringSubmatch(target); matches != nil {
+		target = matches[2]
+	}
+	return strings.Trim(target, `"`)
+}
+
+// normalizeIndexTarget returns the index target for comparison, an index on a collection indexes its values by default so values(m) and m are the same
+func normalizeIndexTarget(target string) string {
+	target = strings.TrimSpace(target)
+	matches := indexTargetRegexp.FindStringSubmatch(target)
+	if matches == nil {
+		return strings.Trim(target, `"`)
+	}
+
+	function := strings.ToLower(matches[1])
+	column := strings.Trim(matches[2], `"`)
+	if function == "values" {
+		return column
+	}
+	return fmt.Sprintf("%s(%s)", function, column)
+}
+
+// indexName returns the name of the index, cassandra names unnamed indexes <table>_<column>_idx
+func indexName(tableName string, index *schemasv1alpha4.CassandraTableIndex) string {
+	if index.Name != "" {
+		return index.Name
+	}
+	return fmt.Sprintf("%s_%s_idx", tableName, indexBaseColumn(index.Column))
+}
+
+// indexClass returns the class of a custom index, or an empty string for a regular secondary index
+func indexClass(index *schemasv1alpha4.CassandraTableIndex) string {
+	switch index.Type {
+	case "SAI":
+		return saiIndexClass
+	case "SASI":
+		return sasiIndexClass
+	case "CUSTOM":
+		return index.Class
+	}
+	return ""
+}
+
+// optionsMap returns the options as a cql map, keys are sorted
+func optionsMap(options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := []string{}
+	for _, key := range keys {
+		entries = append(entries, fmt.Sprintf("'%s': '%s'", escapeString(key), escapeString(options[key])))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+}
+
+// CreateIndexStatement returns the statement to create an index on a table
+func CreateIndexStatement(tableName string, index *schemasv1alpha4.CassandraTableIndex) (string, error) {
+	if err := validateIndex(tableName, index); err != nil {
+		return "", err
+	}
+
+	class := indexClass(index)
+	if class == "" {
+		return fmt.Sprintf(`create index "%s" on "%s" (%s)`, indexName(tableName, index), tableName, strings.TrimSpace(index.Column)), nil
+	}
+
+	query := fmt.Sprintf(`create custom index "%s" on "%s" (%s) using '%s'`, indexName(tableName, index), tableName, strings.TrimSpace(index.Column), escapeString(class))
+	if len(index.Options) > 0 {
+		query = fmt.Sprintf("%s with options = %s", query, optionsMap(index.Options))
+	}
+
+	return query, nil
+}
+
+// indexChanged returns true when the existing index is different from the index in the schema
+func indexChanged(index *schemasv1alpha4.CassandraTableIndex, existing *existingIndex) bool {
+	if normalizeIndexTarget(existing.Options["target"]) != normalizeIndexTarget(index.Column) {
+		return true
+	}
+
+	// the class is stored with its package when the index was created with a short name
+	class := indexClass(index)
+	existingClass := existing.Options["class_name"]
+	if class == "" {
+		if existingClass != "" || strings.EqualFold(existing.Kind, "CUSTOM") {
+			return true
+		}
+	} else if existingClass != class && !strings.HasSuffix(existingClass, "."+class) {
+		return true
+	}
+
+	existingOptions := map[string]string{}
+	for key, value := range existing.Options {
+		if key == "target" || key == "class_name" {
+			continue
+		}
+		existingOptions[key] = value
+	}
+	if len(existingOptions) != len(index.Options) {
+		return true
+	}
+	for key, value := range index.Options {
+		existingValue, ok := existingOptions[key]
+		if !ok || existingValue != value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndexStatements returns the statements to move the indexes of a table from the existing indexes to the schema.
+// Indexes are not managed when the schema has none, otherwise existing indexes that are not in the schema are dropped.
+// Cassandra cannot alter an index, so a changed index is dropped and created again.
+func IndexStatements(tableName string, indexes []*schemasv1alpha4.CassandraTableIndex, existing []*existingIndex) ([]string, error) {
+	if indexes == nil {
+		return []string{}, nil
+	}
+
+	dropStatements := []string{}
+	createStatements := []string{}
+
+	desiredNames := map[string]bool{}
+	for _, index := range indexes {
+		name := indexName(tableName, index)
+		if desiredNames[name] {
+			return nil, errors.Errorf("index %s is declared more than once on table %s", name, tableName)
+		}
+		desiredNames[name] = true
+
+		createStatement, err := CreateIndexStatement(tableName, index)
+		if err != nil {
+			return nil, err
+		}
+
+		var existingMatch *existingIndex
+		for _, existingIndex := range existing {
+			if existingIndex.Name == name {
+				existingMatch = existingIndex
+				break
+			}
+		}
+
+		if existingMatch == nil {
+			createStatements = append(createStatements, createStatement)
+			continue
+		}
+
+		if indexChanged(index, existingMatch) {
+			dropStatements = append(dropStatements, fmt.Sprintf(`drop index "%s"`, name))
+			createStatements = append(createStatements, createStatement)
+		}
+	}
+
+	for _, existingIndex := range existing {
+		if !desiredNames[existingIndex.Name] {
+			dropStatements = append(dropStatements, fmt.Sprintf(`drop index "%s"`, existingIndex.Name))
+		}
+	}
+
+	return append(dropStatements, createStatements...), nil
+}
+
+// readIndexes returns the indexes of a table from system_schema.indexes
+func readIndexes(session *gocql.Session, keyspace string, tableName string) ([]*existingIndex, error) {
+	query := `select index_name, kind, options from system_schema.indexes where keyspace_name = ? and table_name = ?`
+	iter := session.Query(query, keyspace, tableName).Iter()
+
+	indexes := []*existingIndex{}
+	var name, kind string
+	var options map[string]string
+	for iter.Scan(&name, &kind, &options) {
+		indexes = append(indexes, &existingIndex{
+			Name:    name,
+			Kind:    kind,
+			Options: options,
+		})
+		options = nil
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to read indexes")
+	}
+
+	return indexes, nil
+}
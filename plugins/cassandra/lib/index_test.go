@@ -0,0 +1,161 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateIndexStatement(t *testing.T) {
+	tests := []struct {
+		name              string
+		index             *schemasv1alpha4.CassandraTableIndex
+		expectedStatement string
+		wantErr           string
+	}{
+		{
+			name: "regular",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Column: "b",
+			},
+			expectedStatement: `create index "t_b_idx" on "t" (b)`,
+		},
+		{
+			name: "map keys",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Name:   "t_m_keys",
+				Column: "keys(m)",
+			},
+			expectedStatement: `create index "t_m_keys" on "t" (keys(m))`,
+		},
+		{
+			name: "sai with options",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Column: "b",
+				Type:   "SAI",
+				Options: map[string]string{
+					"normalize":      "true",
+					"case_sensitive": "false",
+				},
+			},
+			expectedStatement: `create custom index "t_b_idx" on "t" (b) using 'StorageAttachedIndex' with options = {'case_sensitive': 'false', 'normalize': 'true'}`,
+		},
+		{
+			name: "sasi",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Column: "b",
+				Type:   "SASI",
+				Options: map[string]string{
+					"mode": "CONTAINS",
+				},
+			},
+			expectedStatement: `create custom index "t_b_idx" on "t" (b) using 'org.apache.cassandra.index.sasi.SASIIndex' with options = {'mode': 'CONTAINS'}`,
+		},
+		{
+			name: "custom without a class",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Column: "b",
+				Type:   "CUSTOM",
+			},
+			wantErr: "custom index t_b_idx on table t requires a class",
+		},
+		{
+			name: "options on a regular index",
+			index: &schemasv1alpha4.CassandraTableIndex{
+				Column:  "b",
+				Options: map[string]string{"mode": "CONTAINS"},
+			},
+			wantErr: "index t_b_idx on table t sets options, options are only supported on SAI, SASI and CUSTOM indexes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statement, err := CreateIndexStatement("t", test.index)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatement, statement)
+		})
+	}
+}
+
+func Test_IndexStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		indexes            []*schemasv1alpha4.CassandraTableIndex
+		existing           []*existingIndex
+		expectedStatements []string
+	}{
+		{
+			name: "unmanaged",
+			existing: []*existingIndex{
+				{Name: "t_b_idx", Kind: "COMPOSITES", Options: map[string]string{"target": "b"}},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "unchanged",
+			indexes: []*schemasv1alpha4.CassandraTableIndex{
+				{Column: "b"},
+				{Column: "m"},
+				{Column: "c", Type: "SAI"},
+			},
+			existing: []*existingIndex{
+				{Name: "t_b_idx", Kind: "COMPOSITES", Options: map[string]string{"target": "b"}},
+				{Name: "t_m_idx", Kind: "COMPOSITES", Options: map[string]string{"target": "values(m)"}},
+				{Name: "t_c_idx", Kind: "CUSTOM", Options: map[string]string{"target": "c", "class_name": "org.apache.cassandra.index.sai.StorageAttachedIndex"}},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "changed options are recreated and undeclared indexes dropped",
+			indexes: []*schemasv1alpha4.CassandraTableIndex{
+				{Column: "b", Type: "SASI", Options: map[string]string{"mode": "CONTAINS"}},
+				{Column: "c"},
+			},
+			existing: []*existingIndex{
+				{Name: "t_b_idx", Kind: "CUSTOM", Options: map[string]string{"target": "b", "class_name": "org.apache.cassandra.index.sasi.SASIIndex", "mode": "PREFIX"}},
+				{Name: "t_d_idx", Kind: "COMPOSITES", Options: map[string]string{"target": "d"}},
+			},
+			expectedStatements: []string{
+				`drop index "t_b_idx"`,
+				`drop index "t_d_idx"`,
+				`create custom index "t_b_idx" on "t" (b) using 'org.apache.cassandra.index.sasi.SASIIndex' with options = {'mode': 'CONTAINS'}`,
+				`create index "t_c_idx" on "t" (c)`,
+			},
+		},
+		{
+			name: "regular index changed to sai",
+			indexes: []*schemasv1alpha4.CassandraTableIndex{
+				{Column: "b", Type: "SAI"},
+			},
+			existing: []*existingIndex{
+				{Name: "t_b_idx", Kind: "COMPOSITES", Options: map[string]string{"target": "b"}},
+			},
+			expectedStatements: []string{
+				`drop index "t_b_idx"`,
+				`create custom index "t_b_idx" on "t" (b) using 'StorageAttachedIndex'`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := IndexStatements("t", test.indexes, test.existing)
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// existingKeyspace is a keyspace read from system_schema.keyspaces
+type existingKeyspace struct {
+	Replication   map[string]string
+	DurableWrites bool
+}
+
+func validateKeyspace(keyspaceName string, keyspaceSchema *schemasv1alpha4.CassandraKeyspaceSchema) error {
+	replication := keyspaceSchema.Replication
+	switch replication.Class {
+	case "SimpleStrategy":
+		if replication.ReplicationFactor < 1 {
+			return errors.Errorf("keyspace %s requires a replication factor with SimpleStrategy", keyspaceName)
+		}
+		if len(replication.DataCenters) > 0 {
+			return errors.Errorf("keyspace %s cannot set data centers with SimpleStrategy", keyspaceName)
+		}
+	case "NetworkTopologyStrategy":
+		if len(replication.DataCenters) == 0 {
+			return errors.Errorf("keyspace %s requires data centers with NetworkTopologyStrategy", keyspaceName)
+		}
+		if replication.ReplicationFactor > 0 {
+			return errors.Errorf("keyspace %s sets the replication factor of each data center with NetworkTopologyStrategy", keyspaceName)
+		}
+	default:
+		return errors.Errorf("unsupported replication class %q for keyspace %s", replication.Class, keyspaceName)
+	}
+
+	return nil
+}
+
+// replicationMap returns the replication of the keyspace as a cql map, data centers are sorted by name
+func replicationMap(replication schemasv1alpha4.CassandraKeyspaceReplication) string {
+	entries := []string{fmt.Sprintf("'class': '%s'", replication.Class)}
+	if replication.Class == "SimpleStrategy" {
+		entries = append(entries, fmt.Sprintf("'replication_factor': %d", replication.ReplicationFactor))
+	} else {
+		dataCenters := make([]string, 0, len(replication.DataCenters))
+		for dataCenter := range replication.DataCenters {
+			dataCenters = append(dataCenters, dataCenter)
+		}
+		sort.Strings(dataCenters)
+		for _, dataCenter := range dataCenters {
+			entries = append(entries, fmt.Sprintf("'%s': %d", dataCenter, replication.DataCenters[dataCenter]))
+		}
+	}
+	return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+}
+
+func durableWrites(keyspaceSchema *schemasv1alpha4.CassandraKeyspaceSchema) bool {
+	return keyspaceSchema.DurableWrites == nil || *keyspaceSchema.DurableWrites
+}
+
+// CreateKeyspaceStatement returns the statement to create a keyspace
+func CreateKeyspaceStatement(keyspaceName string, keyspaceSchema *schemasv1alpha4.CassandraKeyspaceSchema) (string, error) {
+	if err := validateKeyspace(keyspaceName, keyspaceSchema); err != nil {
+		return "", err
+	}
+
+	query := fmt.Sprintf(`create keyspace "%s" with replication = %s`, keyspaceName, replicationMap(keyspaceSchema.Replication))
+	if keyspaceSchema.DurableWrites != nil {
+		query = fmt.Sprintf("%s and durable_writes = %t", query, *keyspaceSchema.DurableWrites)
+	}
+
+	return query, nil
+}
+
+// replicationChanged returns true when the replication of the existing keyspace is different from the schema
+func replicationChanged(replication schemasv1alpha4.CassandraKeyspaceReplication, existing map[string]string) bool {
+	// the class is stored with its package, such as org.apache.cassandra.locator.SimpleStrategy
+	existingClass := existing["class"]
+	if existingClass != replication.Class && !strings.HasSuffix(existingClass, "."+replication.Class) {
+		return true
+	}
+
+	desired := map[string]string{}
+	if replication.Class == "SimpleStrategy" {
+		desired["replication_factor"] = strconv.Itoa(replication.ReplicationFactor)
+	} else {
+		for dataCenter, factor := range replication.DataCenters {
+			desired[dataCenter] = strconv.Itoa(factor)
+		}
+	}
+
+	if len(desired) != len(existing)-1 {
+		return true
+	}
+	for key, value := range desired {
+		if existing[key] != value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeyspaceStatements returns the statements to move the keyspace from the existing state to the schema.
+// A keyspace that does not exist is created, and the replication and durable writes of an existing keyspace are altered.
+func KeyspaceStatements(keyspaceName string, keyspaceSchema *schemasv1alpha4.CassandraKeyspaceSchema, existing *existingKeyspace) ([]string, error) {
+	if keyspaceSchema.IsDeleted {
+		if existing == nil {
+			return []string{}, nil
+		}
+		return []string{
+			fmt.Sprintf(`drop keyspace "%s"`, keyspaceName),
+		}, nil
+	}
+
+	if existing == nil {
+		statement, err := CreateKeyspaceStatement(keyspaceName, keyspaceSchema)
+		if err != nil {
+			return nil, err
+		}
+		return []string{statement}, nil
+	}
+
+	if err := validateKeyspace(keyspaceName, keyspaceSchema); err != nil {
+		return nil, err
+	}
+
+	options := []string{}
+	if replicationChanged(keyspaceSchema.Replication, existing.Replication) {
+		options = append(options, fmt.Sprintf("replication = %s", replicationMap(keyspaceSchema.Replication)))
+	}
+	if durableWrites(keyspaceSchema) != existing.DurableWrites {
+		options = append(options, fmt.Sprintf("durable_writes = %t", durableWrites(keyspaceSchema)))
+	}
+
+	if len(options) == 0 {
+		return []string{}, nil
+	}
+
+	return []string{
+		fmt.Sprintf(`alter keyspace "%s" with %s`, keyspaceName, strings.Join(options, " and ")),
+	}, nil
+}
+
+// readKeyspace returns the keyspace from system_schema.keyspaces, or nil when it does not exist
+func readKeyspace(session *gocql.Session, keyspaceName string) (*existingKeyspace, error) {
+	existing := existingKeyspace{}
+	query := `select replication, durable_writes from system_schema.keyspaces where keyspace_name = ?`
+	if err := session.Query(query, keyspaceName).Scan(&existing.Replication, &existing.DurableWrites); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read keyspace")
+	}
+
+	return &existing, nil
+}
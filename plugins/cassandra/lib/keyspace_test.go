@@ -0,0 +1,145 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KeyspaceStatements(t *testing.T) {
+	durableWrites := false
+
+	tests := []struct {
+		name               string
+		keyspaceSchema     *schemasv1alpha4.CassandraKeyspaceSchema
+		existing           *existingKeyspace
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "create simple strategy",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:             "SimpleStrategy",
+					ReplicationFactor: 3,
+				},
+			},
+			expectedStatements: []string{
+				`create keyspace "k" with replication = {'class': 'SimpleStrategy', 'replication_factor': 3}`,
+			},
+		},
+		{
+			name: "create network topology strategy without durable writes",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:       "NetworkTopologyStrategy",
+					DataCenters: map[string]int{"dc2": 2, "dc1": 3},
+				},
+				DurableWrites: &durableWrites,
+			},
+			expectedStatements: []string{
+				`create keyspace "k" with replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 2} and durable_writes = false`,
+			},
+		},
+		{
+			name: "unchanged",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:       "NetworkTopologyStrategy",
+					DataCenters: map[string]int{"dc1": 3},
+				},
+			},
+			existing: &existingKeyspace{
+				Replication: map[string]string{
+					"class": "org.apache.cassandra.locator.NetworkTopologyStrategy",
+					"dc1":   "3",
+				},
+				DurableWrites: true,
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "add a data center",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:       "NetworkTopologyStrategy",
+					DataCenters: map[string]int{"dc1": 3, "dc2": 3},
+				},
+			},
+			existing: &existingKeyspace{
+				Replication: map[string]string{
+					"class": "org.apache.cassandra.locator.NetworkTopologyStrategy",
+					"dc1":   "3",
+				},
+				DurableWrites: true,
+			},
+			expectedStatements: []string{
+				`alter keyspace "k" with replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 3}`,
+			},
+		},
+		{
+			name: "change strategy and durable writes",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:       "NetworkTopologyStrategy",
+					DataCenters: map[string]int{"dc1": 1},
+				},
+				DurableWrites: &durableWrites,
+			},
+			existing: &existingKeyspace{
+				Replication: map[string]string{
+					"class":              "org.apache.cassandra.locator.SimpleStrategy",
+					"replication_factor": "1",
+				},
+				DurableWrites: true,
+			},
+			expectedStatements: []string{
+				`alter keyspace "k" with replication = {'class': 'NetworkTopologyStrategy', 'dc1': 1} and durable_writes = false`,
+			},
+		},
+		{
+			name: "deleted",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				IsDeleted: true,
+			},
+			existing: &existingKeyspace{
+				Replication: map[string]string{
+					"class":              "org.apache.cassandra.locator.SimpleStrategy",
+					"replication_factor": "1",
+				},
+			},
+			expectedStatements: []string{
+				`drop keyspace "k"`,
+			},
+		},
+		{
+			name: "data centers with simple strategy",
+			keyspaceSchema: &schemasv1alpha4.CassandraKeyspaceSchema{
+				Replication: schemasv1alpha4.CassandraKeyspaceReplication{
+					Class:             "SimpleStrategy",
+					ReplicationFactor: 1,
+					DataCenters:       map[string]int{"dc1": 1},
+				},
+			},
+			wantErr: "keyspace k cannot set data centers with SimpleStrategy",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := KeyspaceStatements("k", test.keyspaceSchema, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+const (
+	defaultCopyChunkSize            = 1000
+	defaultCopyThrottleMilliseconds = 100
+)
+
+// existingPrimaryKey is the primary key of a table or materialized view read from system_schema.columns
+type existingPrimaryKey struct {
+	PartitionKey []string
+	Clustering   []string
+	// ClusteringOrder is asc or desc for each clustering column
+	ClusteringOrder map[string]string
+}
+
+func copyTableName(tableName string) string {
+	return fmt.Sprintf("_%s_copy", tableName)
+}
+
+// clusteringColumns returns the clustering columns of a primary key, all columns after the partition key
+func clusteringColumns(primaryKey [][]string) []string {
+	clustering := []string{}
+	if len(primaryKey) == 0 {
+		return clustering
+	}
+	for _, key := range primaryKey[1:] {
+		clustering = append(clustering, key...)
+	}
+	return clustering
+}
+
+// clusteringOrderByColumn returns asc or desc for each clustering column, columns that are not ordered are ascending
+func clusteringOrderByColumn(primaryKey [][]string, clusteringOrder []*schemasv1alpha4.CassandraClusteringOrder) map[string]string {
+	order := map[string]string{}
+	for _, column := range clusteringColumns(primaryKey) {
+		order[column] = "asc"
+	}
+	for _, columnOrder := range clusteringOrder {
+		if columnOrder.IsDescending != nil && *columnOrder.IsDescending {
+			order[columnOrder.Column] = "desc"
+		}
+	}
+	return order
+}
+
+// clusteringOrderClause returns the clustering order of the columns in the order they are listed
+func clusteringOrderClause(clusteringOrder []*schemasv1alpha4.CassandraClusteringOrder) string {
+	columns := []string{}
+	for _, columnOrder := range clusteringOrder {
+		order := "asc"
+		if columnOrder.IsDescending != nil && *columnOrder.IsDescending {
+			order = "desc"
+		}
+		columns = append(columns, fmt.Sprintf("%s %s", columnOrder.Column, order))
+	}
+	return fmt.Sprintf("clustering order by (%s)", strings.Join(columns, ", "))
+}
+
+// validateClusteringOrder checks that the clustering order lists clustering columns in primary key order
+func validateClusteringOrder(kind string, name string, primaryKey [][]string, clusteringOrder []*schemasv1alpha4.CassandraClusteringOrder) error {
+	clustering := clusteringColumns(primaryKey)
+	position := -1
+	for _, columnOrder := range clusteringOrder {
+		found := false
+		for i, column := range clustering {
+			if column != columnOrder.Column {
+				continue
+			}
+			if i < position {
+				return errors.Errorf("%s %s orders by %s out of primary key order", kind, name, columnOrder.Column)
+			}
+			position = i
+			found = true
+			break
+		}
+		if !found {
+			return errors.Errorf("%s %s orders by %s, which is not a clustering column", kind, name, columnOrder.Column)
+		}
+	}
+
+	return nil
+}
+
+// primaryKeyChanged returns true when the partition key, clustering columns or clustering order are different
+func primaryKeyChanged(primaryKey [][]string, clusteringOrder []*schemasv1alpha4.CassandraClusteringOrder, existing *existingPrimaryKey) bool {
+	if len(primaryKey) == 0 {
+		return false
+	}
+
+	if strings.Join(primaryKey[0], ",") != strings.Join(existing.PartitionKey, ",") {
+		return true
+	}
+	clustering := clusteringColumns(primaryKey)
+	if strings.Join(clustering, ",") != strings.Join(existing.Clustering, ",") {
+		return true
+	}
+	order := clusteringOrderByColumn(primaryKey, clusteringOrder)
+	for _, column := range clustering {
+		if !strings.EqualFold(existing.ClusteringOrder[column], order[column]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describePrimaryKey returns the primary key as it is written in a create table statement, with the clustering order
+func describePrimaryKey(partitionKey []string, clustering []string, order map[string]string) string {
+	keys := []string{fmt.Sprintf("(%s)", strings.Join(partitionKey, ", "))}
+	for _, column := range clustering {
+		keys = append(keys, fmt.Sprintf("%s %s", column, strings.ToLower(order[column])))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(keys, ", "))
+}
+
+// copyStatement returns the statement that copies the columns of a table into another table
+func copyStatement(from string, to string, columns []string, primaryKeyChange *schemasv1alpha4.CassandraPrimaryKeyChange) string {
+	chunkSize := defaultCopyChunkSize
+	throttle := defaultCopyThrottleMilliseconds
+	if primaryKeyChange.ChunkSize > 0 {
+		chunkSize = primaryKeyChange.ChunkSize
+	}
+	if primaryKeyChange.ThrottleMilliseconds > 0 {
+		throttle = primaryKeyChange.ThrottleMilliseconds
+	}
+
+	return fmt.Sprintf(`%s table=%s shadow=%s chunkSize=%d throttleMilliseconds=%d */ select json %s from "%s"`,
+		types.OnlineCopyMarker, from, to, chunkSize, throttle, strings.Join(columns, ", "), from)
+}
+
+// RecreateTableStatements returns the statements that change the primary key of a table. Cassandra cannot alter
+// a primary key or rename a table, so the rows are copied into a new table, and the table is dropped, created with
+// the schema and the rows are copied back. Only the columns of the schema that are in the existing table are copied.
+// The drop of the table reports a warning in the plan, the copy is kept when copying the rows back fails.
+func RecreateTableStatements(tableName string, cassandraTableSchema *schemasv1alpha4.CassandraTableSchema, existingColumns []string) ([]string, error) {
+	existing := map[string]bool{}
+	for _, column := range existingColumns {
+		existing[column] = true
+	}
+
+	for _, key := range cassandraTableSchema.PrimaryKey {
+		for _, column := range key {
+			if !existing[column] {
+				return nil, errors.Errorf("primary key column %s is not in table %s, the rows of the table cannot be copied", column, tableName)
+			}
+		}
+	}
+
+	columns := []string{}
+	for _, column := range cassandraTableSchema.Columns {
+		if existing[column.Name] {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	// indexes are named for the table, so they are only created with it
+	copySchema := cassandraTableSchema.DeepCopy()
+	copySchema.Indexes = nil
+	copySchema.Properties = nil
+
+	createCopyStatements, err := CreateTableStatements("", copyTableName(tableName), copySchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create copy table statement")
+	}
+	createStatements, err := CreateTableStatements("", tableName, cassandraTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create table statement")
+	}
+
+	statements := []string{}
+	statements = append(statements, createCopyStatements...)
+	warning := fmt.Sprintf("table %s is dropped and created again from its copy %s, writes to %s made while the rows are copied are lost",
+		tableName, copyTableName(tableName), tableName)
+	statements = append(statements,
+		copyStatement(tableName, copyTableName(tableName), columns, cassandraTableSchema.PrimaryKeyChange),
+		fmt.Sprintf("%s\ndrop table \"%s\"", types.WarningComment(warning), tableName),
+	)
+	statements = append(statements, createStatements...)
+	statements = append(statements,
+		copyStatement(copyTableName(tableName), tableName, columns, cassandraTableSchema.PrimaryKeyChange),
+		fmt.Sprintf(`drop table "%s"`, copyTableName(tableName)),
+	)
+
+	return statements, nil
+}
+
+// PrimaryKeyStatements returns the statements to change the primary key of an existing table, or no statements when
+// the primary key is unchanged. A change fails to plan unless the table opts in to being dropped and recreated.
+func PrimaryKeyStatements(tableName string, cassandraTableSchema *schemasv1alpha4.CassandraTableSchema, existing *existingPrimaryKey, existingColumns []string, views []string) ([]string, error) {
+	if err := validateClusteringOrder("table", tableName, cassandraTableSchema.PrimaryKey, cassandraTableSchema.ClusteringOrder); err != nil {
+		return nil, err
+	}
+
+	if !primaryKeyChanged(cassandraTableSchema.PrimaryKey, cassandraTableSchema.ClusteringOrder, existing) {
+		return []string{}, nil
+	}
+
+	from := describePrimaryKey(existing.PartitionKey, existing.Clustering, existing.ClusteringOrder)
+	to := describePrimaryKey(cassandraTableSchema.PrimaryKey[0], clusteringColumns(cassandraTableSchema.PrimaryKey),
+		clusteringOrderByColumn(cassandraTableSchema.PrimaryKey, cassandraTableSchema.ClusteringOrder))
+
+	if cassandraTableSchema.PrimaryKeyChange == nil || !cassandraTableSchema.PrimaryKeyChange.Destructive {
+		return nil, errors.Errorf("the primary key of table %s changed from %s to %s, cassandra cannot alter a primary key. Set primaryKeyChange.destructive to drop and recreate the table and copy its rows, writes made while the rows are copied are lost", tableName, from, to)
+	}
+	if len(views) > 0 {
+		return nil, errors.Errorf("the primary key of table %s changed from %s to %s, the table cannot be recreated while materialized views %s select from it", tableName, from, to, strings.Join(views, ", "))
+	}
+
+	return RecreateTableStatements(tableName, cassandraTableSchema, existingColumns)
+}
+
+// readPrimaryKey returns the columns and the primary key of a table or materialized view from system_schema.columns
+func readPrimaryKey(session *gocql.Session, keyspace string, tableName string) ([]string, *existingPrimaryKey, error) {
+	type keyColumn struct {
+		name     string
+		position int
+	}
+	partitionKey := []keyColumn{}
+	clustering := []keyColumn{}
+
+	existing := existingPrimaryKey{
+		ClusteringOrder: map[string]string{},
+	}
+	columns := []string{}
+
+	query := `select column_name, kind, position, clustering_order from system_schema.columns where keyspace_name = ? and table_name = ?`
+	iter := session.Query(query, keyspace, tableName).Iter()
+	var columnName, kind, clusteringOrder string
+	var position int
+	for iter.Scan(&columnName, &kind, &position, &clusteringOrder) {
+		columns = append(columns, columnName)
+		switch kind {
+		case "partition_key":
+			partitionKey = append(partitionKey, keyColumn{name: columnName, position: position})
+		case "clustering":
+			clustering = append(clustering, keyColumn{name: columnName, position: position})
+			existing.ClusteringOrder[columnName] = clusteringOrder
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read columns")
+	}
+
+	sort.Slice(partitionKey, func(i, j int) bool { return partitionKey[i].position < partitionKey[j].position })
+	sort.Slice(clustering, func(i, j int) bool { return clustering[i].position < clustering[j].position })
+	for _, column := range partitionKey {
+		existing.PartitionKey = append(existing.PartitionKey, column.name)
+	}
+	for _, column := range clustering {
+		existing.Clustering = append(existing.Clustering, column.name)
+	}
+
+	return columns, &existing, nil
+}
+
+// readTableViews returns the names of the materialized views that select from a table
+func readTableViews(session *gocql.Session, keyspace string, tableName string) ([]string, error) {
+	query := `select view_name, base_table_name from system_schema.views where keyspace_name = ?`
+	iter := session.Query(query, keyspace).Iter()
+
+	views := []string{}
+	var viewName, baseTableName string
+	for iter.Scan(&viewName, &baseTableName) {
+		if baseTableName == tableName {
+			views = append(views, viewName)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to read materialized views")
+	}
+
+	return views, nil
+}
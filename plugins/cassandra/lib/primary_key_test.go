@@ -0,0 +1,150 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PrimaryKeyStatements(t *testing.T) {
+	isDescending := true
+
+	existing := &existingPrimaryKey{
+		PartitionKey:    []string{"account_id"},
+		Clustering:      []string{"created_at", "id"},
+		ClusteringOrder: map[string]string{"created_at": "desc", "id": "asc"},
+	}
+	existingColumns := []string{"account_id", "created_at", "id", "body"}
+	columns := []*schemasv1alpha4.CassandraColumn{
+		{Name: "account_id", Type: "uuid"},
+		{Name: "created_at", Type: "timestamp"},
+		{Name: "id", Type: "uuid"},
+		{Name: "body", Type: "text"},
+	}
+
+	tests := []struct {
+		name               string
+		tableSchema        *schemasv1alpha4.CassandraTableSchema
+		views              []string
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "unchanged",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey: [][]string{{"account_id"}, {"created_at"}, {"id"}},
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{Column: "created_at", IsDescending: &isDescending},
+				},
+				Columns: columns,
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "clustering order changed",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey: [][]string{{"account_id"}, {"created_at"}, {"id"}},
+				Columns:    columns,
+			},
+			wantErr: "the primary key of table t changed from ((account_id), created_at desc, id asc) to ((account_id), created_at asc, id asc), cassandra cannot alter a primary key. Set primaryKeyChange.destructive to drop and recreate the table and copy its rows, writes made while the rows are copied are lost",
+		},
+		{
+			name: "partition key changed with materialized views",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey:       [][]string{{"account_id", "created_at"}, {"id"}},
+				Columns:          columns,
+				PrimaryKeyChange: &schemasv1alpha4.CassandraPrimaryKeyChange{Destructive: true},
+			},
+			views:   []string{"t_by_id"},
+			wantErr: "the primary key of table t changed from ((account_id), created_at desc, id asc) to ((account_id, created_at), id asc), the table cannot be recreated while materialized views t_by_id select from it",
+		},
+		{
+			name: "partition key changed",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey: [][]string{{"account_id", "created_at"}, {"id"}},
+				Columns:    columns,
+				Indexes: []*schemasv1alpha4.CassandraTableIndex{
+					{Column: "body", Type: "SAI"},
+				},
+				PrimaryKeyChange: &schemasv1alpha4.CassandraPrimaryKeyChange{
+					Destructive: true,
+					ChunkSize:   500,
+				},
+			},
+			expectedStatements: []string{
+				`create table "_t_copy" (account_id uuid, created_at timestamp, id uuid, body text, primary key ((account_id, created_at), id))`,
+				`/* schemahero:online-copy table=t shadow=_t_copy chunkSize=500 throttleMilliseconds=100 */ select json account_id, created_at, id, body from "t"`,
+				types.WarningComment("table t is dropped and created again from its copy _t_copy, writes to t made while the rows are copied are lost") + "\n" +
+					`drop table "t"`,
+				`create table "t" (account_id uuid, created_at timestamp, id uuid, body text, primary key ((account_id, created_at), id))`,
+				`create custom index "t_body_idx" on "t" (body) using 'StorageAttachedIndex'`,
+				`/* schemahero:online-copy table=_t_copy shadow=t chunkSize=500 throttleMilliseconds=100 */ select json account_id, created_at, id, body from "_t_copy"`,
+				`drop table "_t_copy"`,
+			},
+		},
+		{
+			name: "partition key changed without destructive",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey:       [][]string{{"account_id", "created_at"}, {"id"}},
+				Columns:          columns,
+				PrimaryKeyChange: &schemasv1alpha4.CassandraPrimaryKeyChange{ChunkSize: 500},
+			},
+			wantErr: "the primary key of table t changed from ((account_id), created_at desc, id asc) to ((account_id, created_at), id asc), cassandra cannot alter a primary key. Set primaryKeyChange.destructive to drop and recreate the table and copy its rows, writes made while the rows are copied are lost",
+		},
+		{
+			name: "new primary key column",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey:       [][]string{{"account_id"}, {"day"}},
+				Columns:          append(columns, &schemasv1alpha4.CassandraColumn{Name: "day", Type: "date"}),
+				PrimaryKeyChange: &schemasv1alpha4.CassandraPrimaryKeyChange{Destructive: true},
+			},
+			wantErr: "primary key column day is not in table t, the rows of the table cannot be copied",
+		},
+		{
+			name: "clustering order out of primary key order",
+			tableSchema: &schemasv1alpha4.CassandraTableSchema{
+				PrimaryKey: [][]string{{"account_id"}, {"created_at"}, {"id"}},
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{Column: "id"},
+					{Column: "created_at", IsDescending: &isDescending},
+				},
+				Columns: columns,
+			},
+			wantErr: "table t orders by created_at out of primary key order",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := PrimaryKeyStatements("t", test.tableSchema, existing, existingColumns, test.views)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
+
+func Test_parseCopyStatement(t *testing.T) {
+	c, err := parseCopyStatement(`/* schemahero:online-copy table=t shadow=_t_copy chunkSize=500 throttleMilliseconds=0 */ select json a, b from "t";`)
+	require.NoError(t, err)
+	assert.Equal(t, tableCopy{
+		Table:                "t",
+		Shadow:               "_t_copy",
+		ChunkSize:            500,
+		ThrottleMilliseconds: 0,
+		Select:               `select json a, b from "t"`,
+	}, *c)
+
+	_, err = parseCopyStatement(`/* schemahero:online-copy table=t */ select json a from "t"`)
+	assert.Error(t, err)
+}
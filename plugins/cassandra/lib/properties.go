@@ -0,0 +1,277 @@
+package cassandra
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// durationPropertyRegexp matches the percentile and millisecond values of speculative_retry and additional_write_policy
+var durationPropertyRegexp = regexp.MustCompile(`^(?i)([0-9.]+)\s*(p|percentile|ms)$`)
+
+// declaredProperty is a property that is set on a table or materialized view
+type declaredProperty struct {
+	// Name is the cql name of the property
+	Name   string
+	Clause string
+	// Value is the declared value, a string, int, bool or map[string]string
+	Value interface{}
+}
+
+// cqlMap returns the map as a cql map with single quotes, keys are sorted for consistent output
+func cqlMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := []string{}
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("'%s':'%s'", k, m[k]))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(parts, ","))
+}
+
+// declaredTableProperties returns the properties that are set on a table or materialized view
+func declaredTableProperties(properties *schemasv1alpha4.CassandraTableProperties) []declaredProperty {
+	declared := []declaredProperty{}
+	if properties == nil {
+		return declared
+	}
+
+	add := func(name string, clause string, value interface{}) {
+		declared = append(declared, declaredProperty{Name: name, Clause: clause, Value: value})
+	}
+
+	if properties.BloomFilterFPChance != "" {
+		add("bloom_filter_fp_chance", fmt.Sprintf(`bloom_filter_fp_chance = %s`, properties.BloomFilterFPChance), properties.BloomFilterFPChance)
+	}
+	if properties.Caching != nil {
+		add("caching", fmt.Sprintf(`caching = %s`, cqlMap(properties.Caching)), properties.Caching)
+	}
+	if properties.Comment != "" {
+		add("comment", fmt.Sprintf(`comment = '%s'`, escapeString(properties.Comment)), properties.Comment)
+	}
+	if properties.Compaction != nil {
+		add("compaction", fmt.Sprintf(`compaction = %s`, cqlMap(properties.Compaction)), properties.Compaction)
+	}
+	if properties.Compression != nil {
+		add("compression", fmt.Sprintf(`compression = %s`, cqlMap(properties.Compression)), properties.Compression)
+	}
+	if properties.CRCCheckChance != "" {
+		add("crc_check_chance", fmt.Sprintf(`crc_check_chance = %s`, properties.CRCCheckChance), properties.CRCCheckChance)
+	}
+	if properties.DCLocalReadRepairChance != "" {
+		add("dclocal_read_repair_chance", fmt.Sprintf(`dclocal_read_repair_chance = %s`, properties.DCLocalReadRepairChance), properties.DCLocalReadRepairChance)
+	}
+	if properties.DefaultTTL != nil {
+		add("default_time_to_live", fmt.Sprintf(`default_time_to_live = %d`, *properties.DefaultTTL), *properties.DefaultTTL)
+	}
+	if properties.GCGraceSeconds != nil {
+		add("gc_grace_seconds", fmt.Sprintf(`gc_grace_seconds = %d`, *properties.GCGraceSeconds), *properties.GCGraceSeconds)
+	}
+	if properties.MaxIndexInterval != nil {
+		add("max_index_interval", fmt.Sprintf(`max_index_interval = %d`, *properties.MaxIndexInterval), *properties.MaxIndexInterval)
+	}
+	if properties.MemtableFlushPeriodMS != nil {
+		add("memtable_flush_period_in_ms", fmt.Sprintf(`memtable_flush_period_in_ms = %d`, *properties.MemtableFlushPeriodMS), *properties.MemtableFlushPeriodMS)
+	}
+	if properties.MinIndexInterval != nil {
+		add("min_index_interval", fmt.Sprintf(`min_index_interval = %d`, *properties.MinIndexInterval), *properties.MinIndexInterval)
+	}
+	if properties.ReadRepairChance != "" {
+		add("read_repair_chance", fmt.Sprintf(`read_repair_chance = %s`, properties.ReadRepairChance), properties.ReadRepairChance)
+	}
+	if properties.SpeculativeRetry != "" {
+		add("speculative_retry", fmt.Sprintf(`speculative_retry = '%s'`, properties.SpeculativeRetry), properties.SpeculativeRetry)
+	}
+	if properties.CDC != nil {
+		add("cdc", fmt.Sprintf(`cdc = %t`, *properties.CDC), *properties.CDC)
+	}
+	if properties.AdditionalWritePolicy != "" {
+		add("additional_write_policy", fmt.Sprintf(`additional_write_policy = '%s'`, properties.AdditionalWritePolicy), properties.AdditionalWritePolicy)
+	}
+	if properties.ReadRepair != "" {
+		add("read_repair", fmt.Sprintf(`read_repair = '%s'`, properties.ReadRepair), properties.ReadRepair)
+	}
+
+	return declared
+}
+
+// tablePropertyClauses returns the with clauses of the properties set on a table or materialized view
+func tablePropertyClauses(properties *schemasv1alpha4.CassandraTableProperties) []string {
+	clauses := []string{}
+	for _, property := range declaredTableProperties(properties) {
+		clauses = append(clauses, property.Clause)
+	}
+	return clauses
+}
+
+// normalizeDurationProperty returns a speculative_retry or additional_write_policy value for comparison,
+// cassandra 3 stores 99PERCENTILE where cassandra 4 stores 99p
+func normalizeDurationProperty(value string) string {
+	value = strings.TrimSpace(value)
+	matches := durationPropertyRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return strings.ToLower(value)
+	}
+
+	amount, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return strings.ToLower(value)
+	}
+	unit := strings.ToLower(matches[2])
+	if unit == "percentile" {
+		unit = "p"
+	}
+	return fmt.Sprintf("%s%s", strconv.FormatFloat(amount, 'f', -1, 64), unit)
+}
+
+func propertyFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return 0, errors.Errorf("unexpected float value %v", value)
+}
+
+func propertyInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	}
+	return 0, errors.Errorf("unexpected int value %v", value)
+}
+
+// propertyMapMatches compares the keys that are declared, cassandra adds the default options of a compaction
+// or compression class to the map, and stores the class with its package
+func propertyMapMatches(desired map[string]string, existing map[string]string) bool {
+	for key, value := range desired {
+		existingValue, ok := existing[key]
+		if !ok {
+			return false
+		}
+		if key == "class" {
+			if existingValue != value && !strings.HasSuffix(existingValue, "."+value) {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(existingValue, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// propertyMatches returns true when the existing value of a property is the same as the declared value
+func propertyMatches(property declaredProperty, existing interface{}) (bool, error) {
+	switch property.Name {
+	case "bloom_filter_fp_chance", "crc_check_chance", "dclocal_read_repair_chance", "read_repair_chance":
+		desired, err := propertyFloat(property.Value)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse %s", property.Name)
+		}
+		current, err := propertyFloat(existing)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read %s", property.Name)
+		}
+		return math.Abs(desired-current) < 1e-9, nil
+
+	case "default_time_to_live", "gc_grace_seconds", "max_index_interval", "memtable_flush_period_in_ms", "min_index_interval":
+		current, err := propertyInt(existing)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read %s", property.Name)
+		}
+		return current == int64(property.Value.(int)), nil
+
+	case "caching", "compaction", "compression":
+		current, ok := existing.(map[string]string)
+		if !ok {
+			return false, errors.Errorf("failed to read %s: unexpected map value %v", property.Name, existing)
+		}
+		return propertyMapMatches(property.Value.(map[string]string), current), nil
+
+	case "speculative_retry", "additional_write_policy":
+		current, ok := existing.(string)
+		if !ok {
+			return false, errors.Errorf("failed to read %s: unexpected value %v", property.Name, existing)
+		}
+		return normalizeDurationProperty(current) == normalizeDurationProperty(property.Value.(string)), nil
+
+	case "read_repair":
+		current, ok := existing.(string)
+		if !ok {
+			return false, errors.Errorf("failed to read %s: unexpected value %v", property.Name, existing)
+		}
+		return strings.EqualFold(current, property.Value.(string)), nil
+
+	case "cdc":
+		// cdc is null on tables that never set it
+		current, _ := existing.(bool)
+		return current == property.Value.(bool), nil
+	}
+
+	return existing == property.Value, nil
+}
+
+// PropertiesStatements returns the statement to alter the declared properties of a table or materialized view that
+// are different from the existing properties. Properties that are not declared are not changed.
+func PropertiesStatements(kind string, name string, properties *schemasv1alpha4.CassandraTableProperties, existing map[string]interface{}) ([]string, error) {
+	changed := []string{}
+	for _, property := range declaredTableProperties(properties) {
+		existingValue, ok := existing[property.Name]
+		if !ok {
+			return nil, errors.Errorf("%s %s sets %s, which is not supported by this version of cassandra", kind, name, property.Name)
+		}
+
+		matches, err := propertyMatches(property, existingValue)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			changed = append(changed, property.Clause)
+		}
+	}
+
+	if len(changed) == 0 {
+		return []string{}, nil
+	}
+
+	// Don't include keyspace in the name since it's already set in the session
+	return []string{
+		fmt.Sprintf(`alter %s "%s" with %s`, kind, name, strings.Join(changed, " AND ")),
+	}, nil
+}
+
+// readProperties returns the properties of a table or materialized view from system_schema.tables or system_schema.views.
+// All columns are read because the properties are different between cassandra versions.
+func readProperties(session *gocql.Session, keyspace string, kind string, name string) (map[string]interface{}, error) {
+	query := `select * from system_schema.tables where keyspace_name = ? and table_name = ?`
+	if kind == "materialized view" {
+		query = `select * from system_schema.views where keyspace_name = ? and view_name = ?`
+	}
+
+	existing := map[string]interface{}{}
+	if err := session.Query(query, keyspace, name).MapScan(existing); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s properties", kind)
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,101 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PropertiesStatements(t *testing.T) {
+	defaultTTL := 0
+	gcGraceSeconds := 864000
+	newGCGraceSeconds := 3600
+	cdc := true
+
+	existing := map[string]interface{}{
+		"bloom_filter_fp_chance":      0.01,
+		"caching":                     map[string]string{"keys": "ALL", "rows_per_partition": "NONE"},
+		"comment":                     "",
+		"compaction":                  map[string]string{"class": "org.apache.cassandra.db.compaction.SizeTieredCompactionStrategy", "max_threshold": "32", "min_threshold": "4"},
+		"compression":                 map[string]string{"chunk_length_in_kb": "16", "class": "org.apache.cassandra.io.compress.LZ4Compressor"},
+		"crc_check_chance":            1.0,
+		"default_time_to_live":        0,
+		"gc_grace_seconds":            864000,
+		"max_index_interval":          2048,
+		"memtable_flush_period_in_ms": 0,
+		"min_index_interval":          128,
+		"speculative_retry":           "99p",
+		"additional_write_policy":     "99p",
+		"read_repair":                 "BLOCKING",
+		"cdc":                         nil,
+	}
+
+	tests := []struct {
+		name               string
+		properties         *schemasv1alpha4.CassandraTableProperties
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "unchanged after normalization",
+			properties: &schemasv1alpha4.CassandraTableProperties{
+				BloomFilterFPChance:   "0.010",
+				Caching:               map[string]string{"keys": "all"},
+				Compaction:            map[string]string{"class": "SizeTieredCompactionStrategy"},
+				CRCCheckChance:        "1",
+				DefaultTTL:            &defaultTTL,
+				GCGraceSeconds:        &gcGraceSeconds,
+				SpeculativeRetry:      "99PERCENTILE",
+				AdditionalWritePolicy: "99.0p",
+				ReadRepair:            "blocking",
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "changed",
+			properties: &schemasv1alpha4.CassandraTableProperties{
+				Comment:          "events",
+				Compaction:       map[string]string{"class": "TimeWindowCompactionStrategy", "compaction_window_unit": "DAYS"},
+				GCGraceSeconds:   &newGCGraceSeconds,
+				SpeculativeRetry: "10ms",
+				CDC:              &cdc,
+				ReadRepair:       "NONE",
+			},
+			expectedStatements: []string{
+				`alter table "t" with comment = 'events' AND compaction = {'class':'TimeWindowCompactionStrategy','compaction_window_unit':'DAYS'} AND gc_grace_seconds = 3600 AND speculative_retry = '10ms' AND cdc = true AND read_repair = 'NONE'`,
+			},
+		},
+		{
+			name: "removed property",
+			properties: &schemasv1alpha4.CassandraTableProperties{
+				ReadRepairChance: "0.1",
+			},
+			wantErr: "table t sets read_repair_chance, which is not supported by this version of cassandra",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := PropertiesStatements("table", "t", test.properties, existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
+
+func Test_normalizeDurationProperty(t *testing.T) {
+	assert.Equal(t, "99p", normalizeDurationProperty("99PERCENTILE"))
+	assert.Equal(t, "99.9p", normalizeDurationProperty("99.90p"))
+	assert.Equal(t, "10ms", normalizeDurationProperty("10.0ms"))
+	assert.Equal(t, "always", normalizeDurationProperty("ALWAYS"))
+}
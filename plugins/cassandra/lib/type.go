@@ -2,10 +2,23 @@ package cassandra
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
+// typeNameRegexp matches the names in a cql type, such as address in list<frozen<address>>
+var typeNameRegexp = regexp.MustCompile(`"[^"]+"|[\w.]+`)
+
+// existingType is a user defined type read from system_schema.types
+type existingType struct {
+	FieldNames []string
+	FieldTypes []string
+}
+
 func cassandraTypeAsInsert(field *schemasv1alpha4.CassandraField) (string, error) {
 	// TODO before merge!  find the right gocql sanitize methods to call
 
@@ -13,3 +26,142 @@ func cassandraTypeAsInsert(field *schemasv1alpha4.CassandraField) (string, error
 
 	return result, nil
 }
+
+// normalizeFieldType returns the type of a field for comparison, cassandra stores types in lower case without spaces
+func normalizeFieldType(fieldType string) string {
+	return strings.ToLower(strings.Join(strings.Fields(fieldType), ""))
+}
+
+// TypeStatements returns the statements to move a user defined type from the existing type to the schema.
+// Cassandra can add and rename the fields of a type, but it cannot drop a field or change its type.
+// A type that is still used by a column or another type is not dropped.
+func TypeStatements(typeName string, typeSchema *schemasv1alpha4.CassandraDataTypeSchema, existing *existingType, dependents []string) ([]string, error) {
+	if typeSchema.IsDeleted {
+		if existing == nil {
+			return []string{}, nil
+		}
+		if len(dependents) > 0 {
+			return nil, errors.Errorf("type %s cannot be dropped because it is used by %s", typeName, strings.Join(dependents, ", "))
+		}
+		return []string{
+			fmt.Sprintf(`drop type "%s"`, typeName),
+		}, nil
+	}
+
+	if existing == nil {
+		statement, err := CreateTypeStatement("", typeName, typeSchema)
+		if err != nil {
+			return nil, err
+		}
+		return []string{statement}, nil
+	}
+
+	existingFields := map[string]string{}
+	for i, fieldName := range existing.FieldNames {
+		if i < len(existing.FieldTypes) {
+			existingFields[fieldName] = existing.FieldTypes[i]
+		}
+	}
+
+	statements := []string{}
+	matched := map[string]bool{}
+	for _, field := range typeSchema.Fields {
+		existingName := field.Name
+		existingFieldType, ok := existingFields[field.Name]
+		if !ok && field.RenamedFrom != "" {
+			existingName = field.RenamedFrom
+			existingFieldType, ok = existingFields[field.RenamedFrom]
+		}
+
+		if !ok {
+			fieldDefinition, err := cassandraTypeAsInsert(field)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, fmt.Sprintf(`alter type "%s" add %s`, typeName, fieldDefinition))
+			continue
+		}
+
+		if matched[existingName] {
+			return nil, errors.Errorf("field %s of type %s is declared more than once", existingName, typeName)
+		}
+		matched[existingName] = true
+
+		if normalizeFieldType(existingFieldType) != normalizeFieldType(field.Type) {
+			return nil, errors.Errorf("cannot change field %s of type %s from %s to %s, cassandra cannot alter the type of a field", field.Name, typeName, existingFieldType, field.Type)
+		}
+
+		if existingName != field.Name {
+			statements = append(statements, fmt.Sprintf(`alter type "%s" rename %s to %s`, typeName, existingName, field.Name))
+		}
+	}
+
+	for _, fieldName := range existing.FieldNames {
+		if !matched[fieldName] {
+			return nil, errors.Errorf("cannot remove field %s from type %s, cassandra cannot drop the fields of a type", fieldName, typeName)
+		}
+	}
+
+	return statements, nil
+}
+
+// typeReferences returns true when the cql type uses the user defined type
+func typeReferences(cqlType string, typeName string) bool {
+	for _, name := range typeNameRegexp.FindAllString(cqlType, -1) {
+		if strings.Trim(name, `"`) == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// readType returns the user defined type from system_schema.types, or nil when it does not exist
+func readType(session *gocql.Session, keyspace string, typeName string) (*existingType, error) {
+	existing := existingType{}
+	query := `select field_names, field_types from system_schema.types where keyspace_name = ? and type_name = ?`
+	if err := session.Query(query, keyspace, typeName).Scan(&existing.FieldNames, &existing.FieldTypes); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read type")
+	}
+
+	return &existing, nil
+}
+
+// readTypeDependents returns the columns and other types in the keyspace that use the user defined type
+func readTypeDependents(session *gocql.Session, keyspace string, typeName string) ([]string, error) {
+	dependents := []string{}
+
+	iter := session.Query(`select table_name, column_name, type from system_schema.columns where keyspace_name = ?`, keyspace).Iter()
+	var tableName, columnName, columnType string
+	for iter.Scan(&tableName, &columnName, &columnType) {
+		if typeReferences(columnType, typeName) {
+			dependents = append(dependents, fmt.Sprintf("column %s.%s", tableName, columnName))
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to read columns")
+	}
+
+	iter = session.Query(`select type_name, field_types from system_schema.types where keyspace_name = ?`, keyspace).Iter()
+	var otherTypeName string
+	var fieldTypes []string
+	for iter.Scan(&otherTypeName, &fieldTypes) {
+		if otherTypeName == typeName {
+			continue
+		}
+		for _, fieldType := range fieldTypes {
+			if typeReferences(fieldType, typeName) {
+				dependents = append(dependents, fmt.Sprintf("type %s", otherTypeName))
+				break
+			}
+		}
+		fieldTypes = nil
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to read types")
+	}
+
+	return dependents, nil
+}
@@ -0,0 +1,128 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TypeStatements(t *testing.T) {
+	existing := &existingType{
+		FieldNames: []string{"street", "zip"},
+		FieldTypes: []string{"text", "int"},
+	}
+
+	tests := []struct {
+		name               string
+		typeSchema         *schemasv1alpha4.CassandraDataTypeSchema
+		existing           *existingType
+		dependents         []string
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "create",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				Fields: []*schemasv1alpha4.CassandraField{
+					{Name: "street", Type: "text"},
+					{Name: "zip", Type: "int"},
+				},
+			},
+			expectedStatements: []string{
+				`create type "address" (street text, zip int)`,
+			},
+		},
+		{
+			name: "unchanged",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				Fields: []*schemasv1alpha4.CassandraField{
+					{Name: "street", Type: "text"},
+					{Name: "zip", Type: "int"},
+				},
+			},
+			existing:           existing,
+			expectedStatements: []string{},
+		},
+		{
+			name: "add and rename fields",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				Fields: []*schemasv1alpha4.CassandraField{
+					{Name: "street", Type: "text"},
+					{Name: "postal_code", Type: "int", RenamedFrom: "zip"},
+					{Name: "tags", Type: "set<text>"},
+				},
+			},
+			existing: existing,
+			expectedStatements: []string{
+				`alter type "address" rename zip to postal_code`,
+				`alter type "address" add tags set<text>`,
+			},
+		},
+		{
+			name: "change the type of a field",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				Fields: []*schemasv1alpha4.CassandraField{
+					{Name: "street", Type: "text"},
+					{Name: "zip", Type: "text"},
+				},
+			},
+			existing: existing,
+			wantErr:  "cannot change field zip of type address from int to text, cassandra cannot alter the type of a field",
+		},
+		{
+			name: "remove a field",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				Fields: []*schemasv1alpha4.CassandraField{
+					{Name: "street", Type: "text"},
+				},
+			},
+			existing: existing,
+			wantErr:  "cannot remove field zip from type address, cassandra cannot drop the fields of a type",
+		},
+		{
+			name: "drop",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				IsDeleted: true,
+			},
+			existing: existing,
+			expectedStatements: []string{
+				`drop type "address"`,
+			},
+		},
+		{
+			name: "drop a type that is in use",
+			typeSchema: &schemasv1alpha4.CassandraDataTypeSchema{
+				IsDeleted: true,
+			},
+			existing:   existing,
+			dependents: []string{"column users.home", "type contact"},
+			wantErr:    "type address cannot be dropped because it is used by column users.home, type contact",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := TypeStatements("address", test.typeSchema, test.existing, test.dependents)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
+
+func Test_typeReferences(t *testing.T) {
+	assert.True(t, typeReferences("address", "address"))
+	assert.True(t, typeReferences("list<frozen<address>>", "address"))
+	assert.True(t, typeReferences(`map<text, frozen<"address">>`, "address"))
+	assert.False(t, typeReferences("frozen<home_address>", "address"))
+	assert.False(t, typeReferences("text", "address"))
+}
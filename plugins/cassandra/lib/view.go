@@ -0,0 +1,195 @@
+package cassandra
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var whitespaceRegexp = regexp.MustCompile(`\s+`)
+
+// existingView is a materialized view read from system_schema.views and system_schema.columns
+type existingView struct {
+	Table             string
+	Where             string
+	IncludeAllColumns bool
+	Columns           []string
+	PrimaryKey        existingPrimaryKey
+	// Properties are all columns of the view in system_schema.views
+	Properties map[string]interface{}
+}
+
+func validateView(viewName string, viewSchema *schemasv1alpha4.CassandraViewSchema) error {
+	if viewSchema.Table == "" {
+		return errors.Errorf("materialized view %s requires a table", viewName)
+	}
+	if len(viewSchema.PrimaryKey) == 0 || len(viewSchema.PrimaryKey[0]) == 0 {
+		return errors.Errorf("materialized view %s requires a primary key", viewName)
+	}
+
+	return validateClusteringOrder("materialized view", viewName, viewSchema.PrimaryKey, viewSchema.ClusteringOrder)
+}
+
+// viewWhereClause returns the where clause of the view, every primary key column must be restricted to not null
+// so the columns that are not already restricted in the schema are added
+func viewWhereClause(viewSchema *schemasv1alpha4.CassandraViewSchema) string {
+	conditions := []string{}
+	for _, key := range viewSchema.PrimaryKey {
+		for _, column := range key {
+			notNull := regexp.MustCompile(fmt.Sprintf(`(?i)(^|[^\w])"?%s"?\s+is\s+not\s+null`, regexp.QuoteMeta(column)))
+			if notNull.MatchString(viewSchema.Where) {
+				continue
+			}
+			conditions = append(conditions, fmt.Sprintf("%s is not null", column))
+		}
+	}
+
+	if strings.TrimSpace(viewSchema.Where) != "" {
+		conditions = append(conditions, strings.TrimSpace(viewSchema.Where))
+	}
+
+	return strings.Join(conditions, " and ")
+}
+
+// normalizeWhereClause returns the where clause for comparison, cassandra stores it with upper case keywords
+func normalizeWhereClause(where string) string {
+	where = strings.ReplaceAll(strings.ToLower(where), `"`, "")
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(where, " "))
+}
+
+// CreateViewStatement returns the statement to create a materialized view
+func CreateViewStatement(viewName string, viewSchema *schemasv1alpha4.CassandraViewSchema) (string, error) {
+	if err := validateView(viewName, viewSchema); err != nil {
+		return "", err
+	}
+
+	columns := "*"
+	if len(viewSchema.Columns) > 0 {
+		columns = strings.Join(viewSchema.Columns, ", ")
+	}
+
+	query := fmt.Sprintf(`create materialized view "%s" as select %s from "%s" where %s %s`,
+		viewName, columns, viewSchema.Table, viewWhereClause(viewSchema), primaryKeyClause(viewSchema.PrimaryKey))
+
+	viewProperties := []string{}
+	if len(viewSchema.ClusteringOrder) > 0 {
+		order := clusteringOrderByColumn(viewSchema.PrimaryKey, viewSchema.ClusteringOrder)
+		clustering := []string{}
+		for _, column := range clusteringColumns(viewSchema.PrimaryKey) {
+			clustering = append(clustering, fmt.Sprintf("%s %s", column, order[column]))
+		}
+		viewProperties = append(viewProperties, fmt.Sprintf("clustering order by (%s)", strings.Join(clustering, ", ")))
+	}
+	viewProperties = append(viewProperties, tablePropertyClauses(viewSchema.Properties)...)
+
+	if len(viewProperties) > 0 {
+		query = fmt.Sprintf("%s with %s", query, strings.Join(viewProperties, " AND "))
+	}
+
+	return query, nil
+}
+
+// viewChanged returns true when the definition of the existing view is different from the schema
+func viewChanged(viewSchema *schemasv1alpha4.CassandraViewSchema, existing *existingView) bool {
+	if existing.Table != viewSchema.Table {
+		return true
+	}
+
+	if len(viewSchema.Columns) == 0 {
+		if !existing.IncludeAllColumns {
+			return true
+		}
+	} else {
+		if existing.IncludeAllColumns {
+			return true
+		}
+
+		// the primary key columns are always part of the view
+		desired := map[string]bool{}
+		for _, column := range viewSchema.Columns {
+			desired[column] = true
+		}
+		for _, key := range viewSchema.PrimaryKey {
+			for _, column := range key {
+				desired[column] = true
+			}
+		}
+		if len(desired) != len(existing.Columns) {
+			return true
+		}
+		for _, column := range existing.Columns {
+			if !desired[column] {
+				return true
+			}
+		}
+	}
+
+	if primaryKeyChanged(viewSchema.PrimaryKey, viewSchema.ClusteringOrder, &existing.PrimaryKey) {
+		return true
+	}
+
+	return normalizeWhereClause(viewWhereClause(viewSchema)) != normalizeWhereClause(existing.Where)
+}
+
+// ViewStatements returns the statements to move the materialized view from the existing state to the schema.
+// Cassandra cannot alter the definition of a materialized view, so a changed view is dropped and created again,
+// and only the properties of an unchanged view are altered.
+func ViewStatements(viewName string, viewSchema *schemasv1alpha4.CassandraViewSchema, existing *existingView) ([]string, error) {
+	if viewSchema.IsDeleted {
+		if existing == nil {
+			return []string{}, nil
+		}
+		return []string{
+			fmt.Sprintf(`drop materialized view "%s"`, viewName),
+		}, nil
+	}
+
+	statement, err := CreateViewStatement(viewName, viewSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return []string{statement}, nil
+	}
+
+	if !viewChanged(viewSchema, existing) {
+		return PropertiesStatements("materialized view", viewName, viewSchema.Properties, existing.Properties)
+	}
+
+	return []string{
+		fmt.Sprintf(`drop materialized view "%s"`, viewName),
+		statement,
+	}, nil
+}
+
+// readView returns the materialized view from system_schema.views, or nil when it does not exist
+func readView(session *gocql.Session, keyspace string, viewName string) (*existingView, error) {
+	properties, err := readProperties(session, keyspace, "materialized view", viewName)
+	if err != nil {
+		if errors.Cause(err) == gocql.ErrNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read materialized view")
+	}
+
+	existing := existingView{
+		Properties: properties,
+	}
+	existing.Table, _ = properties["base_table_name"].(string)
+	existing.Where, _ = properties["where_clause"].(string)
+	existing.IncludeAllColumns, _ = properties["include_all_columns"].(bool)
+
+	columns, primaryKey, err := readPrimaryKey(session, keyspace, viewName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read materialized view columns")
+	}
+	existing.Columns = columns
+	existing.PrimaryKey = *primaryKey
+
+	return &existing, nil
+}
@@ -0,0 +1,126 @@
+package cassandra
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ViewStatements(t *testing.T) {
+	isDescending := true
+	gcGraceSeconds := 3600
+
+	usersByEmail := &schemasv1alpha4.CassandraViewSchema{
+		Table:      "users",
+		Columns:    []string{"name"},
+		PrimaryKey: [][]string{{"email"}, {"id"}},
+	}
+
+	tests := []struct {
+		name               string
+		viewSchema         *schemasv1alpha4.CassandraViewSchema
+		existing           *existingView
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name:       "create",
+			viewSchema: usersByEmail,
+			expectedStatements: []string{
+				`create materialized view "v" as select name from "users" where email is not null and id is not null primary key (email, id)`,
+			},
+		},
+		{
+			name: "create with all columns, where, clustering order and properties",
+			viewSchema: &schemasv1alpha4.CassandraViewSchema{
+				Table:      "events",
+				Where:      "kind = 'login' and device_id IS NOT NULL",
+				PrimaryKey: [][]string{{"account_id", "day"}, {"created_at"}, {"device_id"}},
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{Column: "created_at", IsDescending: &isDescending},
+				},
+				Properties: &schemasv1alpha4.CassandraTableProperties{
+					GCGraceSeconds: &gcGraceSeconds,
+				},
+			},
+			expectedStatements: []string{
+				`create materialized view "v" as select * from "events" where account_id is not null and day is not null and created_at is not null and kind = 'login' and device_id IS NOT NULL primary key ((account_id, day), created_at, device_id) with clustering order by (created_at desc, device_id asc) AND gc_grace_seconds = 3600`,
+			},
+		},
+		{
+			name:       "unchanged",
+			viewSchema: usersByEmail,
+			existing: &existingView{
+				Table:   "users",
+				Where:   "email IS NOT NULL AND id IS NOT NULL",
+				Columns: []string{"email", "id", "name"},
+				PrimaryKey: existingPrimaryKey{
+					PartitionKey:    []string{"email"},
+					Clustering:      []string{"id"},
+					ClusteringOrder: map[string]string{"id": "asc"},
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:       "changed columns",
+			viewSchema: usersByEmail,
+			existing: &existingView{
+				Table:             "users",
+				Where:             "email IS NOT NULL AND id IS NOT NULL",
+				IncludeAllColumns: true,
+				Columns:           []string{"email", "id", "name", "age"},
+				PrimaryKey: existingPrimaryKey{
+					PartitionKey:    []string{"email"},
+					Clustering:      []string{"id"},
+					ClusteringOrder: map[string]string{"id": "asc"},
+				},
+			},
+			expectedStatements: []string{
+				`drop materialized view "v"`,
+				`create materialized view "v" as select name from "users" where email is not null and id is not null primary key (email, id)`,
+			},
+		},
+		{
+			name: "deleted",
+			viewSchema: &schemasv1alpha4.CassandraViewSchema{
+				IsDeleted: true,
+			},
+			existing: &existingView{
+				Table: "users",
+			},
+			expectedStatements: []string{
+				`drop materialized view "v"`,
+			},
+		},
+		{
+			name: "clustering order on a partition key column",
+			viewSchema: &schemasv1alpha4.CassandraViewSchema{
+				Table:      "users",
+				PrimaryKey: [][]string{{"email"}, {"id"}},
+				ClusteringOrder: []*schemasv1alpha4.CassandraClusteringOrder{
+					{Column: "email", IsDescending: &isDescending},
+				},
+			},
+			wantErr: "materialized view v orders by email, which is not a clustering column",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := ViewStatements("v", test.viewSchema, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
@@ -1,11 +1,22 @@
 package mysql
 
 import (
+	"strings"
+
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
 func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumns []*schemasv1alpha4.MysqlTableColumn, existingColumn *types.Column, defaultCharset string, defaultCollation string) ([]string, error) {
+	statements, err := alterColumnDDLStatements(tableName, primaryKeys, desiredColumns, existingColumn, defaultCharset, defaultCollation)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyOnlineDDL(nil, statements), nil
+}
+
+func alterColumnDDLStatements(tableName string, primaryKeys []string, desiredColumns []*schemasv1alpha4.MysqlTableColumn, existingColumn *types.Column, defaultCharset string, defaultCollation string) ([]ddlStatement, error) {
 	// this could be an alter or a drop column command
 	for _, desiredColumn := range desiredColumns {
 		if desiredColumn.Name == existingColumn.Name {
@@ -27,22 +38,32 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 			}
 
 			if columnsMatch(*existingColumn, *column, defaultCharset, defaultCollation) {
-				return []string{}, nil
+				return []ddlStatement{}, nil
 			}
 
-			return AlterModifyColumnStatement{
+			change := modifyColumnChange(*existingColumn, *column, defaultCharset)
+			statements := []ddlStatement{}
+			for _, statement := range (AlterModifyColumnStatement{
 				TableName:      tableName,
 				ExistingColumn: *existingColumn,
 				Column:         *column,
-			}.DDL(), nil
+			}.DDL()) {
+				// the update that fills in a default before adding not null is not an alter statement
+				if strings.HasPrefix(statement, "update ") {
+					statements = append(statements, ddlStatement{SQL: statement})
+					continue
+				}
+				statements = append(statements, ddlStatement{SQL: statement, Change: change})
+			}
+			return statements, nil
 		}
 	}
 
 	// wasn't found as a desired column, so drop
-	return AlterDropColumnStatement{
+	return ddlStatements(ddlChangeDropColumn, AlterDropColumnStatement{
 		TableName: tableName,
 		Column:    types.Column{Name: existingColumn.Name},
-	}.DDL(), nil
+	}.DDL()), nil
 }
 
 func columnsMatch(existingCol types.Column, specCol types.Column, defaultCharset string, defaultCollation string) bool {
@@ -79,6 +100,17 @@ func columnsMatch(existingCol types.Column, specCol types.Column, defaultCharset
 		return false
 	}
 
+	// a comment that is not in the spec is not managed
+	if specCol.Comment != nil {
+		existingComment := ""
+		if existingCol.Comment != nil {
+			existingComment = *existingCol.Comment
+		}
+		if existingComment != *specCol.Comment {
+			return false
+		}
+	}
+
 	col1Constraints, col2Constraints := existingCol.Constraints, specCol.Constraints
 	if col1Constraints == nil {
 		col1Constraints = &types.ColumnConstraints{}
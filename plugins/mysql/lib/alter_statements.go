@@ -84,6 +84,15 @@ func (s AlterModifyColumnStatement) ddl(useConstraintsFromExistingColumn bool) [
 		stmts = append(stmts, fmt.Sprintf("default \"%s\"", *s.Column.ColumnDefault))
 	}
 
+	// modify column replaces the entire column definition, so an unmanaged comment has to be carried over
+	comment := s.Column.Comment
+	if comment == nil {
+		comment = s.ExistingColumn.Comment
+	}
+	if comment != nil && *comment != "" {
+		stmts = append(stmts, fmt.Sprintf("comment %s", stringLiteral(*comment)))
+	}
+
 	return []string{strings.Join(stmts, " ")}
 }
 
@@ -13,6 +13,8 @@ import (
 func Test_AlterColumnStatment(t *testing.T) {
 	defaultEleven := "11"
 	defaultEmpty := ""
+	oldComment := "old"
+	newComment := "it's new"
 
 	tests := []struct {
 		name               string
@@ -332,6 +334,59 @@ func Test_AlterColumnStatment(t *testing.T) {
 				"alter table `t` modify column `c` int (11) not null",
 			},
 		},
+		{
+			name:      "change comment",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name:    "c",
+					Type:    "integer",
+					Comment: &newComment,
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "c",
+				DataType: "int (11)",
+				Comment:  &oldComment,
+			},
+			expectedStatements: []string{
+				"alter table `t` modify column `c` int (11) comment 'it''s new'",
+			},
+		},
+		{
+			name:      "comment not in spec is not changed",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name: "c",
+					Type: "integer",
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "c",
+				DataType: "int (11)",
+				Comment:  &oldComment,
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:      "type change keeps comment not in spec",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name: "c",
+					Type: "integer",
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "c",
+				DataType: "varchar (255)",
+				Comment:  &oldComment,
+			},
+			expectedStatements: []string{
+				"alter table `t` modify column `c` int (11) comment 'old'",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -13,6 +13,7 @@ func schemaColumnToColumn(schemaColumn *schemasv1alpha4.MysqlTableColumn) (*type
 		ColumnDefault: schemaColumn.Default,
 		Charset:       schemaColumn.Charset,
 		Collation:     schemaColumn.Collation,
+		Comment:       schemaColumn.Comment,
 	}
 
 	if schemaColumn.Constraints != nil {
@@ -99,6 +100,10 @@ func mysqlColumnAsInsert(column *schemasv1alpha4.MysqlTableColumn) (string, erro
 		}
 	}
 
+	if mysqlColumn.Comment != nil && *mysqlColumn.Comment != "" {
+		formatted = fmt.Sprintf("%s comment %s", formatted, stringLiteral(*mysqlColumn.Comment))
+	}
+
 	return formatted, nil
 }
 
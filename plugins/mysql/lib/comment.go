@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// TableCommentStatement returns the statement to set the comment on a table.
+// An empty comment removes the existing comment.
+func TableCommentStatement(tableName string, comment string) string {
+	return fmt.Sprintf("alter table `%s` comment = %s", tableName, stringLiteral(comment))
+}
+
+func stringLiteral(s string) string {
+	escaped := strings.ReplaceAll(s, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `''`)
+	return fmt.Sprintf("'%s'", escaped)
+}
+
+// buildTableCommentStatements will return the statements needed to
+// change the comment on a table. Comments that are not set in the schema are not managed.
+func buildTableCommentStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Comment == nil {
+		return []string{}, nil
+	}
+
+	query := `select TABLE_COMMENT from information_schema.TABLES where TABLE_SCHEMA = ? and TABLE_NAME = ?`
+	row := m.db.QueryRow(query, m.databaseName, tableName)
+
+	var existingComment string
+	if err := row.Scan(&existingComment); err != nil {
+		return nil, errors.Wrap(err, "failed to read existing table comment")
+	}
+
+	if existingComment == *mysqlTableSchema.Comment {
+		return []string{}, nil
+	}
+
+	return []string{
+		TableCommentStatement(tableName, *mysqlTableSchema.Comment),
+	}, nil
+}
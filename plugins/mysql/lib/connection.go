@@ -8,6 +8,7 @@ import (
 	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
 type MysqlConnection struct {
@@ -97,8 +98,12 @@ func (m *MysqlConnection) PlanViewSchema(viewName string, viewSchema interface{}
 
 // PlanFunctionSchema generates SQL statements to create or update a function
 func (m *MysqlConnection) PlanFunctionSchema(functionName string, functionSchema interface{}) ([]string, error) {
-	// MySQL functions not yet implemented
-	return nil, errors.New("MySQL function planning not yet implemented")
+	mysqlFunction, ok := functionSchema.(*schemasv1alpha4.MysqlFunctionSchema)
+	if !ok {
+		return nil, errors.New("functionSchema must be *MysqlFunctionSchema")
+	}
+
+	return PlanMysqlFunction(m.uri, functionName, mysqlFunction)
 }
 
 // PlanExtensionSchema generates SQL statements to manage database extensions
@@ -107,11 +112,36 @@ func (m *MysqlConnection) PlanExtensionSchema(extensionName string, extensionSch
 	return nil, errors.New("MySQL does not support extensions")
 }
 
+// PlanGrantSchema generates SQL statements to grant or revoke privileges on an object
+func (m *MysqlConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	mysqlGrant, ok := grantSchema.(*schemasv1alpha4.MysqlGrantSchema)
+	if !ok {
+		return nil, errors.New("grantSchema must be *MysqlGrantSchema")
+	}
+
+	return PlanMysqlGrant(m.uri, mysqlGrant)
+}
+
+// PlanDatabaseSchema - MySQL schemas are databases, which are not managed
+func (m *MysqlConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	return nil, errors.New("mysql does not support managed schemas")
+}
+
+// PlanSequenceSchema - MySQL does not have sequences
+func (m *MysqlConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	return nil, errors.New("mysql does not support sequences")
+}
+
 // DeployStatements executes a list of SQL statements
 func (m *MysqlConnection) DeployStatements(statements []string) error {
 	return DeployMysqlStatements(m.uri, statements)
 }
 
+// GetOnlineCopyProgress returns the progress of the last shadow table copy of the table
+func (m *MysqlConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	return getOnlineCopyProgress(m, tableName)
+}
+
 func (m *MysqlConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error) {
 	if spec.Schema == nil || spec.Schema.Mysql == nil {
 		return []string{}, nil
@@ -130,7 +160,7 @@ func (m *MysqlConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]s
 
 	// Add seed data if present
 	if spec.SeedData != nil {
-		seedStatements, err := SeedDataStatements(spec.Name, spec.SeedData)
+		seedStatements, err := SeedDataStatements(spec.Name, spec.Schema.Mysql.PrimaryKey, spec.SeedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create seed data statements")
 		}
@@ -1,55 +1,189 @@
 package mysql
 
 import (
+	"database/sql"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func SeedDataStatements(tableName string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func SeedDataStatements(tableName string, primaryKey []string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, primaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
+
 	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		keys, err := types.SeedDataKeysCondition(rows, primaryKey)
+		if err != nil {
+			return nil, err
+		}
+		if keys == "" {
+			statements = append(statements, fmt.Sprintf(`delete from %s`, tableName))
+		} else {
+			statements = append(statements, fmt.Sprintf(`delete from %s where not (%s)`, tableName, keys))
+		}
+	}
 
-	for _, row := range seedData.Rows {
-		cols := []string{}
-		vals := []string{}
-		updateVals := []string{}
-		for _, col := range row.Columns {
-			cols = append(cols, col.Column)
-			if col.Value.Int != nil {
-				vals = append(vals, strconv.Itoa(*col.Value.Int))
-				updateVals = append(updateVals, fmt.Sprintf("%s=%s", col.Column, strconv.Itoa(*col.Value.Int)))
-			} else if col.Value.Str != nil {
-				// handle multiline strings
-				if strings.Contains(*col.Value.Str, "\n") {
-					builder := []string{
-						"CONCAT_WS(CHAR(10 using utf8)",
-					}
-					for _, s := range strings.Split(*col.Value.Str, "\n") {
-						builder = append(builder, fmt.Sprintf("'%s'", s))
-					}
-					vals = append(vals, fmt.Sprintf("%s)", strings.Join(builder, ", ")))
-					updateVals = append(updateVals, fmt.Sprintf("%s=%s", col.Column, fmt.Sprintf("%s)", strings.Join(builder, ", "))))
-				} else {
-					vals = append(vals, fmt.Sprintf("'%s'", *col.Value.Str))
-					updateVals = append(updateVals, fmt.Sprintf("%s=%s", col.Column, fmt.Sprintf("'%s'", *col.Value.Str)))
-				}
-			} else {
-				// If neither Int nor Str is set (e.g., str: "" becomes nil due to omitempty),
-				// treat it as an empty string
-				vals = append(vals, "''")
-				updateVals = append(updateVals, fmt.Sprintf("%s=''", col.Column))
+	for _, row := range rows {
+		var updateVals []string
+		switch {
+		case seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly:
+			updateVals = []string{}
+		case types.SeedDataReadsTable(seedData):
+			updateVals = row.Assignments(primaryKey)
+		default:
+			updateVals = []string{}
+			for i, col := range row.Columns {
+				updateVals = append(updateVals, fmt.Sprintf("%s=%s", col, row.Values[i]))
 			}
 		}
+		if len(updateVals) == 0 {
+			// a row that is already in the table is not changed
+			updateVals = []string{fmt.Sprintf("%s = %s", primaryKey[0], primaryKey[0])}
+		}
 
-		statement := fmt.Sprintf(`insert into %s (%s) values (%s) on duplicate key update %s`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "), strings.Join(updateVals, ", "))
+		statement := fmt.Sprintf(`insert into %s (%s) values (%s) on duplicate key update %s`, tableName, strings.Join(row.Columns, ", "), strings.Join(row.Values, ", "), strings.Join(updateVals, ", "))
 		statements = append(statements, statement)
 	}
 
 	return statements, nil
 }
 
+// SeedDataDiffStatements plans the seed data from the rows in the table, so that there is a statement for each
+// row that is inserted, updated or deleted
+func SeedDataDiffStatements(m *MysqlConnection, tableName string, primaryKey []string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, primaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		deleteStatements, err := seedDataDeleteStatements(m, tableName, primaryKey, rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan seed data deletes")
+		}
+		statements = append(statements, deleteStatements...)
+	}
+
+	for _, row := range rows {
+		where, err := row.Condition(primaryKey, "=")
+		if err != nil {
+			return nil, err
+		}
+		same, err := row.Condition(row.Columns, "<=>")
+		if err != nil {
+			return nil, err
+		}
+
+		isSame := false
+		err = m.db.QueryRow(fmt.Sprintf(`select %s from %s where %s`, same, tableName, where)).Scan(&isSame)
+		if err == sql.ErrNoRows {
+			statements = append(statements, fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(row.Columns, ", "), strings.Join(row.Values, ", ")))
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read seed data row where %s", where)
+		}
+
+		if isSame || seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf(`update %s set %s where %s`, tableName, strings.Join(row.Assignments(primaryKey), ", "), where))
+	}
+
+	return statements, nil
+}
+
+// seedDataDeleteStatements returns a delete statement for each row of the table that is not in the seed data
+func seedDataDeleteStatements(m *MysqlConnection, tableName string, primaryKey []string, rows []types.SeedDataRowLiterals) ([]string, error) {
+	columns := []string{}
+	for _, column := range primaryKey {
+		columns = append(columns, fmt.Sprintf("cast(%s as char)", column))
+	}
+
+	query := fmt.Sprintf(`select %s from %s`, strings.Join(columns, ", "), tableName)
+	keys, err := types.SeedDataKeysCondition(rows, primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	if keys != "" {
+		query = fmt.Sprintf(`%s where not (%s)`, query, keys)
+	}
+	query = fmt.Sprintf(`%s order by %s`, query, strings.Join(primaryKey, ", "))
+
+	result, err := m.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	statements := []string{}
+	for result.Next() {
+		values := make([]string, len(primaryKey))
+		dest := []interface{}{}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := result.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		conditions := []string{}
+		for i, column := range primaryKey {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", column, mysqlString(values[i])))
+		}
+		statements = append(statements, fmt.Sprintf(`delete from %s where %s`, tableName, strings.Join(conditions, " and ")))
+	}
+
+	return statements, result.Err()
+}
+
+// seedDataLiterals are the literals of seed data values
+var seedDataLiterals = types.SeedDataLiterals{
+	String: mysqlString,
+	Bool:   strconv.FormatBool,
+	Bytes: func(b []byte) string {
+		return fmt.Sprintf("x'%x'", b)
+	},
+	JSON: func(s string) string {
+		return fmt.Sprintf("cast(%s as json)", mysqlString(s))
+	},
+}
+
+// mysqlString returns the string literal. A multiline string is joined with CHAR(10), because the lines of a
+// statement are joined when it is read from a ddl file
+func mysqlString(s string) string {
+	escaped := strings.ReplaceAll(s, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `''`)
+
+	if !strings.Contains(escaped, "\n") {
+		return fmt.Sprintf("'%s'", escaped)
+	}
+
+	builder := []string{
+		"CONCAT_WS(CHAR(10 using utf8)",
+	}
+	for _, line := range strings.Split(escaped, "\n") {
+		builder = append(builder, fmt.Sprintf("'%s'", line))
+	}
+	return fmt.Sprintf("%s)", strings.Join(builder, ", "))
+}
+
 func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
 	columns := []string{}
 	for _, desiredColumn := range tableSchema.Columns {
@@ -77,7 +211,11 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.MysqlT
 	}
 
 	for _, index := range tableSchema.Indexes {
-		columns = append(columns, indexClause(tableName, index))
+		clause, err := indexClause(tableName, index)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, clause)
 	}
 
 	query := fmt.Sprintf("create table `%s` (%s)", tableName, strings.Join(columns, ", "))
@@ -88,6 +226,18 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.MysqlT
 	if tableSchema.Collation != "" {
 		query = fmt.Sprintf("%s collate %s", query, tableSchema.Collation)
 	}
+	if options := tableOptionsClause(tableSchema); options != "" {
+		query = fmt.Sprintf("%s %s", query, options)
+	}
+	if tableSchema.Comment != nil && *tableSchema.Comment != "" {
+		query = fmt.Sprintf("%s comment %s", query, stringLiteral(*tableSchema.Comment))
+	}
+	if tableSchema.Partitioning != nil && tableSchema.Partitioning.Type != "" {
+		if err := validatePartitioning(tableSchema.Partitioning); err != nil {
+			return nil, err
+		}
+		query = fmt.Sprintf("%s %s", query, PartitionByClause(tableSchema.Partitioning))
+	}
 
 	return []string{query}, nil
 }
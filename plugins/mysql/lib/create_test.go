@@ -10,6 +10,11 @@ import (
 )
 
 func Test_CreateTableStatement(t *testing.T) {
+	idComment := "the id"
+	tableComment := "a table's comment"
+	keyBlockSize := 8
+	autoIncrement := int64(1000)
+
 	tests := []struct {
 		name               string
 		tableSchema        *schemasv1alpha4.MysqlTableSchema
@@ -164,6 +169,97 @@ func Test_CreateTableStatement(t *testing.T) {
 				"create table `test` (`id` int (11), primary key (`id`)) collate latin1_german1_ci",
 			},
 		},
+		{
+			name: "with comments",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				PrimaryKey: []string{
+					"id",
+				},
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name:    "id",
+						Type:    "integer",
+						Comment: &idComment,
+					},
+				},
+				Comment: &tableComment,
+			},
+			tableName: "commented",
+			expectedStatements: []string{
+				"create table `commented` (`id` int (11) comment 'the id', primary key (`id`)) comment 'a table''s comment'",
+			},
+		},
+		{
+			name: "with table options and partitions",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				PrimaryKey: []string{
+					"id",
+					"created_year",
+				},
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+					{
+						Name: "created_year",
+						Type: "integer",
+					},
+				},
+				Engine:        "InnoDB",
+				RowFormat:     "compressed",
+				KeyBlockSize:  &keyBlockSize,
+				AutoIncrement: &autoIncrement,
+				Comment:       &tableComment,
+				Partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+					Type:       "RANGE",
+					Expression: "created_year",
+					Partitions: []*schemasv1alpha4.MysqlTablePartition{
+						{Name: "p2023", Values: "2024"},
+						{Name: "pmax", Values: "MAXVALUE"},
+					},
+				},
+			},
+			tableName: "events",
+			expectedStatements: []string{
+				"create table `events` (`id` int (11), `created_year` int (11), primary key (`id`, `created_year`)) engine=InnoDB row_format=COMPRESSED key_block_size=8 auto_increment=1000 comment 'a table''s comment' partition by range (created_year) (partition `p2023` values less than (2024), partition `pmax` values less than (MAXVALUE))",
+			},
+		},
+		{
+			name: "with fulltext, prefix and descending indexes",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "title",
+						Type: "varchar(255)",
+					},
+					{
+						Name: "body",
+						Type: "text",
+					},
+				},
+				Indexes: []*schemasv1alpha4.MysqlTableIndex{
+					{
+						Name:   "ft_body",
+						Type:   "FULLTEXT",
+						Parser: "ngram",
+						Columns: []string{
+							"body",
+						},
+					},
+					{
+						Name: "idx_title",
+						Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+							{Column: "title", Length: 20, Descending: true},
+						},
+					},
+				},
+			},
+			tableName: "posts",
+			expectedStatements: []string{
+				"create table `posts` (`title` varchar (255), `body` text, fulltext key ft_body (body) with parser ngram, key idx_title (title(20) desc))",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -177,3 +273,96 @@ func Test_CreateTableStatement(t *testing.T) {
 		})
 	}
 }
+
+func Test_SeedDataStatements(t *testing.T) {
+	id, name, active, doc, data, createdAt := 1, "it's\nhere", true, `{"a": 1}`, "Af8=", "current_timestamp"
+	price := schemasv1alpha4.SeedDataFloat("19.90")
+	seedData := &schemasv1alpha4.SeedData{
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &id}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+					{Column: "active", Value: schemasv1alpha4.SeedDataValue{Bool: &active}},
+					{Column: "price", Value: schemasv1alpha4.SeedDataValue{Float: &price}},
+					{Column: "deleted_at", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+					{Column: "doc", Value: schemasv1alpha4.SeedDataValue{JSON: &doc}},
+					{Column: "data", Value: schemasv1alpha4.SeedDataValue{Bytes: &data}},
+					{Column: "created_at", Value: schemasv1alpha4.SeedDataValue{Expr: &createdAt}},
+				},
+			},
+		},
+	}
+
+	statements, err := SeedDataStatements("users", nil, seedData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`insert into users (id, name, active, price, deleted_at, doc, data, created_at) values (1, CONCAT_WS(CHAR(10 using utf8), 'it''s', 'here'), true, 19.90, null, cast('{"a": 1}' as json), x'01ff', current_timestamp) on duplicate key update id=1, name=CONCAT_WS(CHAR(10 using utf8), 'it''s', 'here'), active=true, price=19.90, deleted_at=null, doc=cast('{"a": 1}' as json), data=x'01ff', created_at=current_timestamp`,
+	}, statements)
+
+	seedData.Rows[0].Columns[3].Value.Null = true
+	_, err = SeedDataStatements("users", nil, seedData)
+	assert.EqualError(t, err, "seed data for column price has more than one value")
+}
+
+func Test_SeedDataStatementsModes(t *testing.T) {
+	one, two, name := 1, 2, "a"
+	rows := []schemasv1alpha4.SeedDataRow{
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &one}},
+				{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+			},
+		},
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &two}},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode            schemasv1alpha4.SeedDataMode
+		expect          []string
+		expectErrSubstr string
+	}{
+		{
+			mode: schemasv1alpha4.SeedDataModeInsertOnly,
+			expect: []string{
+				`insert into users (id, name) values (1, 'a') on duplicate key update id = id`,
+				`insert into users (id) values (2) on duplicate key update id = id`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeUpsert,
+			expect: []string{
+				`insert into users (id, name) values (1, 'a') on duplicate key update name = 'a'`,
+				`insert into users (id) values (2) on duplicate key update id = id`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeExact,
+			expect: []string{
+				`delete from users where not ((id = 1) or (id = 2))`,
+				`insert into users (id, name) values (1, 'a') on duplicate key update name = 'a'`,
+				`insert into users (id) values (2) on duplicate key update id = id`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.mode), func(t *testing.T) {
+			statements, err := SeedDataStatements("users", []string{"id"}, &schemasv1alpha4.SeedData{Mode: test.mode, Rows: rows})
+			if test.expectErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, statements)
+		})
+	}
+
+	_, err := SeedDataStatements("users", nil, &schemasv1alpha4.SeedData{Mode: schemasv1alpha4.SeedDataModeUpsert, Rows: rows})
+	assert.EqualError(t, err, "seed data mode upsert requires table users to have a primary key")
+}
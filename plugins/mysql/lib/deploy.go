@@ -41,8 +41,21 @@ func PlanMysqlTableSeedDataOnly(uri string, tableName string, seedData *schemasv
 		return nil, errors.Errorf("table %s does not exist, cannot apply seed data without schema", tableName)
 	}
 
+	var primaryKey []string
+	primaryKeyConstraint, err := m.GetTablePrimaryKey(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table primary key")
+	}
+	if primaryKeyConstraint != nil {
+		primaryKey = primaryKeyConstraint.Columns
+	}
+
+	if types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(m, tableName, primaryKey, seedData)
+	}
+
 	// Generate seed data statements
-	seedDataStatements, err := SeedDataStatements(tableName, seedData)
+	seedDataStatements, err := SeedDataStatements(tableName, primaryKey, seedData)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create seed data statements")
 	}
@@ -75,7 +88,7 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 
 	seedDataStatements := []string{}
 	if seedData != nil {
-		seedDataStatements, err = SeedDataStatements(tableName, seedData)
+		seedDataStatements, err = SeedDataStatements(tableName, mysqlTableSchema.PrimaryKey, seedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "create seed data statements")
 		}
@@ -88,31 +101,37 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 			return nil, errors.Wrap(err, "failed to create table statement")
 		}
 
+		triggerStatements, err := TriggerStatements(tableName, mysqlTableSchema.Triggers, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create trigger statements")
+		}
+		queries = append(queries, triggerStatements...)
+
 		return append(queries, seedDataStatements...), nil
 	}
 
-	statements := []string{}
+	statements := []ddlStatement{}
 
-	// first, if the table charset or collation changed, add
-	charsetAndCollationStatements, err := buildTableCharsetAndCollationStatements(m, tableName, mysqlTableSchema)
+	// first, if the table charset, collation, comment or other options changed, add
+	tableOptionsStatements, err := buildTableOptionsStatements(m, tableName, mysqlTableSchema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to build table charset and collation statements")
+		return nil, errors.Wrap(err, "failed to build table options statements")
 	}
-	statements = append(statements, charsetAndCollationStatements...)
+	statements = append(statements, tableOptionsStatements...)
 
 	// remove primary keys before removing columns
 	removePrimaryKeyStatements, err := buildRemovePrimaryKeyStatements(m, tableName, mysqlTableSchema)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build remove primary key statements")
 	}
-	statements = append(statements, removePrimaryKeyStatements...)
+	statements = append(statements, ddlStatements(ddlChangeDropPrimaryKey, removePrimaryKeyStatements)...)
 
 	// indexes need to be removed before columns are removed
 	removeIndexStatements, err := buildRemoveIndexStatements(m, tableName, mysqlTableSchema)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build remove index statements")
 	}
-	statements = append(statements, removeIndexStatements...)
+	statements = append(statements, ddlStatements(ddlChangeDropIndex, removeIndexStatements)...)
 
 	// table needs to be altered?
 	columnStatements, err := buildColumnStatements(m, tableName, mysqlTableSchema)
@@ -126,7 +145,7 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build add primary key statements")
 	}
-	statements = append(statements, addPrimaryKeyStatements...)
+	statements = append(statements, ddlStatements(ddlChangeAddPrimaryKey, addPrimaryKeyStatements)...)
 
 	// foreign key changes
 	foreignKeyStatements, err := buildForeignKeyStatements(m, tableName, mysqlTableSchema)
@@ -142,9 +161,46 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 	}
 	statements = append(statements, addIndexStatements...)
 
-	statements = append(statements, seedDataStatements...)
+	// partitioning is changed last, unique keys must include the partitioning columns
+	partitionStatements, err := buildPartitionStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build partition statements")
+	}
+	statements = append(statements, partitionStatements...)
 
-	return statements, nil
+	// triggers are replaced after the columns that they use exist
+	triggerStatements, err := buildTriggerStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build trigger statements")
+	}
+	statements = append(statements, ddlStatements("", triggerStatements)...)
+
+	// the rows can be read when the table does not change, otherwise the seed data is written without them
+	if len(statements) == 0 && types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(m, tableName, mysqlTableSchema.PrimaryKey, seedData)
+	}
+
+	// changes that would copy the table under a lock are applied to a shadow table instead
+	if mysqlTableSchema.OnlineSchemaChange != nil && requiresTableRebuild(statements) {
+		shadowStatements, err := buildOnlineSchemaChangeStatements(m, tableName, mysqlTableSchema)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build online schema change statements")
+		}
+
+		return append(shadowStatements, seedDataStatements...), nil
+	}
+
+	if mysqlTableSchema.OnlineDDL != nil {
+		version, err := m.getServerVersion()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get server version")
+		}
+		if err := validateOnlineDDL(tableName, mysqlTableSchema.OnlineDDL, statements, *version); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(applyOnlineDDL(mysqlTableSchema.OnlineDDL, statements), seedDataStatements...), nil
 }
 
 func DeployMysqlStatements(uri string, statements []string) error {
@@ -167,6 +223,13 @@ func executeStatements(m *MysqlConnection, statements []string) error {
 		if statement == "" {
 			continue
 		}
+		if types.RequiresOnlineCopy([]string{statement}) {
+			fmt.Printf("Executing online copy %q\n", statement)
+			if err := runOnlineCopy(m, statement); err != nil {
+				return errors.Wrap(err, "failed to run online copy")
+			}
+			continue
+		}
 		fmt.Printf("Executing query %q\n", statement)
 		if _, err := m.db.ExecContext(context.Background(), statement); err != nil {
 			return err
@@ -305,14 +368,14 @@ AND t.table_name = ?`
 	return mostSpecificCharset, mostSpecificCollation, nil
 }
 
-func buildColumnStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+func buildColumnStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]ddlStatement, error) {
 	defaultCharset, defaultCollation, err := getDefaultCharsetAndCollationForTable(m, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "get default charset and collation")
 	}
 
 	query := `select
-COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, CHARACTER_SET_NAME, COLLATION_NAME
+COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, CHARACTER_SET_NAME, COLLATION_NAME, COLUMN_COMMENT
 FROM information_schema.COLUMNS
 WHERE TABLE_SCHEMA = ?
 AND TABLE_NAME = ?`
@@ -322,15 +385,15 @@ AND TABLE_NAME = ?`
 	}
 	defer rows.Close()
 
-	alterAndDropStatements := []string{}
+	alterAndDropStatements := []ddlStatement{}
 	foundColumnNames := []string{}
 	for rows.Next() {
-		var columnName, dataType, isNullable, extra string
+		var columnName, dataType, isNullable, extra, columnComment string
 		var columnDefault sql.NullString
 		var charMaxLength sql.NullInt64
 		var columnCharset, columnCollation sql.NullString
 
-		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &extra, &dataType, &charMaxLength, &columnCharset, &columnCollation); err != nil {
+		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &extra, &dataType, &charMaxLength, &columnCharset, &columnCollation, &columnComment); err != nil {
 			return nil, errors.Wrap(err, "failed to scan")
 		}
 
@@ -387,7 +450,11 @@ AND TABLE_NAME = ?`
 			existingColumn.ColumnDefault = &columnDefault.String
 		}
 
-		columnStatement, err := AlterColumnStatements(tableName, mysqlTableSchema.PrimaryKey, mysqlTableSchema.Columns, &existingColumn, defaultCharset, defaultCollation)
+		if columnComment != "" {
+			existingColumn.Comment = &columnComment
+		}
+
+		columnStatement, err := alterColumnDDLStatements(tableName, mysqlTableSchema.PrimaryKey, mysqlTableSchema.Columns, &existingColumn, defaultCharset, defaultCollation)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create alter column statement")
 		}
@@ -409,7 +476,7 @@ AND TABLE_NAME = ?`
 				return nil, errors.Wrap(err, "failed to create insert column statement")
 			}
 
-			alterAndDropStatements = append(alterAndDropStatements, statement)
+			alterAndDropStatements = append(alterAndDropStatements, ddlStatement{SQL: statement, Change: ddlChangeAddColumn})
 		}
 	}
 
@@ -473,8 +540,8 @@ func buildAddPrimaryKeyStatements(m *MysqlConnection, tableName string, mysqlTab
 	return statements, nil
 }
 
-func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
-	foreignKeyStatements := []string{}
+func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]ddlStatement, error) {
+	foreignKeyStatements := []ddlStatement{}
 	currentForeignKeys, err := m.ListTableForeignKeys(m.databaseName, tableName)
 	if err != nil {
 		return nil, err
@@ -495,11 +562,11 @@ func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableS
 		// TODO can we alter
 		if matchedForeignKey != nil {
 			statement = RemoveForeignKeyStatement(tableName, matchedForeignKey)
-			foreignKeyStatements = append(foreignKeyStatements, statement)
+			foreignKeyStatements = append(foreignKeyStatements, ddlStatement{SQL: statement, Change: ddlChangeDropForeignKey})
 		}
 
 		statement = AddForeignKeyStatement(tableName, foreignKey)
-		foreignKeyStatements = append(foreignKeyStatements, statement)
+		foreignKeyStatements = append(foreignKeyStatements, ddlStatement{SQL: statement, Change: ddlChangeAddForeignKey})
 
 	Next:
 	}
@@ -513,7 +580,7 @@ func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableS
 		}
 
 		statement = RemoveForeignKeyStatement(tableName, currentForeignKey)
-		foreignKeyStatements = append(foreignKeyStatements, statement)
+		foreignKeyStatements = append(foreignKeyStatements, ddlStatement{SQL: statement, Change: ddlChangeDropForeignKey})
 
 	NextCurrentFK:
 	}
@@ -548,8 +615,8 @@ func buildRemoveIndexStatements(m *MysqlConnection, tableName string, mysqlTable
 	return indexStatements, nil
 }
 
-func buildAddIndexStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
-	indexStatements := []string{}
+func buildAddIndexStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]ddlStatement, error) {
+	indexStatements := []ddlStatement{}
 	currentIndexes, err := m.ListTableIndexes(m.databaseName, tableName)
 	if err != nil {
 		return nil, err
@@ -564,7 +631,11 @@ func buildAddIndexStatements(m *MysqlConnection, tableName string, mysqlTableSch
 		}
 
 		if !isMatch {
-			indexStatements = append(indexStatements, AddIndexStatement(tableName, desiredIndex))
+			statement, err := AddIndexStatement(tableName, desiredIndex)
+			if err != nil {
+				return nil, err
+			}
+			indexStatements = append(indexStatements, ddlStatement{SQL: statement, Change: addIndexChange(desiredIndex)})
 		}
 	}
 
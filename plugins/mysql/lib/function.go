@@ -0,0 +1,254 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// FunctionBodyTag surrounds routine and trigger bodies in the generated statements. The body of a
+// routine can contain statement terminators, so the tags keep it in a single statement when DDL is split.
+const FunctionBodyTag = "-- Function body follows"
+
+// existingFunction is a stored function or procedure read from information_schema.ROUTINES
+type existingFunction struct {
+	Params        []*schemasv1alpha4.MysqlFunctionParameter
+	Returns       string
+	Deterministic bool
+	DataAccess    string
+	Security      string
+	Comment       string
+	Body          string
+}
+
+func functionType(functionSchema *schemasv1alpha4.MysqlFunctionSchema) string {
+	if functionSchema.Type == "" {
+		return "FUNCTION"
+	}
+	return strings.ToUpper(functionSchema.Type)
+}
+
+// taggedBody places a body between the body tags, each on its own line
+func taggedBody(body string) string {
+	return fmt.Sprintf("%s\n%s\n%s", FunctionBodyTag, strings.TrimSpace(body), FunctionBodyTag)
+}
+
+func validateFunction(functionName string, functionSchema *schemasv1alpha4.MysqlFunctionSchema) error {
+	routineType := functionType(functionSchema)
+	if routineType != "FUNCTION" && routineType != "PROCEDURE" {
+		return errors.Errorf("unsupported routine type %q for %s", functionSchema.Type, functionName)
+	}
+	if strings.TrimSpace(functionSchema.Body) == "" {
+		return errors.Errorf("%s %s requires a body", strings.ToLower(routineType), functionName)
+	}
+
+	if routineType == "FUNCTION" {
+		if functionSchema.Returns == "" {
+			return errors.Errorf("function %s requires a return type", functionName)
+		}
+		for _, param := range functionSchema.Params {
+			if param.Mode != "" && strings.ToUpper(param.Mode) != "IN" {
+				return errors.Errorf("function %s parameter %s cannot have mode %s, only procedures support parameter modes", functionName, param.Name, param.Mode)
+			}
+		}
+	} else if functionSchema.Returns != "" {
+		return errors.Errorf("procedure %s cannot have a return type", functionName)
+	}
+
+	return nil
+}
+
+// CreateFunctionStatements returns the statement to create a stored function or procedure
+func CreateFunctionStatements(functionName string, functionSchema *schemasv1alpha4.MysqlFunctionSchema) ([]string, error) {
+	if err := validateFunction(functionName, functionSchema); err != nil {
+		return nil, err
+	}
+
+	routineType := functionType(functionSchema)
+
+	params := []string{}
+	for _, param := range functionSchema.Params {
+		formatted := fmt.Sprintf("`%s` %s", param.Name, param.Type)
+		if routineType == "PROCEDURE" && param.Mode != "" {
+			formatted = fmt.Sprintf("%s %s", strings.ToLower(param.Mode), formatted)
+		}
+		params = append(params, formatted)
+	}
+
+	statement := fmt.Sprintf("create %s `%s` (%s)", strings.ToLower(routineType), functionName, strings.Join(params, ", "))
+	if routineType == "FUNCTION" {
+		statement = fmt.Sprintf("%s returns %s", statement, functionSchema.Returns)
+	}
+	if functionSchema.Deterministic {
+		statement = fmt.Sprintf("%s deterministic", statement)
+	}
+	if functionSchema.DataAccess != "" {
+		statement = fmt.Sprintf("%s %s", statement, strings.ToLower(functionSchema.DataAccess))
+	}
+	if functionSchema.Security != "" {
+		statement = fmt.Sprintf("%s sql security %s", statement, strings.ToLower(functionSchema.Security))
+	}
+	if functionSchema.Comment != "" {
+		statement = fmt.Sprintf("%s comment %s", statement, stringLiteral(functionSchema.Comment))
+	}
+
+	return []string{
+		fmt.Sprintf("%s\n%s", statement, taggedBody(functionSchema.Body)),
+	}, nil
+}
+
+// DropFunctionStatements returns the statement to drop a stored function or procedure
+func DropFunctionStatements(functionName string, functionSchema *schemasv1alpha4.MysqlFunctionSchema) []string {
+	return []string{
+		fmt.Sprintf("drop %s if exists `%s`", strings.ToLower(functionType(functionSchema)), functionName),
+	}
+}
+
+func normalizeRoutineType(t string) string {
+	return strings.Join(strings.Fields(strings.ToLower(t)), "")
+}
+
+func normalizeRoutineBody(body string) string {
+	return strings.Join(strings.Fields(body), " ")
+}
+
+// functionChanged returns true when the routine in the database is different from the schema
+func functionChanged(functionSchema *schemasv1alpha4.MysqlFunctionSchema, existing *existingFunction) bool {
+	if len(functionSchema.Params) != len(existing.Params) {
+		return true
+	}
+	for i, param := range functionSchema.Params {
+		existingParam := existing.Params[i]
+		if param.Name != existingParam.Name || normalizeRoutineType(param.Type) != normalizeRoutineType(existingParam.Type) {
+			return true
+		}
+
+		mode := strings.ToUpper(param.Mode)
+		if mode == "" {
+			mode = "IN"
+		}
+		existingMode := strings.ToUpper(existingParam.Mode)
+		if existingMode == "" {
+			existingMode = "IN"
+		}
+		if mode != existingMode {
+			return true
+		}
+	}
+
+	if normalizeRoutineType(functionSchema.Returns) != normalizeRoutineType(existing.Returns) {
+		return true
+	}
+	if functionSchema.Deterministic != existing.Deterministic {
+		return true
+	}
+
+	dataAccess := strings.ToUpper(functionSchema.DataAccess)
+	if dataAccess == "" {
+		dataAccess = "CONTAINS SQL"
+	}
+	if dataAccess != strings.ToUpper(existing.DataAccess) {
+		return true
+	}
+
+	security := strings.ToUpper(functionSchema.Security)
+	if security == "" {
+		security = "DEFINER"
+	}
+	if security != strings.ToUpper(existing.Security) {
+		return true
+	}
+
+	if functionSchema.Comment != existing.Comment {
+		return true
+	}
+
+	return normalizeRoutineBody(functionSchema.Body) != normalizeRoutineBody(existing.Body)
+}
+
+// FunctionStatements returns the statements to move the routine from the existing state to the schema.
+// Routines cannot be altered beyond their characteristics, so a changed routine is dropped and created again.
+func FunctionStatements(functionName string, functionSchema *schemasv1alpha4.MysqlFunctionSchema, existing *existingFunction) ([]string, error) {
+	if functionSchema.IsDeleted {
+		if existing == nil {
+			return []string{}, nil
+		}
+		return DropFunctionStatements(functionName, functionSchema), nil
+	}
+
+	if existing == nil {
+		return CreateFunctionStatements(functionName, functionSchema)
+	}
+
+	if !functionChanged(functionSchema, existing) {
+		return []string{}, nil
+	}
+
+	createStatements, err := CreateFunctionStatements(functionName, functionSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(DropFunctionStatements(functionName, functionSchema), createStatements...), nil
+}
+
+// readFunction returns the routine from the database, or nil when it does not exist
+func readFunction(m *MysqlConnection, functionName string, routineType string) (*existingFunction, error) {
+	query := `select DTD_IDENTIFIER, IS_DETERMINISTIC, SQL_DATA_ACCESS, SECURITY_TYPE, ROUTINE_COMMENT, ROUTINE_DEFINITION
+from information_schema.ROUTINES
+where ROUTINE_SCHEMA = ? and ROUTINE_NAME = ? and ROUTINE_TYPE = ?`
+	row := m.db.QueryRow(query, m.databaseName, functionName, routineType)
+
+	var returns, body sql.NullString
+	var deterministic string
+	existing := existingFunction{}
+	if err := row.Scan(&returns, &deterministic, &existing.DataAccess, &existing.Security, &existing.Comment, &body); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read routine")
+	}
+	existing.Returns = returns.String
+	existing.Body = body.String
+	existing.Deterministic = deterministic == "YES"
+
+	query = `select PARAMETER_MODE, PARAMETER_NAME, DTD_IDENTIFIER
+from information_schema.PARAMETERS
+where SPECIFIC_SCHEMA = ? and SPECIFIC_NAME = ? and ROUTINE_TYPE = ? and ORDINAL_POSITION > 0
+order by ORDINAL_POSITION`
+	rows, err := m.db.Query(query, m.databaseName, functionName, routineType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query routine parameters")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mode sql.NullString
+		param := schemasv1alpha4.MysqlFunctionParameter{}
+		if err := rows.Scan(&mode, &param.Name, &param.Type); err != nil {
+			return nil, errors.Wrap(err, "failed to scan routine parameter")
+		}
+		param.Mode = mode.String
+		existing.Params = append(existing.Params, &param)
+	}
+
+	return &existing, nil
+}
+
+func PlanMysqlFunction(uri string, functionName string, mysqlFunctionSchema *schemasv1alpha4.MysqlFunctionSchema) ([]string, error) {
+	m, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to mysql")
+	}
+	defer m.Close()
+
+	existing, err := readFunction(m, functionName, functionType(mysqlFunctionSchema))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing routine")
+	}
+
+	return FunctionStatements(functionName, mysqlFunctionSchema, existing)
+}
@@ -0,0 +1,139 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFunctionStatements(t *testing.T) {
+	tests := []struct {
+		name           string
+		functionName   string
+		functionSchema *schemasv1alpha4.MysqlFunctionSchema
+		expected       []string
+		wantErr        bool
+	}{
+		{
+			name:         "function",
+			functionName: "order_total",
+			functionSchema: &schemasv1alpha4.MysqlFunctionSchema{
+				Params: []*schemasv1alpha4.MysqlFunctionParameter{
+					{Name: "price", Type: "decimal(10,2)"},
+					{Name: "quantity", Type: "int"},
+				},
+				Returns:       "decimal(10,2)",
+				Deterministic: true,
+				DataAccess:    "NO SQL",
+				Comment:       "price times quantity",
+				Body:          "RETURN price * quantity",
+			},
+			expected: []string{
+				"create function `order_total` (`price` decimal(10,2), `quantity` int) returns decimal(10,2) deterministic no sql comment 'price times quantity'\n" +
+					"-- Function body follows\n" +
+					"RETURN price * quantity\n" +
+					"-- Function body follows",
+			},
+		},
+		{
+			name:         "procedure",
+			functionName: "archive_orders",
+			functionSchema: &schemasv1alpha4.MysqlFunctionSchema{
+				Type: "PROCEDURE",
+				Params: []*schemasv1alpha4.MysqlFunctionParameter{
+					{Name: "cutoff", Type: "datetime", Mode: "IN"},
+					{Name: "archived", Type: "int", Mode: "OUT"},
+				},
+				DataAccess: "MODIFIES SQL DATA",
+				Security:   "INVOKER",
+				Body: `BEGIN
+    INSERT INTO orders_archive SELECT * FROM orders WHERE created_at < cutoff;
+    SET archived = ROW_COUNT();
+    DELETE FROM orders WHERE created_at < cutoff;
+END
+`,
+			},
+			expected: []string{
+				"create procedure `archive_orders` (in `cutoff` datetime, out `archived` int) modifies sql data sql security invoker\n" +
+					"-- Function body follows\n" +
+					"BEGIN\n" +
+					"    INSERT INTO orders_archive SELECT * FROM orders WHERE created_at < cutoff;\n" +
+					"    SET archived = ROW_COUNT();\n" +
+					"    DELETE FROM orders WHERE created_at < cutoff;\n" +
+					"END\n" +
+					"-- Function body follows",
+			},
+		},
+		{
+			name:         "function without return type",
+			functionName: "f",
+			functionSchema: &schemasv1alpha4.MysqlFunctionSchema{
+				Body: "RETURN 1",
+			},
+			wantErr: true,
+		},
+		{
+			name:         "function with out parameter",
+			functionName: "f",
+			functionSchema: &schemasv1alpha4.MysqlFunctionSchema{
+				Params:  []*schemasv1alpha4.MysqlFunctionParameter{{Name: "a", Type: "int", Mode: "OUT"}},
+				Returns: "int",
+				Body:    "RETURN 1",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := CreateFunctionStatements(test.functionName, test.functionSchema)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestFunctionStatements(t *testing.T) {
+	functionSchema := &schemasv1alpha4.MysqlFunctionSchema{
+		Params:        []*schemasv1alpha4.MysqlFunctionParameter{{Name: "a", Type: "INT"}},
+		Returns:       "INT",
+		Deterministic: true,
+		Body:          "RETURN a + 1",
+	}
+	unchanged := &existingFunction{
+		Params:        []*schemasv1alpha4.MysqlFunctionParameter{{Name: "a", Type: "int", Mode: "IN"}},
+		Returns:       "int",
+		Deterministic: true,
+		DataAccess:    "CONTAINS SQL",
+		Security:      "DEFINER",
+		Body:          "RETURN a + 1",
+	}
+
+	statements, err := FunctionStatements("plus_one", functionSchema, unchanged)
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+
+	changed := *unchanged
+	changed.Body = "RETURN a + 2"
+	statements, err = FunctionStatements("plus_one", functionSchema, &changed)
+	require.NoError(t, err)
+	require.Len(t, statements, 2)
+	assert.Equal(t, "drop function if exists `plus_one`", statements[0])
+	assert.Contains(t, statements[1], "create function `plus_one`")
+
+	deleted := functionSchema.DeepCopy()
+	deleted.IsDeleted = true
+	statements, err = FunctionStatements("plus_one", deleted, unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"drop function if exists `plus_one`"}, statements)
+
+	statements, err = FunctionStatements("plus_one", deleted, nil)
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+}
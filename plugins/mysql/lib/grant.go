@@ -0,0 +1,224 @@
+package mysql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var showGrantsRegex = regexp.MustCompile(`(?i)^GRANT (.+) ON (.+) TO (.+?)( WITH GRANT OPTION)?$`)
+
+func PlanMysqlGrant(uri string, grantSchema *schemasv1alpha4.MysqlGrantSchema) ([]string, error) {
+	m, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to mysql")
+	}
+	defer m.Close()
+
+	object, err := grantObjectIdentifier(grantSchema.Object, m.databaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("show grants for %s", grantUserIdentifier(grantSchema.User, grantSchema.Host))
+	rows, err := m.db.Query(query)
+	if err != nil {
+		// 1141 is returned when the user does not exist, so there is nothing granted yet
+		if mysqlErr, ok := err.(*mysqldriver.MySQLError); ok && mysqlErr.Number == 1141 {
+			return GrantStatements(grantSchema, m.databaseName, []string{}, false)
+		}
+		return nil, errors.Wrap(err, "failed to show grants")
+	}
+	defer rows.Close()
+
+	lines := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, errors.Wrap(err, "failed to scan grant")
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read grants")
+	}
+
+	existingPrivileges, existingGrantOption := parseShowGrants(lines, object)
+	return GrantStatements(grantSchema, m.databaseName, existingPrivileges, existingGrantOption)
+}
+
+// GrantStatements returns the grant and revoke statements needed to make the privileges that the user
+// has on the object match the schema.
+func GrantStatements(grantSchema *schemasv1alpha4.MysqlGrantSchema, databaseName string, existingPrivileges []string, existingGrantOption bool) ([]string, error) {
+	object, err := grantObjectIdentifier(grantSchema.Object, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	user := grantUserIdentifier(grantSchema.User, grantSchema.Host)
+
+	desiredPrivileges := []string{}
+	if !grantSchema.IsDeleted {
+		for _, privilege := range grantSchema.Privileges {
+			privilege = strings.ToUpper(strings.TrimSpace(privilege))
+			if privilege == "ALL" || privilege == "ALL PRIVILEGES" {
+				desiredPrivileges = []string{"ALL PRIVILEGES"}
+				break
+			}
+			if !containsString(desiredPrivileges, privilege) {
+				desiredPrivileges = append(desiredPrivileges, privilege)
+			}
+		}
+	}
+
+	toGrant := []string{}
+	for _, privilege := range desiredPrivileges {
+		if !containsString(existingPrivileges, privilege) {
+			toGrant = append(toGrant, privilege)
+		}
+	}
+
+	// all privileges covers anything that was granted individually
+	toRevoke := []string{}
+	if !containsString(desiredPrivileges, "ALL PRIVILEGES") {
+		for _, privilege := range existingPrivileges {
+			if !containsString(desiredPrivileges, privilege) {
+				toRevoke = append(toRevoke, privilege)
+			}
+		}
+	}
+	if existingGrantOption && (grantSchema.IsDeleted || !grantSchema.WithGrantOption) {
+		toRevoke = append(toRevoke, "GRANT OPTION")
+	}
+
+	// the grant option can only be added by granting privileges
+	if len(toGrant) == 0 && grantSchema.WithGrantOption && !existingGrantOption && !grantSchema.IsDeleted {
+		toGrant = desiredPrivileges
+	}
+
+	statements := []string{}
+	if len(toRevoke) > 0 {
+		statements = append(statements, fmt.Sprintf("revoke %s on %s from %s", strings.Join(toRevoke, ", "), object, user))
+	}
+	if len(toGrant) > 0 {
+		statement := fmt.Sprintf("grant %s on %s to %s", strings.Join(toGrant, ", "), object, user)
+		if grantSchema.WithGrantOption {
+			statement = fmt.Sprintf("%s with grant option", statement)
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+// grantObjectIdentifier returns the object clause of a grant statement, such as `db`.`users`
+func grantObjectIdentifier(object schemasv1alpha4.GrantObject, databaseName string) (string, error) {
+	database := databaseName
+	if object.Schema != "" {
+		database = object.Schema
+	}
+
+	switch object.Type {
+	case "schema":
+		if object.Name != "" {
+			database = object.Name
+		}
+		return fmt.Sprintf("`%s`.*", database), nil
+	case "table", "view":
+		if object.Name == "" {
+			return "", errors.Errorf("%s grants require an object name", object.Type)
+		}
+		return fmt.Sprintf("`%s`.`%s`", database, object.Name), nil
+	case "function":
+		if object.Name == "" {
+			return "", errors.New("function grants require an object name")
+		}
+		return fmt.Sprintf("function `%s`.`%s`", database, object.Name), nil
+	case "sequence":
+		return "", errors.New("mysql does not support sequences")
+	}
+
+	return "", errors.Errorf("unsupported grant object type %q", object.Type)
+}
+
+func grantUserIdentifier(user string, host string) string {
+	if host == "" {
+		host = "%"
+	}
+	return fmt.Sprintf("%s@%s", stringLiteral(user), stringLiteral(host))
+}
+
+// parseShowGrants returns the privileges granted on the object in the output of SHOW GRANTS,
+// and whether they were granted with grant option.
+func parseShowGrants(lines []string, object string) ([]string, bool) {
+	wantObject := normalizeGrantObject(object)
+
+	privileges := []string{}
+	grantOption := false
+	for _, line := range lines {
+		matches := showGrantsRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		if normalizeGrantObject(matches[2]) != wantObject {
+			continue
+		}
+
+		for _, privilege := range splitPrivileges(matches[1]) {
+			// column level privileges are not managed
+			if strings.Contains(privilege, "(") {
+				continue
+			}
+			privilege = strings.ToUpper(privilege)
+			if privilege == "USAGE" {
+				continue
+			}
+			if !containsString(privileges, privilege) {
+				privileges = append(privileges, privilege)
+			}
+		}
+		if matches[4] != "" {
+			grantOption = true
+		}
+	}
+
+	return privileges, grantOption
+}
+
+func normalizeGrantObject(object string) string {
+	object = strings.NewReplacer("`", "", "'", "", `"`, "").Replace(object)
+	return strings.ToLower(strings.TrimSpace(object))
+}
+
+// splitPrivileges splits a privilege list on commas that are not inside a column list
+func splitPrivileges(list string) []string {
+	privileges := []string{}
+	depth := 0
+	start := 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				privileges = append(privileges, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(privileges, strings.TrimSpace(list[start:]))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseShowGrants(t *testing.T) {
+	lines := []string{
+		"GRANT USAGE ON *.* TO `app`@`%`",
+		"GRANT SELECT, INSERT, UPDATE (`name`, `email`) ON `shop`.`users` TO `app`@`%` WITH GRANT OPTION",
+		"GRANT DELETE ON `shop`.`orders` TO `app`@`%`",
+		"GRANT EXECUTE ON FUNCTION `shop`.`total` TO `app`@`%`",
+	}
+
+	privileges, grantOption := parseShowGrants(lines, "`shop`.`users`")
+	assert.Equal(t, []string{"SELECT", "INSERT"}, privileges)
+	assert.True(t, grantOption)
+
+	privileges, grantOption = parseShowGrants(lines, "`shop`.`orders`")
+	assert.Equal(t, []string{"DELETE"}, privileges)
+	assert.False(t, grantOption)
+
+	privileges, _ = parseShowGrants(lines, "function `shop`.`total`")
+	assert.Equal(t, []string{"EXECUTE"}, privileges)
+
+	privileges, _ = parseShowGrants(lines, "`shop`.*")
+	assert.Equal(t, []string{}, privileges)
+}
+
+func TestGrantStatements(t *testing.T) {
+	tests := []struct {
+		name                string
+		grantSchema         *schemasv1alpha4.MysqlGrantSchema
+		existingPrivileges  []string
+		existingGrantOption bool
+		expected            []string
+		wantErr             bool
+	}{
+		{
+			name: "new table grant",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "table", Name: "users"},
+				Privileges: []string{"select", "insert"},
+			},
+			existingPrivileges: []string{},
+			expected: []string{
+				"grant SELECT, INSERT on `shop`.`users` to 'reader'@'%'",
+			},
+		},
+		{
+			name: "already granted",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "reader",
+				Host:       "localhost",
+				Object:     schemasv1alpha4.GrantObject{Type: "view", Name: "active_users"},
+				Privileges: []string{"SELECT"},
+			},
+			existingPrivileges: []string{"SELECT"},
+			expected:           []string{},
+		},
+		{
+			name: "add and remove privileges",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "writer",
+				Object:     schemasv1alpha4.GrantObject{Type: "schema"},
+				Privileges: []string{"SELECT", "UPDATE"},
+			},
+			existingPrivileges: []string{"SELECT", "DELETE"},
+			expected: []string{
+				"revoke DELETE on `shop`.* from 'writer'@'%'",
+				"grant UPDATE on `shop`.* to 'writer'@'%'",
+			},
+		},
+		{
+			name: "all privileges does not revoke",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "admin",
+				Object:     schemasv1alpha4.GrantObject{Type: "schema", Name: "other"},
+				Privileges: []string{"ALL"},
+			},
+			existingPrivileges: []string{"SELECT"},
+			expected: []string{
+				"grant ALL PRIVILEGES on `other`.* to 'admin'@'%'",
+			},
+		},
+		{
+			name: "add grant option",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:            "app",
+				Object:          schemasv1alpha4.GrantObject{Type: "function", Name: "total"},
+				Privileges:      []string{"EXECUTE"},
+				WithGrantOption: true,
+			},
+			existingPrivileges: []string{"EXECUTE"},
+			expected: []string{
+				"grant EXECUTE on function `shop`.`total` to 'app'@'%' with grant option",
+			},
+		},
+		{
+			name: "deleted grant revokes everything",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "table", Schema: "other", Name: "users"},
+				Privileges: []string{"SELECT"},
+				IsDeleted:  true,
+			},
+			existingPrivileges:  []string{"SELECT", "INSERT"},
+			existingGrantOption: true,
+			expected: []string{
+				"revoke SELECT, INSERT, GRANT OPTION on `other`.`users` from 'reader'@'%'",
+			},
+		},
+		{
+			name: "sequences are not supported",
+			grantSchema: &schemasv1alpha4.MysqlGrantSchema{
+				User:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "sequence", Name: "users_seq"},
+				Privileges: []string{"SELECT"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			actual, err := GrantStatements(test.grantSchema, "shop", test.existingPrivileges, test.existingGrantOption)
+			if test.wantErr {
+				req.Error(err)
+				return
+			}
+			req.NoError(err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
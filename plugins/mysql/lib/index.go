@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
@@ -12,10 +13,96 @@ func RemoveIndexStatement(tableName string, index *types.Index) string {
 	return fmt.Sprintf("alter table `%s` drop index `%s`", tableName, index.Name)
 }
 
-func AddIndexStatement(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
-	unique := ""
+func validateIndex(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) error {
+	if len(schemaIndex.Columns) > 0 && len(schemaIndex.Parts) > 0 {
+		return errors.Errorf("index on table %s cannot have both columns and parts", tableName)
+	}
+	if len(schemaIndex.Columns) == 0 && len(schemaIndex.Parts) == 0 {
+		return errors.Errorf("index on table %s requires columns or parts", tableName)
+	}
+
+	indexType := strings.ToUpper(schemaIndex.Type)
+	switch indexType {
+	case "", "BTREE", "HASH":
+		if schemaIndex.Parser != "" {
+			return errors.Errorf("index on table %s has a parser, only FULLTEXT indexes have a parser", tableName)
+		}
+	case "FULLTEXT", "SPATIAL":
+		if schemaIndex.IsUnique {
+			return errors.Errorf("%s index on table %s cannot be unique", indexType, tableName)
+		}
+		if indexType == "SPATIAL" && schemaIndex.Parser != "" {
+			return errors.Errorf("index on table %s has a parser, only FULLTEXT indexes have a parser", tableName)
+		}
+	default:
+		return errors.Errorf("unsupported index type %q on table %s", schemaIndex.Type, tableName)
+	}
+
+	for _, part := range schemaIndex.Parts {
+		if (part.Column == "") == (part.Expression == "") {
+			return errors.Errorf("index part on table %s requires either a column or an expression", tableName)
+		}
+		if part.Expression != "" && part.Length > 0 {
+			return errors.Errorf("index expression %s on table %s cannot have a length", part.Expression, tableName)
+		}
+		if (indexType == "FULLTEXT" || indexType == "SPATIAL") && (part.Expression != "" || part.Length > 0 || part.Descending) {
+			return errors.Errorf("%s index on table %s only supports columns", indexType, tableName)
+		}
+	}
+
+	return nil
+}
+
+// keyParts returns the key parts of an index, such as name(20), created_at desc or (lower(email))
+func keyParts(schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
+	if len(schemaIndex.Parts) == 0 {
+		return strings.Join(schemaIndex.Columns, ", ")
+	}
+
+	parts := []string{}
+	for _, part := range schemaIndex.Parts {
+		formatted := part.Column
+		if part.Expression != "" {
+			formatted = fmt.Sprintf("(%s)", part.Expression)
+		} else if part.Length > 0 {
+			formatted = fmt.Sprintf("%s(%d)", part.Column, part.Length)
+		}
+		if part.Descending {
+			formatted = fmt.Sprintf("%s desc", formatted)
+		}
+		parts = append(parts, formatted)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// indexPrefix returns unique, fulltext or spatial for the index, followed by a space
+func indexPrefix(schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
+	indexType := strings.ToUpper(schemaIndex.Type)
+	if indexType == "FULLTEXT" || indexType == "SPATIAL" {
+		return fmt.Sprintf("%s ", strings.ToLower(indexType))
+	}
 	if schemaIndex.IsUnique {
-		unique = "unique "
+		return "unique "
+	}
+	return ""
+}
+
+// indexOptions returns the index method and parser of the index, preceded by a space
+func indexOptions(schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
+	options := ""
+	indexType := strings.ToUpper(schemaIndex.Type)
+	if indexType == "BTREE" || indexType == "HASH" {
+		options = fmt.Sprintf("%s using %s", options, strings.ToLower(indexType))
+	}
+	if schemaIndex.Parser != "" {
+		options = fmt.Sprintf("%s with parser %s", options, schemaIndex.Parser)
+	}
+	return options
+}
+
+func AddIndexStatement(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) (string, error) {
+	if err := validateIndex(tableName, schemaIndex); err != nil {
+		return "", err
 	}
 
 	name := schemaIndex.Name
@@ -23,17 +110,25 @@ func AddIndexStatement(tableName string, schemaIndex *schemasv1alpha4.MysqlTable
 		name = types.GenerateMysqlIndexName(tableName, schemaIndex)
 	}
 
-	return fmt.Sprintf("create %sindex %s on %s (%s)", unique, name, tableName, strings.Join(schemaIndex.Columns, ", "))
+	return fmt.Sprintf("create %sindex %s on %s (%s)%s", indexPrefix(schemaIndex), name, tableName, keyParts(schemaIndex), indexOptions(schemaIndex)), nil
+}
+
+// addIndexChange returns the kind of change that adding the index makes
+func addIndexChange(schemaIndex *schemasv1alpha4.MysqlTableIndex) ddlChange {
+	indexType := strings.ToUpper(schemaIndex.Type)
+	if indexType == "FULLTEXT" || indexType == "SPATIAL" {
+		return ddlChangeAddFulltextIndex
+	}
+	return ddlChangeAddIndex
 }
 
 func RenameIndexStatement(tableName string, index *types.Index, schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
 	return fmt.Sprintf("alter index %s rename to %s", index.Name, schemaIndex.Name)
 }
 
-func indexClause(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) string {
-	unique := ""
-	if schemaIndex.IsUnique {
-		unique = "unique "
+func indexClause(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex) (string, error) {
+	if err := validateIndex(tableName, schemaIndex); err != nil {
+		return "", err
 	}
 
 	name := schemaIndex.Name
@@ -41,5 +136,5 @@ func indexClause(tableName string, schemaIndex *schemasv1alpha4.MysqlTableIndex)
 		name = types.GenerateMysqlIndexName(tableName, schemaIndex)
 	}
 
-	return fmt.Sprintf("%skey %s (%s)", unique, name, strings.Join(schemaIndex.Columns, ", "))
+	return fmt.Sprintf("%skey %s (%s)%s", indexPrefix(schemaIndex), name, keyParts(schemaIndex), indexOptions(schemaIndex)), nil
 }
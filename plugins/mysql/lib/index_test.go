@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddIndexStatement(t *testing.T) {
+	tests := []struct {
+		name              string
+		tableName         string
+		schemaIndex       *schemasv1alpha4.MysqlTableIndex
+		expectedStatement string
+		expectedChange    ddlChange
+		wantErr           string
+	}{
+		{
+			name:      "columns",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns:  []string{"email", "name"},
+				IsUnique: true,
+			},
+			expectedStatement: "create unique index idx_users_email_name on users (email, name)",
+			expectedChange:    ddlChangeAddIndex,
+		},
+		{
+			name:      "prefix length and descending",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+					{Column: "name", Length: 20},
+					{Column: "created_at", Descending: true},
+				},
+			},
+			expectedStatement: "create index idx_users_name_created_at on users (name(20), created_at desc)",
+			expectedChange:    ddlChangeAddIndex,
+		},
+		{
+			name:      "functional key part",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Name: "idx_users_lower_email",
+				Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+					{Expression: "lower(email)"},
+				},
+			},
+			expectedStatement: "create index idx_users_lower_email on users ((lower(email)))",
+			expectedChange:    ddlChangeAddIndex,
+		},
+		{
+			name:      "fulltext with parser",
+			tableName: "posts",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns: []string{"title", "body"},
+				Type:    "fulltext",
+				Parser:  "ngram",
+			},
+			expectedStatement: "create fulltext index idx_posts_title_body on posts (title, body) with parser ngram",
+			expectedChange:    ddlChangeAddFulltextIndex,
+		},
+		{
+			name:      "spatial",
+			tableName: "places",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns: []string{"location"},
+				Type:    "SPATIAL",
+			},
+			expectedStatement: "create spatial index idx_places_location on places (location)",
+			expectedChange:    ddlChangeAddFulltextIndex,
+		},
+		{
+			name:      "index method",
+			tableName: "sessions",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns: []string{"token"},
+				Type:    "HASH",
+			},
+			expectedStatement: "create index idx_sessions_token on sessions (token) using hash",
+			expectedChange:    ddlChangeAddIndex,
+		},
+		{
+			name:      "unique fulltext",
+			tableName: "posts",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns:  []string{"body"},
+				Type:     "FULLTEXT",
+				IsUnique: true,
+			},
+			wantErr: "FULLTEXT index on table posts cannot be unique",
+		},
+		{
+			name:      "columns and parts",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns: []string{"email"},
+				Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+					{Column: "name"},
+				},
+			},
+			wantErr: "index on table users cannot have both columns and parts",
+		},
+		{
+			name:      "expression with a length",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Parts: []*schemasv1alpha4.MysqlTableIndexPart{
+					{Expression: "lower(email)", Length: 10},
+				},
+			},
+			wantErr: "index expression lower(email) on table users cannot have a length",
+		},
+		{
+			name:      "parser on a btree index",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.MysqlTableIndex{
+				Columns: []string{"email"},
+				Parser:  "ngram",
+			},
+			wantErr: "index on table users has a parser, only FULLTEXT indexes have a parser",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statement, err := AddIndexStatement(test.tableName, test.schemaIndex)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatement, statement)
+			assert.Equal(t, test.expectedChange, addIndexChange(test.schemaIndex))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package mysql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// ddlChange is the kind of change that an alter table statement makes. It decides which
+// online DDL algorithms are able to apply the statement.
+type ddlChange string
+
+const (
+	ddlChangeAddColumn      ddlChange = "add column"
+	ddlChangeDropColumn     ddlChange = "drop column"
+	ddlChangeColumnType     ddlChange = "change column type"
+	ddlChangeColumnNullable ddlChange = "change column nullability"
+	ddlChangeColumnMetadata ddlChange = "change column default or comment"
+	ddlChangeAddIndex       ddlChange = "add index"
+	ddlChangeDropIndex      ddlChange = "drop index"
+	ddlChangeAddPrimaryKey  ddlChange = "add primary key"
+	ddlChangeDropPrimaryKey ddlChange = "drop primary key"
+	ddlChangeAddForeignKey  ddlChange = "add foreign key"
+	ddlChangeDropForeignKey ddlChange = "drop foreign key"
+	ddlChangeConvertCharset ddlChange = "convert table character set"
+	ddlChangeTableComment   ddlChange = "change table comment"
+	ddlChangeEngine         ddlChange = "change storage engine"
+	ddlChangeRowFormat      ddlChange = "change row format"
+	ddlChangeAutoIncrement  ddlChange = "change auto increment"
+
+	// fulltext and spatial indexes are built in place, but without allowing concurrent writes
+	ddlChangeAddFulltextIndex ddlChange = "add fulltext or spatial index"
+
+	ddlChangePartitioning         ddlChange = "change table partitioning"
+	ddlChangeAddPartition         ddlChange = "add partition"
+	ddlChangeDropPartition        ddlChange = "drop partition"
+	ddlChangeReorganizePartition  ddlChange = "reorganize partition"
+	ddlChangeChangePartitionCount ddlChange = "change partition count"
+)
+
+// onlineDDLAlgorithms are the algorithms in order from least to most disruptive
+var onlineDDLAlgorithms = []string{"INSTANT", "INPLACE", "COPY"}
+
+var serverVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+// ddlStatement is a statement along with the kind of change that it makes
+type ddlStatement struct {
+	SQL    string
+	Change ddlChange
+}
+
+func ddlStatements(change ddlChange, statements []string) []ddlStatement {
+	result := []ddlStatement{}
+	for _, statement := range statements {
+		result = append(result, ddlStatement{SQL: statement, Change: change})
+	}
+	return result
+}
+
+// serverVersion is the version of the mysql or mariadb server that the plan is for
+type serverVersion struct {
+	MariaDB bool
+	Major   int
+	Minor   int
+	Patch   int
+}
+
+// parseServerVersion parses the result of select version(), such as 8.0.35 or 10.11.6-MariaDB
+func parseServerVersion(version string) (*serverVersion, error) {
+	matches := serverVersionRegex.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, errors.Errorf("unable to parse server version %q", version)
+	}
+
+	v := serverVersion{
+		MariaDB: strings.Contains(strings.ToLower(version), "mariadb"),
+	}
+	v.Major, _ = strconv.Atoi(matches[1])
+	v.Minor, _ = strconv.Atoi(matches[2])
+	v.Patch, _ = strconv.Atoi(matches[3])
+
+	return &v, nil
+}
+
+// getServerVersion reads the server version, it is stored as the engine version of the connection
+func (m *MysqlConnection) getServerVersion() (*serverVersion, error) {
+	if m.engineVersion == "" {
+		if err := m.db.QueryRow("select version()").Scan(&m.engineVersion); err != nil {
+			return nil, errors.Wrap(err, "failed to read version")
+		}
+	}
+
+	return parseServerVersion(m.engineVersion)
+}
+
+func (v serverVersion) atLeast(major int, minor int, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+func (v serverVersion) String() string {
+	if v.MariaDB {
+		return fmt.Sprintf("MariaDB %d.%d.%d", v.Major, v.Minor, v.Patch)
+	}
+	return fmt.Sprintf("MySQL %d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// leastAlgorithm returns the least disruptive algorithm that the server can apply the change with
+func leastAlgorithm(change ddlChange, version serverVersion) string {
+	switch change {
+	case ddlChangeAddColumn, ddlChangeColumnMetadata:
+		if version.MariaDB && version.atLeast(10, 3, 2) || !version.MariaDB && version.atLeast(8, 0, 12) {
+			return "INSTANT"
+		}
+		return "INPLACE"
+	case ddlChangeDropColumn:
+		if version.MariaDB && version.atLeast(10, 4, 0) || !version.MariaDB && version.atLeast(8, 0, 29) {
+			return "INSTANT"
+		}
+		return "INPLACE"
+	case ddlChangeColumnNullable, ddlChangeAddIndex, ddlChangeAddFulltextIndex, ddlChangeDropIndex, ddlChangeAddPrimaryKey,
+		ddlChangeDropForeignKey, ddlChangeTableComment, ddlChangeRowFormat, ddlChangeAutoIncrement,
+		ddlChangeAddPartition, ddlChangeDropPartition, ddlChangeReorganizePartition, ddlChangeChangePartitionCount:
+		return "INPLACE"
+	}
+
+	// column type changes, dropping the primary key, converting the character set, changing the engine,
+	// partitioning the table, and adding a foreign key while foreign_key_checks is enabled all copy the table
+	return "COPY"
+}
+
+func algorithmRank(algorithm string) int {
+	for i, a := range onlineDDLAlgorithms {
+		if a == algorithm {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateOnlineDDL returns an error when the policy is invalid, or when a statement cannot be applied
+// with the requested algorithm on this server
+func validateOnlineDDL(tableName string, onlineDDL *schemasv1alpha4.MysqlOnlineDDL, statements []ddlStatement, version serverVersion) error {
+	algorithm := strings.ToUpper(onlineDDL.Algorithm)
+	lock := strings.ToUpper(onlineDDL.Lock)
+
+	if algorithm != "" && algorithmRank(algorithm) < 0 {
+		return errors.Errorf("unsupported online ddl algorithm %q", onlineDDL.Algorithm)
+	}
+	if lock != "" && lock != "NONE" && lock != "SHARED" && lock != "EXCLUSIVE" {
+		return errors.Errorf("unsupported online ddl lock %q", onlineDDL.Lock)
+	}
+	if algorithm == "INSTANT" && lock != "" {
+		return errors.Errorf("lock %s cannot be used with the INSTANT algorithm", lock)
+	}
+	if algorithm == "INSTANT" && (version.MariaDB && !version.atLeast(10, 3, 2) || !version.MariaDB && !version.atLeast(8, 0, 12)) {
+		return errors.Errorf("the INSTANT algorithm is not supported by %s", version)
+	}
+
+	for _, statement := range statements {
+		if statement.Change == "" {
+			continue
+		}
+
+		least := leastAlgorithm(statement.Change, version)
+		if algorithm != "" && algorithmRank(algorithm) < algorithmRank(least) {
+			return errors.Errorf("cannot %s on table %s with algorithm %s on %s, the change requires algorithm %s",
+				statement.Change, tableName, algorithm, version, least)
+		}
+
+		effective := algorithm
+		if effective == "" {
+			effective = least
+		}
+		if lock == "NONE" && effective == "COPY" {
+			return errors.Errorf("cannot %s on table %s with lock NONE, the change requires algorithm COPY which does not allow concurrent writes",
+				statement.Change, tableName)
+		}
+		if lock == "NONE" && statement.Change == ddlChangeAddFulltextIndex {
+			return errors.Errorf("cannot %s on table %s with lock NONE, the index is built without allowing concurrent writes",
+				statement.Change, tableName)
+		}
+	}
+
+	return nil
+}
+
+// applyOnlineDDL adds the algorithm and lock clauses to the alter table and create index statements
+func applyOnlineDDL(onlineDDL *schemasv1alpha4.MysqlOnlineDDL, statements []ddlStatement) []string {
+	result := []string{}
+	for _, statement := range statements {
+		if onlineDDL == nil || statement.Change == "" {
+			result = append(result, statement.SQL)
+			continue
+		}
+
+		clauses := []string{}
+		if onlineDDL.Algorithm != "" {
+			clauses = append(clauses, fmt.Sprintf("algorithm=%s", strings.ToUpper(onlineDDL.Algorithm)))
+		}
+		if onlineDDL.Lock != "" {
+			clauses = append(clauses, fmt.Sprintf("lock=%s", strings.ToUpper(onlineDDL.Lock)))
+		}
+
+		switch {
+		case len(clauses) == 0:
+			result = append(result, statement.SQL)
+		case strings.HasPrefix(statement.SQL, "create "):
+			result = append(result, fmt.Sprintf("%s %s", statement.SQL, strings.Join(clauses, " ")))
+		case isPartitionChange(statement.Change):
+			// partition clauses must come after the other alter table options
+			result = append(result, partitionStatementWithClauses(statement, clauses))
+		default:
+			result = append(result, fmt.Sprintf("%s, %s", statement.SQL, strings.Join(clauses, ", ")))
+		}
+	}
+
+	return result
+}
+
+// modifyColumnChange returns the kind of change that modifying the existing column to the desired column makes
+func modifyColumnChange(existingColumn types.Column, column types.Column, defaultCharset string) ddlChange {
+	existingCharset, charset := existingColumn.Charset, column.Charset
+	if existingCharset == "" {
+		existingCharset = defaultCharset
+	}
+	if charset == "" {
+		charset = defaultCharset
+	}
+	if existingColumn.DataType != column.DataType || existingCharset != charset {
+		return ddlChangeColumnType
+	}
+	if column.Collation != "" && existingColumn.Collation != column.Collation {
+		return ddlChangeColumnType
+	}
+
+	existingAttributes, attributes := existingColumn.Attributes, column.Attributes
+	if existingAttributes == nil {
+		existingAttributes = &types.ColumnAttributes{}
+	}
+	if attributes == nil {
+		attributes = &types.ColumnAttributes{}
+	}
+	if !types.BoolsEqual(existingAttributes.AutoIncrement, attributes.AutoIncrement) {
+		return ddlChangeColumnType
+	}
+
+	existingConstraints, constraints := existingColumn.Constraints, column.Constraints
+	if existingConstraints == nil {
+		existingConstraints = &types.ColumnConstraints{}
+	}
+	if constraints == nil {
+		constraints = &types.ColumnConstraints{}
+	}
+	if !types.BoolsEqual(existingConstraints.NotNull, constraints.NotNull) {
+		return ddlChangeColumnNullable
+	}
+
+	return ddlChangeColumnMetadata
+}
@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseServerVersion(t *testing.T) {
+	version, err := parseServerVersion("8.0.35")
+	require.NoError(t, err)
+	assert.Equal(t, serverVersion{Major: 8, Minor: 0, Patch: 35}, *version)
+
+	version, err = parseServerVersion("10.11.6-MariaDB-1:10.11.6+maria~ubu2204")
+	require.NoError(t, err)
+	assert.Equal(t, serverVersion{MariaDB: true, Major: 10, Minor: 11, Patch: 6}, *version)
+
+	_, err = parseServerVersion("unknown")
+	assert.Error(t, err)
+}
+
+func Test_validateOnlineDDL(t *testing.T) {
+	mysql80 := serverVersion{Major: 8, Minor: 0, Patch: 35}
+	mysql57 := serverVersion{Major: 5, Minor: 7, Patch: 44}
+	mysql8020 := serverVersion{Major: 8, Minor: 0, Patch: 20}
+
+	tests := []struct {
+		name       string
+		onlineDDL  *schemasv1alpha4.MysqlOnlineDDL
+		statements []ddlStatement
+		version    serverVersion
+		wantErr    string
+	}{
+		{
+			name:      "instant add column",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INSTANT"},
+			statements: []ddlStatement{
+				{SQL: "alter table `t` add column `c` int", Change: ddlChangeAddColumn},
+			},
+			version: mysql80,
+		},
+		{
+			name:      "instant not supported by server",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INSTANT"},
+			statements: []ddlStatement{
+				{SQL: "alter table `t` add column `c` int", Change: ddlChangeAddColumn},
+			},
+			version: mysql57,
+			wantErr: "the INSTANT algorithm is not supported by MySQL 5.7.44",
+		},
+		{
+			name:      "instant drop column needs 8.0.29",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INSTANT"},
+			statements: []ddlStatement{
+				{SQL: "alter table `t` drop column `c`", Change: ddlChangeDropColumn},
+			},
+			version: mysql8020,
+			wantErr: "cannot drop column on table t with algorithm INSTANT on MySQL 8.0.20, the change requires algorithm INPLACE",
+		},
+		{
+			name:      "inplace index with no lock",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INPLACE", Lock: "NONE"},
+			statements: []ddlStatement{
+				{SQL: "create index idx_t_c on t (c)", Change: ddlChangeAddIndex},
+			},
+			version: mysql57,
+		},
+		{
+			name:      "inplace column type change",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INPLACE"},
+			statements: []ddlStatement{
+				{SQL: "alter table `t` modify column `c` bigint", Change: ddlChangeColumnType},
+			},
+			version: mysql80,
+			wantErr: "cannot change column type on table t with algorithm INPLACE on MySQL 8.0.35, the change requires algorithm COPY",
+		},
+		{
+			name:      "lock none with a copy",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Lock: "NONE"},
+			statements: []ddlStatement{
+				{SQL: "alter table `t` modify column `c` bigint", Change: ddlChangeColumnType},
+			},
+			version: mysql80,
+			wantErr: "cannot change column type on table t with lock NONE, the change requires algorithm COPY which does not allow concurrent writes",
+		},
+		{
+			name:      "lock none with a fulltext index",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Lock: "NONE"},
+			statements: []ddlStatement{
+				{SQL: "create fulltext index ft_body on posts (body)", Change: ddlChangeAddFulltextIndex},
+			},
+			version: mysql80,
+			wantErr: "cannot add fulltext or spatial index on table t with lock NONE, the index is built without allowing concurrent writes",
+		},
+		{
+			name:      "instant with lock",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INSTANT", Lock: "NONE"},
+			version:   mysql80,
+			wantErr:   "lock NONE cannot be used with the INSTANT algorithm",
+		},
+		{
+			name:      "statements that are not alters are ignored",
+			onlineDDL: &schemasv1alpha4.MysqlOnlineDDL{Algorithm: "INSTANT"},
+			statements: []ddlStatement{
+				{SQL: "update `t` set `c`=\"a\" where `c` is null"},
+			},
+			version: mysql80,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOnlineDDL("t", test.onlineDDL, test.statements, test.version)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_applyOnlineDDL(t *testing.T) {
+	statements := []ddlStatement{
+		{SQL: "alter table `t` add column `c` int", Change: ddlChangeAddColumn},
+		{SQL: "update `t` set `c`=\"1\" where `c` is null"},
+		{SQL: "create index idx_t_c on t (c)", Change: ddlChangeAddIndex},
+	}
+
+	assert.Equal(t, []string{
+		"alter table `t` add column `c` int",
+		"update `t` set `c`=\"1\" where `c` is null",
+		"create index idx_t_c on t (c)",
+	}, applyOnlineDDL(nil, statements))
+
+	assert.Equal(t, []string{
+		"alter table `t` add column `c` int, algorithm=INPLACE, lock=NONE",
+		"update `t` set `c`=\"1\" where `c` is null",
+		"create index idx_t_c on t (c) algorithm=INPLACE lock=NONE",
+	}, applyOnlineDDL(&schemasv1alpha4.MysqlOnlineDDL{Algorithm: "inplace", Lock: "none"}, statements))
+}
+
+func Test_modifyColumnChange(t *testing.T) {
+	notNull := true
+	nullable := false
+
+	existing := types.Column{Name: "c", DataType: "varchar (255)", Constraints: &types.ColumnConstraints{NotNull: &nullable}}
+
+	assert.Equal(t, ddlChangeColumnType, modifyColumnChange(existing, types.Column{Name: "c", DataType: "text"}, "utf8mb4"))
+	assert.Equal(t, ddlChangeColumnNullable, modifyColumnChange(existing, types.Column{Name: "c", DataType: "varchar (255)", Constraints: &types.ColumnConstraints{NotNull: &notNull}}, "utf8mb4"))
+
+	defaultValue := "a"
+	assert.Equal(t, ddlChangeColumnMetadata, modifyColumnChange(existing, types.Column{Name: "c", DataType: "varchar (255)", ColumnDefault: &defaultValue}, "utf8mb4"))
+}
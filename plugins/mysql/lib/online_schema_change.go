@@ -0,0 +1,417 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+const (
+	defaultOnlineCopyChunkSize            = 1000
+	defaultOnlineCopyThrottleMilliseconds = 100
+)
+
+var onlineCopyParamsRegex = regexp.MustCompile(`(\w+)=(\S+)`)
+
+// onlineCopyProgressTable stores the progress of the online copies in the database, so that it can be read
+// by any process while the copy runs, and after the process that ran it is gone
+const onlineCopyProgressTable = "_schemahero_osc"
+
+// onlineCopy is the parsed form of an online copy statement
+type onlineCopy struct {
+	Table                string
+	Shadow               string
+	Keys                 []string
+	ChunkSize            int
+	ThrottleMilliseconds int
+	// Insert is the statement that copies all rows, a chunk is copied by appending a where clause
+	Insert string
+}
+
+// requiresTableRebuild returns true when one of the statements rewrites the whole table
+// while holding a lock on it
+func requiresTableRebuild(statements []ddlStatement) bool {
+	for _, statement := range statements {
+		switch statement.Change {
+		case ddlChangeColumnType, ddlChangeAddPrimaryKey, ddlChangeDropPrimaryKey, ddlChangeConvertCharset,
+			ddlChangeEngine, ddlChangePartitioning:
+			return true
+		}
+	}
+	return false
+}
+
+func shadowTableName(tableName string) string {
+	return fmt.Sprintf("_%s_new", tableName)
+}
+
+func oldTableName(tableName string) string {
+	return fmt.Sprintf("_%s_old", tableName)
+}
+
+func onlineCopyTriggerName(tableName string, suffix string) string {
+	return fmt.Sprintf("_%s_%s", tableName, suffix)
+}
+
+// validateOnlineSchemaChange checks that the table can be copied into a shadow table. Triggers cannot
+// maintain foreign keys across the rename, so tables with foreign keys in either direction or with triggers are refused.
+func validateOnlineSchemaChange(tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema, existingPrimaryKey *types.KeyConstraint, existingForeignKeys []*types.ForeignKey, referencingTables []string, existingTriggers []*schemasv1alpha4.MysqlTableTrigger) error {
+	if len(mysqlTableSchema.ForeignKeys) > 0 || len(existingForeignKeys) > 0 {
+		return errors.Errorf("online schema change of table %s is not supported because it has foreign keys", tableName)
+	}
+	if len(mysqlTableSchema.Triggers) > 0 || len(existingTriggers) > 0 {
+		// the triggers would move to the old table when the tables are swapped
+		return errors.Errorf("online schema change of table %s is not supported because it has triggers", tableName)
+	}
+	if len(referencingTables) > 0 {
+		return errors.Errorf("online schema change of table %s is not supported because it is referenced by foreign keys from %s", tableName, strings.Join(referencingTables, ", "))
+	}
+
+	if existingPrimaryKey == nil || len(existingPrimaryKey.Columns) == 0 {
+		return errors.Errorf("online schema change of table %s requires the table to have a primary key", tableName)
+	}
+
+	for _, key := range existingPrimaryKey.Columns {
+		found := false
+		for _, column := range mysqlTableSchema.Columns {
+			if column.Name == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("online schema change of table %s requires primary key column %s to be kept", tableName, key)
+		}
+	}
+
+	return nil
+}
+
+// OnlineSchemaChangeStatements returns the statements that rebuild a table through a shadow table.
+// The shadow table is created with the desired schema, triggers copy writes to it while the existing
+// rows are copied in chunks, and the tables are swapped with a single rename.
+func OnlineSchemaChangeStatements(tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema, existingColumns []string, existingPrimaryKey []string) ([]string, error) {
+	shadowTable := shadowTableName(tableName)
+
+	// index names are generated from the table name, so they are named for the table that the shadow table becomes
+	shadowSchema := mysqlTableSchema.DeepCopy()
+	for _, index := range shadowSchema.Indexes {
+		if index.Name == "" {
+			index.Name = types.GenerateMysqlIndexName(tableName, index)
+		}
+	}
+
+	createStatements, err := CreateTableStatements(shadowTable, shadowSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create shadow table statement")
+	}
+
+	sharedColumns := []string{}
+	for _, column := range mysqlTableSchema.Columns {
+		for _, existingColumn := range existingColumns {
+			if column.Name == existingColumn {
+				sharedColumns = append(sharedColumns, fmt.Sprintf("`%s`", column.Name))
+				break
+			}
+		}
+	}
+	if len(sharedColumns) == 0 {
+		return nil, errors.Errorf("online schema change of table %s requires at least one column to be kept", tableName)
+	}
+
+	newValues := []string{}
+	for _, column := range sharedColumns {
+		newValues = append(newValues, "NEW."+column)
+	}
+
+	keyMatches := []string{}
+	for _, key := range existingPrimaryKey {
+		keyMatches = append(keyMatches, fmt.Sprintf("`%s`.`%s` <=> OLD.`%s`", shadowTable, key, key))
+	}
+
+	columnList := strings.Join(sharedColumns, ", ")
+	replaceStatement := fmt.Sprintf("replace into `%s` (%s) values (%s)", shadowTable, columnList, strings.Join(newValues, ", "))
+	deleteStatement := fmt.Sprintf("delete ignore from `%s` where %s", shadowTable, strings.Join(keyMatches, " and "))
+
+	chunkSize := defaultOnlineCopyChunkSize
+	throttle := defaultOnlineCopyThrottleMilliseconds
+	if mysqlTableSchema.OnlineSchemaChange != nil {
+		if mysqlTableSchema.OnlineSchemaChange.ChunkSize > 0 {
+			chunkSize = mysqlTableSchema.OnlineSchemaChange.ChunkSize
+		}
+		if mysqlTableSchema.OnlineSchemaChange.ThrottleMilliseconds > 0 {
+			throttle = mysqlTableSchema.OnlineSchemaChange.ThrottleMilliseconds
+		}
+	}
+
+	insertTrigger := onlineCopyTriggerName(tableName, "ins")
+	updateTrigger := onlineCopyTriggerName(tableName, "upd")
+	deleteTrigger := onlineCopyTriggerName(tableName, "del")
+
+	statements := []string{}
+	statements = append(statements, createStatements...)
+	statements = append(statements,
+		fmt.Sprintf("create trigger `%s` after insert on `%s` for each row %s", insertTrigger, tableName, replaceStatement),
+		fmt.Sprintf("create trigger `%s` after update on `%s` for each row\n%s", updateTrigger, tableName, taggedBody(fmt.Sprintf("begin\n%s;\n%s;\nend", deleteStatement, replaceStatement))),
+		fmt.Sprintf("create trigger `%s` after delete on `%s` for each row %s", deleteTrigger, tableName, deleteStatement),
+		fmt.Sprintf("%s table=%s shadow=%s key=%s chunkSize=%d throttleMilliseconds=%d */ insert ignore into `%s` (%s) select %s from `%s`",
+			types.OnlineCopyMarker, tableName, shadowTable, strings.Join(existingPrimaryKey, ","), chunkSize, throttle, shadowTable, columnList, columnList, tableName),
+		fmt.Sprintf("rename table `%s` to `%s`, `%s` to `%s`", tableName, oldTableName(tableName), shadowTable, tableName),
+		fmt.Sprintf("drop trigger if exists `%s`", insertTrigger),
+		fmt.Sprintf("drop trigger if exists `%s`", updateTrigger),
+		fmt.Sprintf("drop trigger if exists `%s`", deleteTrigger),
+		fmt.Sprintf("%s drop table `%s`", types.RebuildMarker, oldTableName(tableName)),
+	)
+
+	return statements, nil
+}
+
+// parseOnlineCopyStatement reads the parameters from the comment of an online copy statement
+func parseOnlineCopyStatement(statement string) (*onlineCopy, error) {
+	statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+	if !strings.HasPrefix(statement, types.OnlineCopyMarker) {
+		return nil, errors.New("statement is not an online copy")
+	}
+
+	end := strings.Index(statement, "*/")
+	if end < 0 {
+		return nil, errors.New("online copy statement has no closing comment")
+	}
+
+	c := onlineCopy{
+		ChunkSize:            defaultOnlineCopyChunkSize,
+		ThrottleMilliseconds: defaultOnlineCopyThrottleMilliseconds,
+		Insert:               strings.TrimSpace(statement[end+2:]),
+	}
+
+	params := statement[len(types.OnlineCopyMarker):end]
+	for _, match := range onlineCopyParamsRegex.FindAllStringSubmatch(params, -1) {
+		switch match[1] {
+		case "table":
+			c.Table = match[2]
+		case "shadow":
+			c.Shadow = match[2]
+		case "key":
+			c.Keys = strings.Split(match[2], ",")
+		case "chunkSize":
+			chunkSize, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse chunk size")
+			}
+			c.ChunkSize = chunkSize
+		case "throttleMilliseconds":
+			throttle, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse throttle")
+			}
+			c.ThrottleMilliseconds = throttle
+		}
+	}
+
+	if c.Table == "" || c.Shadow == "" || len(c.Keys) == 0 || c.Insert == "" {
+		return nil, errors.Errorf("online copy statement is missing parameters: %q", statement)
+	}
+	if c.ChunkSize <= 0 {
+		return nil, errors.Errorf("online copy chunk size must be positive, got %d", c.ChunkSize)
+	}
+
+	return &c, nil
+}
+
+// listReferencingTables returns the tables that have a foreign key to the table
+func (m *MysqlConnection) listReferencingTables(tableName string) ([]string, error) {
+	query := `select distinct TABLE_NAME from information_schema.KEY_COLUMN_USAGE
+where REFERENCED_TABLE_SCHEMA = ? and REFERENCED_TABLE_NAME = ? and TABLE_NAME != ?`
+	rows, err := m.db.Query(query, m.databaseName, tableName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query referencing tables")
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// buildOnlineSchemaChangeStatements validates the table against the database and returns the shadow table statements
+func buildOnlineSchemaChangeStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	primaryKey, err := m.GetTablePrimaryKey(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get primary key")
+	}
+
+	foreignKeys, err := m.ListTableForeignKeys(m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list foreign keys")
+	}
+
+	referencingTables, err := m.listReferencingTables(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list referencing tables")
+	}
+
+	triggers, err := listTableTriggers(m, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list triggers")
+	}
+
+	if err := validateOnlineSchemaChange(tableName, mysqlTableSchema, primaryKey, foreignKeys, referencingTables, triggers); err != nil {
+		return nil, err
+	}
+
+	existingColumns, err := m.GetTableSchema(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table schema")
+	}
+	existingColumnNames := []string{}
+	for _, column := range existingColumns {
+		existingColumnNames = append(existingColumnNames, column.Name)
+	}
+
+	return OnlineSchemaChangeStatements(tableName, mysqlTableSchema, existingColumnNames, primaryKey.Columns)
+}
+
+// setOnlineCopyProgress records the progress of the online copy of the table
+func setOnlineCopyProgress(m *MysqlConnection, tableName string, progress types.OnlineCopyProgress) error {
+	query := fmt.Sprintf("insert into `%s` (table_name, rows_copied, total_rows, completed) values (?, ?, ?, ?) on duplicate key update rows_copied = values(rows_copied), total_rows = values(total_rows), completed = values(completed)", onlineCopyProgressTable)
+	if _, err := m.db.Exec(query, tableName, progress.RowsCopied, progress.TotalRows, progress.Completed); err != nil {
+		return errors.Wrap(err, "failed to record online copy progress")
+	}
+
+	return nil
+}
+
+// getOnlineCopyProgress returns the progress of the last online copy of the table, or nil when the table
+// has not been copied
+func getOnlineCopyProgress(m *MysqlConnection, tableName string) (*types.OnlineCopyProgress, error) {
+	query := fmt.Sprintf("select rows_copied, total_rows, completed from `%s` where table_name = ?", onlineCopyProgressTable)
+	progress := types.OnlineCopyProgress{}
+	if err := m.db.QueryRow(query, tableName).Scan(&progress.RowsCopied, &progress.TotalRows, &progress.Completed); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		// 1146 is returned when the table does not exist, so no online copy has run in the database
+		if mysqlErr, ok := err.(*mysqldriver.MySQLError); ok && mysqlErr.Number == 1146 {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read online copy progress")
+	}
+
+	return &progress, nil
+}
+
+// runOnlineCopy copies the rows of a table into its shadow table in primary key order, one chunk at a time
+func runOnlineCopy(m *MysqlConnection, statement string) error {
+	c, err := parseOnlineCopyStatement(statement)
+	if err != nil {
+		return err
+	}
+
+	createProgressTable := fmt.Sprintf("create table if not exists `%s` (table_name varchar(64) not null, rows_copied bigint not null, total_rows bigint not null, completed boolean not null, updated_at timestamp not null default current_timestamp on update current_timestamp, primary key (table_name))", onlineCopyProgressTable)
+	if _, err := m.db.Exec(createProgressTable); err != nil {
+		return errors.Wrap(err, "failed to create online copy progress table")
+	}
+
+	progress := types.OnlineCopyProgress{}
+	row := m.db.QueryRow(`select coalesce(TABLE_ROWS, 0) from information_schema.TABLES where TABLE_SCHEMA = ? and TABLE_NAME = ?`, m.databaseName, c.Table)
+	if err := row.Scan(&progress.TotalRows); err != nil {
+		return errors.Wrap(err, "failed to estimate table rows")
+	}
+	if err := setOnlineCopyProgress(m, c.Table, progress); err != nil {
+		return err
+	}
+
+	quotedKeys := []string{}
+	placeholders := []string{}
+	for _, key := range c.Keys {
+		quotedKeys = append(quotedKeys, fmt.Sprintf("`%s`", key))
+		placeholders = append(placeholders, "?")
+	}
+	keyList := strings.Join(quotedKeys, ", ")
+	keyTuple := fmt.Sprintf("(%s)", keyList)
+	placeholderTuple := fmt.Sprintf("(%s)", strings.Join(placeholders, ", "))
+
+	// the insert selects from the table, so the chunk bounds are appended to its where clause
+	boundQuery := fmt.Sprintf("select %s from `%s` order by %s limit 1 offset %d", keyList, c.Table, keyList, c.ChunkSize-1)
+	nextBoundQuery := fmt.Sprintf("select %s from `%s` where %s > %s order by %s limit 1 offset %d", keyList, c.Table, keyTuple, placeholderTuple, keyList, c.ChunkSize-1)
+
+	var lowerBound []interface{}
+	for {
+		upperBound := make([]interface{}, len(c.Keys))
+		scanTo := make([]interface{}, len(c.Keys))
+		for i := range upperBound {
+			scanTo[i] = &upperBound[i]
+		}
+
+		var row *sql.Row
+		if lowerBound == nil {
+			row = m.db.QueryRow(boundQuery)
+		} else {
+			row = m.db.QueryRow(nextBoundQuery, lowerBound...)
+		}
+
+		lastChunk := false
+		if err := row.Scan(scanTo...); err != nil {
+			if err != sql.ErrNoRows {
+				return errors.Wrap(err, "failed to find chunk bound")
+			}
+			lastChunk = true
+		}
+
+		query := c.Insert
+		args := []interface{}{}
+		conditions := []string{}
+		if lowerBound != nil {
+			conditions = append(conditions, fmt.Sprintf("%s > %s", keyTuple, placeholderTuple))
+			args = append(args, lowerBound...)
+		}
+		if !lastChunk {
+			conditions = append(conditions, fmt.Sprintf("%s <= %s", keyTuple, placeholderTuple))
+			args = append(args, upperBound...)
+		}
+		if len(conditions) > 0 {
+			query = fmt.Sprintf("%s where %s", query, strings.Join(conditions, " and "))
+		}
+
+		result, err := m.db.ExecContext(context.Background(), query, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to copy chunk")
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "failed to get rows affected")
+		}
+		progress.RowsCopied += rowsAffected
+		if progress.RowsCopied > progress.TotalRows {
+			progress.TotalRows = progress.RowsCopied
+		}
+
+		if lastChunk {
+			progress.Completed = true
+			return setOnlineCopyProgress(m, c.Table, progress)
+		}
+
+		if err := setOnlineCopyProgress(m, c.Table, progress); err != nil {
+			return err
+		}
+		lowerBound = upperBound
+
+		time.Sleep(time.Duration(c.ThrottleMilliseconds) * time.Millisecond)
+	}
+}
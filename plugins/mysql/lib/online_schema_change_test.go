@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_requiresTableRebuild(t *testing.T) {
+	assert.False(t, requiresTableRebuild([]ddlStatement{
+		{SQL: "alter table `t` add column `a` int", Change: ddlChangeAddColumn},
+		{SQL: "create index idx_t_a on `t` (a)", Change: ddlChangeAddIndex},
+	}))
+	assert.True(t, requiresTableRebuild([]ddlStatement{
+		{SQL: "alter table `t` add column `a` int", Change: ddlChangeAddColumn},
+		{SQL: "alter table `t` modify column `b` bigint", Change: ddlChangeColumnType},
+	}))
+	assert.True(t, requiresTableRebuild([]ddlStatement{
+		{SQL: "alter table `t` drop primary key", Change: ddlChangeDropPrimaryKey},
+	}))
+}
+
+func Test_validateOnlineSchemaChange(t *testing.T) {
+	schema := &schemasv1alpha4.MysqlTableSchema{
+		Columns: []*schemasv1alpha4.MysqlTableColumn{
+			{Name: "id", Type: "int"},
+			{Name: "name", Type: "varchar(255)"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		schema            *schemasv1alpha4.MysqlTableSchema
+		primaryKey        *types.KeyConstraint
+		foreignKeys       []*types.ForeignKey
+		referencingTables []string
+		triggers          []*schemasv1alpha4.MysqlTableTrigger
+		wantErr           string
+	}{
+		{
+			name:       "valid",
+			schema:     schema,
+			primaryKey: &types.KeyConstraint{IsPrimary: true, Columns: []string{"id"}},
+		},
+		{
+			name:    "no primary key",
+			schema:  schema,
+			wantErr: "requires the table to have a primary key",
+		},
+		{
+			name:       "primary key column dropped",
+			schema:     schema,
+			primaryKey: &types.KeyConstraint{IsPrimary: true, Columns: []string{"uuid"}},
+			wantErr:    "requires primary key column uuid to be kept",
+		},
+		{
+			name:        "existing foreign key",
+			schema:      schema,
+			primaryKey:  &types.KeyConstraint{IsPrimary: true, Columns: []string{"id"}},
+			foreignKeys: []*types.ForeignKey{{Name: "fk"}},
+			wantErr:     "has foreign keys",
+		},
+		{
+			name:              "referenced by another table",
+			schema:            schema,
+			primaryKey:        &types.KeyConstraint{IsPrimary: true, Columns: []string{"id"}},
+			referencingTables: []string{"orders"},
+			wantErr:           "referenced by foreign keys from orders",
+		},
+		{
+			name:       "existing trigger",
+			schema:     schema,
+			primaryKey: &types.KeyConstraint{IsPrimary: true, Columns: []string{"id"}},
+			triggers:   []*schemasv1alpha4.MysqlTableTrigger{{Name: "users_audit"}},
+			wantErr:    "has triggers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOnlineSchemaChange("users", test.schema, test.primaryKey, test.foreignKeys, test.referencingTables, test.triggers)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func Test_OnlineSchemaChangeStatements(t *testing.T) {
+	schema := &schemasv1alpha4.MysqlTableSchema{
+		PrimaryKey: []string{"id"},
+		Columns: []*schemasv1alpha4.MysqlTableColumn{
+			{Name: "id", Type: "int"},
+			{Name: "total", Type: "bigint"},
+			{Name: "created_at", Type: "datetime"},
+		},
+		OnlineSchemaChange: &schemasv1alpha4.MysqlOnlineSchemaChange{
+			ChunkSize: 500,
+		},
+	}
+
+	statements, err := OnlineSchemaChangeStatements("orders", schema, []string{"id", "total", "legacy"}, []string{"id"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"create table `_orders_new` (`id` int (11), `total` bigint (20), `created_at` datetime, primary key (`id`))",
+		"create trigger `_orders_ins` after insert on `orders` for each row replace into `_orders_new` (`id`, `total`) values (NEW.`id`, NEW.`total`)",
+		"create trigger `_orders_upd` after update on `orders` for each row\n" +
+			"-- Function body follows\n" +
+			"begin\n" +
+			"delete ignore from `_orders_new` where `_orders_new`.`id` <=> OLD.`id`;\n" +
+			"replace into `_orders_new` (`id`, `total`) values (NEW.`id`, NEW.`total`);\n" +
+			"end\n" +
+			"-- Function body follows",
+		"create trigger `_orders_del` after delete on `orders` for each row delete ignore from `_orders_new` where `_orders_new`.`id` <=> OLD.`id`",
+		"/* schemahero:online-copy table=orders shadow=_orders_new key=id chunkSize=500 throttleMilliseconds=100 */ insert ignore into `_orders_new` (`id`, `total`) select `id`, `total` from `orders`",
+		"rename table `orders` to `_orders_old`, `_orders_new` to `orders`",
+		"drop trigger if exists `_orders_ins`",
+		"drop trigger if exists `_orders_upd`",
+		"drop trigger if exists `_orders_del`",
+		types.RebuildMarker + " drop table `_orders_old`",
+	}, statements)
+	assert.True(t, types.RequiresOnlineCopy(statements))
+	assert.Equal(t, []string{"orders"}, types.OnlineCopyTables(statements))
+}
+
+func Test_parseOnlineCopyStatement(t *testing.T) {
+	c, err := parseOnlineCopyStatement("/* schemahero:online-copy table=orders shadow=_orders_new key=tenant_id,id chunkSize=500 throttleMilliseconds=20 */ insert ignore into `_orders_new` (`id`) select `id` from `orders`;")
+	require.NoError(t, err)
+	assert.Equal(t, &onlineCopy{
+		Table:                "orders",
+		Shadow:               "_orders_new",
+		Keys:                 []string{"tenant_id", "id"},
+		ChunkSize:            500,
+		ThrottleMilliseconds: 20,
+		Insert:               "insert ignore into `_orders_new` (`id`) select `id` from `orders`",
+	}, c)
+
+	_, err = parseOnlineCopyStatement("insert into `orders` values (1)")
+	assert.Error(t, err)
+
+	_, err = parseOnlineCopyStatement("/* schemahero:online-copy table=orders */ insert ignore into `_orders_new` select * from `orders`")
+	assert.Error(t, err)
+}
@@ -0,0 +1,300 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var whitespaceAroundCommaRegex = regexp.MustCompile(`\s*,\s*`)
+
+// existingPartitioning is the partitioning of a table read from information_schema.PARTITIONS
+type existingPartitioning struct {
+	Method     string
+	Expression string
+	Partitions []*schemasv1alpha4.MysqlTablePartition
+}
+
+func isRangePartitioning(method string) bool {
+	return strings.HasPrefix(strings.ToUpper(method), "RANGE")
+}
+
+func isListPartitioning(method string) bool {
+	return strings.HasPrefix(strings.ToUpper(method), "LIST")
+}
+
+func isPartitionChange(change ddlChange) bool {
+	switch change {
+	case ddlChangePartitioning, ddlChangeAddPartition, ddlChangeDropPartition, ddlChangeReorganizePartition, ddlChangeChangePartitionCount:
+		return true
+	}
+	return false
+}
+
+// partitionStatementWithClauses places the algorithm and lock clauses in front of the partition
+// clause of the statement, mysql does not accept them after it
+func partitionStatementWithClauses(statement ddlStatement, clauses []string) string {
+	prefix := "alter table `"
+	end := strings.Index(statement.SQL[len(prefix):], "` ")
+	if !strings.HasPrefix(statement.SQL, prefix) || end < 0 {
+		return statement.SQL
+	}
+	end += len(prefix) + 1
+
+	separator := ", "
+	if statement.Change == ddlChangePartitioning {
+		// partition by and remove partitioning follow the other options without a comma
+		separator = " "
+	}
+
+	return fmt.Sprintf("%s %s%s%s", statement.SQL[:end], strings.Join(clauses, ", "), separator, statement.SQL[end+1:])
+}
+
+func validatePartitioning(partitioning *schemasv1alpha4.MysqlTablePartitioning) error {
+	method := strings.ToUpper(partitioning.Type)
+	switch method {
+	case "RANGE", "LIST", "HASH", "LINEAR HASH":
+		if partitioning.Expression == "" {
+			return errors.Errorf("%s partitioning requires an expression", method)
+		}
+	case "RANGE COLUMNS", "LIST COLUMNS":
+		if len(partitioning.Columns) == 0 {
+			return errors.Errorf("%s partitioning requires columns", method)
+		}
+	case "KEY", "LINEAR KEY":
+	default:
+		return errors.Errorf("unsupported partitioning type %q", partitioning.Type)
+	}
+
+	if isRangePartitioning(method) || isListPartitioning(method) {
+		if len(partitioning.Partitions) == 0 {
+			return errors.Errorf("%s partitioning requires partitions", method)
+		}
+		if partitioning.Count > 0 {
+			return errors.Errorf("%s partitioning does not support a partition count", method)
+		}
+	} else if len(partitioning.Partitions) > 0 {
+		return errors.Errorf("%s partitioning uses a partition count instead of partitions", method)
+	}
+
+	return nil
+}
+
+func partitioningExpression(partitioning *schemasv1alpha4.MysqlTablePartitioning) string {
+	if partitioning.Expression != "" {
+		return partitioning.Expression
+	}
+
+	columns := []string{}
+	for _, column := range partitioning.Columns {
+		columns = append(columns, fmt.Sprintf("`%s`", column))
+	}
+	return strings.Join(columns, ", ")
+}
+
+func partitionCount(partitioning *schemasv1alpha4.MysqlTablePartitioning) int {
+	if partitioning.Count > 0 {
+		return partitioning.Count
+	}
+	return 1
+}
+
+func partitionDefinition(method string, partition *schemasv1alpha4.MysqlTablePartition) string {
+	if isListPartitioning(method) {
+		return fmt.Sprintf("partition `%s` values in (%s)", partition.Name, partition.Values)
+	}
+	return fmt.Sprintf("partition `%s` values less than (%s)", partition.Name, partition.Values)
+}
+
+func partitionDefinitions(method string, partitions []*schemasv1alpha4.MysqlTablePartition) string {
+	definitions := []string{}
+	for _, partition := range partitions {
+		definitions = append(definitions, partitionDefinition(method, partition))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(definitions, ", "))
+}
+
+// PartitionByClause returns the partition by clause for a table
+func PartitionByClause(partitioning *schemasv1alpha4.MysqlTablePartitioning) string {
+	method := strings.ToUpper(partitioning.Type)
+	clause := fmt.Sprintf("partition by %s (%s)", strings.ToLower(method), partitioningExpression(partitioning))
+
+	if isRangePartitioning(method) || isListPartitioning(method) {
+		return fmt.Sprintf("%s %s", clause, partitionDefinitions(method, partitioning.Partitions))
+	}
+
+	return fmt.Sprintf("%s partitions %d", clause, partitionCount(partitioning))
+}
+
+func normalizePartitionExpression(expression string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(expression, "`", ""))
+	return strings.Join(strings.Fields(normalized), "")
+}
+
+func normalizePartitionValues(values string) string {
+	normalized := whitespaceAroundCommaRegex.ReplaceAllString(strings.TrimSpace(values), ",")
+	if strings.EqualFold(normalized, "maxvalue") {
+		return "MAXVALUE"
+	}
+	return normalized
+}
+
+func partitionsEqual(a *schemasv1alpha4.MysqlTablePartition, b *schemasv1alpha4.MysqlTablePartition) bool {
+	return a.Name == b.Name && normalizePartitionValues(a.Values) == normalizePartitionValues(b.Values)
+}
+
+// PartitionStatements returns the statements to move the table from the existing partitioning to the desired
+// partitioning. RANGE and LIST partitions are added, dropped and reorganized in place, HASH and KEY partitions
+// are added or coalesced, and any other change repartitions the table.
+func PartitionStatements(tableName string, desired *schemasv1alpha4.MysqlTablePartitioning, existing *existingPartitioning) ([]ddlStatement, error) {
+	if desired == nil {
+		return []ddlStatement{}, nil
+	}
+
+	if desired.Type == "" {
+		if existing == nil {
+			return []ddlStatement{}, nil
+		}
+		return []ddlStatement{
+			{SQL: fmt.Sprintf("alter table `%s` remove partitioning", tableName), Change: ddlChangePartitioning},
+		}, nil
+	}
+
+	if err := validatePartitioning(desired); err != nil {
+		return nil, err
+	}
+
+	method := strings.ToUpper(desired.Type)
+	repartition := []ddlStatement{
+		{SQL: fmt.Sprintf("alter table `%s` %s", tableName, PartitionByClause(desired)), Change: ddlChangePartitioning},
+	}
+
+	if existing == nil {
+		return repartition, nil
+	}
+	if strings.ToUpper(existing.Method) != method {
+		return repartition, nil
+	}
+	if normalizePartitionExpression(existing.Expression) != normalizePartitionExpression(partitioningExpression(desired)) {
+		return repartition, nil
+	}
+
+	if !isRangePartitioning(method) && !isListPartitioning(method) {
+		count := partitionCount(desired)
+		existingCount := len(existing.Partitions)
+		if count > existingCount {
+			return []ddlStatement{
+				{SQL: fmt.Sprintf("alter table `%s` add partition partitions %d", tableName, count-existingCount), Change: ddlChangeChangePartitionCount},
+			}, nil
+		} else if count < existingCount {
+			return []ddlStatement{
+				{SQL: fmt.Sprintf("alter table `%s` coalesce partition %d", tableName, existingCount-count), Change: ddlChangeChangePartitionCount},
+			}, nil
+		}
+		return []ddlStatement{}, nil
+	}
+
+	statements := []ddlStatement{}
+
+	desiredNames := map[string]bool{}
+	for _, partition := range desired.Partitions {
+		desiredNames[partition.Name] = true
+	}
+
+	// partitions that are no longer in the schema are dropped, along with their rows
+	dropped := []string{}
+	remaining := []*schemasv1alpha4.MysqlTablePartition{}
+	for _, partition := range existing.Partitions {
+		if desiredNames[partition.Name] {
+			remaining = append(remaining, partition)
+		} else {
+			dropped = append(dropped, fmt.Sprintf("`%s`", partition.Name))
+		}
+	}
+	if len(dropped) > 0 {
+		statements = append(statements, ddlStatement{
+			SQL:    fmt.Sprintf("alter table `%s` drop partition %s", tableName, strings.Join(dropped, ", ")),
+			Change: ddlChangeDropPartition,
+		})
+	}
+
+	diverged := 0
+	for diverged < len(remaining) && diverged < len(desired.Partitions) && partitionsEqual(remaining[diverged], desired.Partitions[diverged]) {
+		diverged++
+	}
+
+	if diverged == len(remaining) {
+		// the existing partitions are unchanged, new partitions are added after them
+		if diverged < len(desired.Partitions) {
+			statements = append(statements, ddlStatement{
+				SQL:    fmt.Sprintf("alter table `%s` add partition %s", tableName, partitionDefinitions(method, desired.Partitions[diverged:])),
+				Change: ddlChangeAddPartition,
+			})
+		}
+		return statements, nil
+	}
+
+	// partitions were changed or inserted between existing partitions, so the partitions from the
+	// first difference onwards are reorganized into the desired partitions
+	reorganized := []string{}
+	for _, partition := range remaining[diverged:] {
+		reorganized = append(reorganized, fmt.Sprintf("`%s`", partition.Name))
+	}
+	statements = append(statements, ddlStatement{
+		SQL: fmt.Sprintf("alter table `%s` reorganize partition %s into %s",
+			tableName, strings.Join(reorganized, ", "), partitionDefinitions(method, desired.Partitions[diverged:])),
+		Change: ddlChangeReorganizePartition,
+	})
+
+	return statements, nil
+}
+
+// readTablePartitioning returns the partitioning of a table, or nil when the table is not partitioned
+func readTablePartitioning(m *MysqlConnection, tableName string) (*existingPartitioning, error) {
+	query := `select PARTITION_NAME, PARTITION_METHOD, PARTITION_EXPRESSION, PARTITION_DESCRIPTION
+from information_schema.PARTITIONS
+where TABLE_SCHEMA = ? and TABLE_NAME = ? and PARTITION_NAME is not null
+order by PARTITION_ORDINAL_POSITION`
+	rows, err := m.db.Query(query, m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query partitions")
+	}
+	defer rows.Close()
+
+	var partitioning *existingPartitioning
+	for rows.Next() {
+		var name, method string
+		var expression, description sql.NullString
+		if err := rows.Scan(&name, &method, &expression, &description); err != nil {
+			return nil, errors.Wrap(err, "failed to scan partition")
+		}
+
+		if partitioning == nil {
+			partitioning = &existingPartitioning{
+				Method:     method,
+				Expression: expression.String,
+			}
+		}
+		partitioning.Partitions = append(partitioning.Partitions, &schemasv1alpha4.MysqlTablePartition{
+			Name:   name,
+			Values: description.String,
+		})
+	}
+
+	return partitioning, nil
+}
+
+// buildPartitionStatements will return the statements needed to change the partitioning of a table.
+// Partitioning that is not set in the schema is not managed.
+func buildPartitionStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]ddlStatement, error) {
+	existing, err := readTablePartitioning(m, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read table partitioning")
+	}
+
+	return PartitionStatements(tableName, mysqlTableSchema.Partitioning, existing)
+}
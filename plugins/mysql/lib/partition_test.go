@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionStatements(t *testing.T) {
+	yearly := func(partitions ...*schemasv1alpha4.MysqlTablePartition) *schemasv1alpha4.MysqlTablePartitioning {
+		return &schemasv1alpha4.MysqlTablePartitioning{
+			Type:       "RANGE",
+			Expression: "year(created_at)",
+			Partitions: partitions,
+		}
+	}
+	existingYearly := func(partitions ...*schemasv1alpha4.MysqlTablePartition) *existingPartitioning {
+		return &existingPartitioning{
+			Method:     "RANGE",
+			Expression: "year(`created_at`)",
+			Partitions: partitions,
+		}
+	}
+	p2023 := &schemasv1alpha4.MysqlTablePartition{Name: "p2023", Values: "2024"}
+	p2024 := &schemasv1alpha4.MysqlTablePartition{Name: "p2024", Values: "2025"}
+	p2025 := &schemasv1alpha4.MysqlTablePartition{Name: "p2025", Values: "2026"}
+	pmax := &schemasv1alpha4.MysqlTablePartition{Name: "pmax", Values: "MAXVALUE"}
+
+	tests := []struct {
+		name     string
+		desired  *schemasv1alpha4.MysqlTablePartitioning
+		existing *existingPartitioning
+		expected []ddlStatement
+		wantErr  bool
+	}{
+		{
+			name:     "not managed",
+			existing: existingYearly(p2023, pmax),
+			expected: []ddlStatement{},
+		},
+		{
+			name:     "remove partitioning",
+			desired:  &schemasv1alpha4.MysqlTablePartitioning{},
+			existing: existingYearly(p2023, pmax),
+			expected: []ddlStatement{
+				{SQL: "alter table `t` remove partitioning", Change: ddlChangePartitioning},
+			},
+		},
+		{
+			name:    "partition an existing table",
+			desired: yearly(p2023, pmax),
+			expected: []ddlStatement{
+				{SQL: "alter table `t` partition by range (year(created_at)) (partition `p2023` values less than (2024), partition `pmax` values less than (MAXVALUE))", Change: ddlChangePartitioning},
+			},
+		},
+		{
+			name:     "unchanged",
+			desired:  yearly(p2023, pmax),
+			existing: existingYearly(p2023, &schemasv1alpha4.MysqlTablePartition{Name: "pmax", Values: "maxvalue"}),
+			expected: []ddlStatement{},
+		},
+		{
+			name:     "add partitions at the end",
+			desired:  yearly(p2023, p2024, p2025),
+			existing: existingYearly(p2023),
+			expected: []ddlStatement{
+				{SQL: "alter table `t` add partition (partition `p2024` values less than (2025), partition `p2025` values less than (2026))", Change: ddlChangeAddPartition},
+			},
+		},
+		{
+			name:     "drop the oldest and split the last partition",
+			desired:  yearly(p2024, p2025, pmax),
+			existing: existingYearly(p2023, p2024, pmax),
+			expected: []ddlStatement{
+				{SQL: "alter table `t` drop partition `p2023`", Change: ddlChangeDropPartition},
+				{SQL: "alter table `t` reorganize partition `pmax` into (partition `p2025` values less than (2026), partition `pmax` values less than (MAXVALUE))", Change: ddlChangeReorganizePartition},
+			},
+		},
+		{
+			name:     "expression changed",
+			desired:  &schemasv1alpha4.MysqlTablePartitioning{Type: "RANGE COLUMNS", Columns: []string{"created_at"}, Partitions: []*schemasv1alpha4.MysqlTablePartition{{Name: "p0", Values: "'2024-01-01'"}}},
+			existing: existingYearly(p2023),
+			expected: []ddlStatement{
+				{SQL: "alter table `t` partition by range columns (`created_at`) (partition `p0` values less than ('2024-01-01'))", Change: ddlChangePartitioning},
+			},
+		},
+		{
+			name:     "list values",
+			desired:  &schemasv1alpha4.MysqlTablePartitioning{Type: "LIST", Expression: "region", Partitions: []*schemasv1alpha4.MysqlTablePartition{{Name: "east", Values: "1, 2"}, {Name: "west", Values: "3, 4"}}},
+			existing: &existingPartitioning{Method: "LIST", Expression: "`region`", Partitions: []*schemasv1alpha4.MysqlTablePartition{{Name: "east", Values: "1,2"}}},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` add partition (partition `west` values in (3, 4))", Change: ddlChangeAddPartition},
+			},
+		},
+		{
+			name:     "more hash partitions",
+			desired:  &schemasv1alpha4.MysqlTablePartitioning{Type: "HASH", Expression: "id", Count: 8},
+			existing: &existingPartitioning{Method: "HASH", Expression: "`id`", Partitions: make([]*schemasv1alpha4.MysqlTablePartition, 4)},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` add partition partitions 4", Change: ddlChangeChangePartitionCount},
+			},
+		},
+		{
+			name:     "fewer key partitions",
+			desired:  &schemasv1alpha4.MysqlTablePartitioning{Type: "KEY", Columns: []string{"id"}, Count: 2},
+			existing: &existingPartitioning{Method: "KEY", Expression: "`id`", Partitions: make([]*schemasv1alpha4.MysqlTablePartition, 4)},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` coalesce partition 2", Change: ddlChangeChangePartitionCount},
+			},
+		},
+		{
+			name:    "range without partitions",
+			desired: &schemasv1alpha4.MysqlTablePartitioning{Type: "RANGE", Expression: "id"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := PartitionStatements("t", test.desired, test.existing)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_partitionStatementWithClauses(t *testing.T) {
+	assert.Equal(t, "alter table `t` algorithm=INPLACE, lock=SHARED, add partition (partition `p1` values less than (10))",
+		partitionStatementWithClauses(ddlStatement{SQL: "alter table `t` add partition (partition `p1` values less than (10))", Change: ddlChangeAddPartition}, []string{"algorithm=INPLACE", "lock=SHARED"}))
+	assert.Equal(t, "alter table `t` algorithm=COPY remove partitioning",
+		partitionStatementWithClauses(ddlStatement{SQL: "alter table `t` remove partitioning", Change: ddlChangePartitioning}, []string{"algorithm=COPY"}))
+}
@@ -0,0 +1,152 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// existingTableOptions are the options of a table read from information_schema.TABLES
+type existingTableOptions struct {
+	Engine        string
+	RowFormat     string
+	CreateOptions string
+	// AutoIncrement is nil when the table has no auto increment column
+	AutoIncrement *int64
+}
+
+// createOption returns the value of an option that was set explicitly when the table was created,
+// such as row_format or key_block_size
+func (o existingTableOptions) createOption(name string) string {
+	for _, option := range strings.Fields(o.CreateOptions) {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], name) {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
+// tableOptionsClause returns the table options that are set on a new table
+func tableOptionsClause(tableSchema *schemasv1alpha4.MysqlTableSchema) string {
+	options := []string{}
+	if tableSchema.Engine != "" {
+		options = append(options, fmt.Sprintf("engine=%s", tableSchema.Engine))
+	}
+	if tableSchema.RowFormat != "" {
+		options = append(options, fmt.Sprintf("row_format=%s", strings.ToUpper(tableSchema.RowFormat)))
+	}
+	if tableSchema.KeyBlockSize != nil {
+		options = append(options, fmt.Sprintf("key_block_size=%d", *tableSchema.KeyBlockSize))
+	}
+	if tableSchema.AutoIncrement != nil {
+		options = append(options, fmt.Sprintf("auto_increment=%d", *tableSchema.AutoIncrement))
+	}
+	return strings.Join(options, " ")
+}
+
+// TableOptionsStatements returns the statements to change the engine, row format, key block size and
+// auto increment of an existing table. Options that are not set in the schema are not managed.
+func TableOptionsStatements(tableName string, tableSchema *schemasv1alpha4.MysqlTableSchema, existing existingTableOptions) []ddlStatement {
+	statements := []ddlStatement{}
+
+	if tableSchema.Engine != "" && !strings.EqualFold(tableSchema.Engine, existing.Engine) {
+		statements = append(statements, ddlStatement{
+			SQL:    fmt.Sprintf("alter table `%s` engine=%s", tableName, tableSchema.Engine),
+			Change: ddlChangeEngine,
+		})
+	}
+
+	if tableSchema.RowFormat != "" {
+		rowFormat := strings.ToUpper(tableSchema.RowFormat)
+		explicitRowFormat := strings.ToUpper(existing.createOption("row_format"))
+
+		changed := false
+		if rowFormat == "DEFAULT" {
+			changed = explicitRowFormat != "" && explicitRowFormat != "DEFAULT"
+		} else {
+			changed = rowFormat != explicitRowFormat && rowFormat != strings.ToUpper(existing.RowFormat)
+		}
+		if changed {
+			statements = append(statements, ddlStatement{
+				SQL:    fmt.Sprintf("alter table `%s` row_format=%s", tableName, rowFormat),
+				Change: ddlChangeRowFormat,
+			})
+		}
+	}
+
+	if tableSchema.KeyBlockSize != nil {
+		existingKeyBlockSize := 0
+		if value := existing.createOption("key_block_size"); value != "" {
+			existingKeyBlockSize, _ = strconv.Atoi(value)
+		}
+		if *tableSchema.KeyBlockSize != existingKeyBlockSize {
+			statements = append(statements, ddlStatement{
+				SQL:    fmt.Sprintf("alter table `%s` key_block_size=%d", tableName, *tableSchema.KeyBlockSize),
+				Change: ddlChangeRowFormat,
+			})
+		}
+	}
+
+	if tableSchema.AutoIncrement != nil && existing.AutoIncrement != nil && *existing.AutoIncrement < *tableSchema.AutoIncrement {
+		statements = append(statements, ddlStatement{
+			SQL:    fmt.Sprintf("alter table `%s` auto_increment=%d", tableName, *tableSchema.AutoIncrement),
+			Change: ddlChangeAutoIncrement,
+		})
+	}
+
+	return statements
+}
+
+func readTableOptions(m *MysqlConnection, tableName string) (*existingTableOptions, error) {
+	query := `select ENGINE, ROW_FORMAT, CREATE_OPTIONS, AUTO_INCREMENT from information_schema.TABLES
+where TABLE_SCHEMA = ? and TABLE_NAME = ?`
+	row := m.db.QueryRow(query, m.databaseName, tableName)
+
+	var engine, rowFormat, createOptions sql.NullString
+	var autoIncrement sql.NullInt64
+	if err := row.Scan(&engine, &rowFormat, &createOptions, &autoIncrement); err != nil {
+		return nil, errors.Wrap(err, "failed to read existing table options")
+	}
+
+	existing := existingTableOptions{
+		Engine:        engine.String,
+		RowFormat:     rowFormat.String,
+		CreateOptions: createOptions.String,
+	}
+	if autoIncrement.Valid {
+		existing.AutoIncrement = &autoIncrement.Int64
+	}
+
+	return &existing, nil
+}
+
+// buildTableOptionsStatements will return the statements needed to change the options
+// of a TABLE: charset and collation, comment, engine, row format, key block size and auto increment
+func buildTableOptionsStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]ddlStatement, error) {
+	statements := []ddlStatement{}
+
+	charsetAndCollationStatements, err := buildTableCharsetAndCollationStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build table charset and collation statements")
+	}
+	statements = append(statements, ddlStatements(ddlChangeConvertCharset, charsetAndCollationStatements)...)
+
+	tableCommentStatements, err := buildTableCommentStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build table comment statements")
+	}
+	statements = append(statements, ddlStatements(ddlChangeTableComment, tableCommentStatements)...)
+
+	existing, err := readTableOptions(m, tableName)
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, TableOptionsStatements(tableName, mysqlTableSchema, *existing)...)
+
+	return statements, nil
+}
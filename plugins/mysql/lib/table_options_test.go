@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableOptionsStatements(t *testing.T) {
+	keyBlockSize := 8
+	autoIncrement := int64(1000)
+	existingAutoIncrement := int64(42)
+	laterAutoIncrement := int64(5000)
+
+	tests := []struct {
+		name        string
+		tableSchema *schemasv1alpha4.MysqlTableSchema
+		existing    existingTableOptions
+		expected    []ddlStatement
+	}{
+		{
+			name:        "options not managed",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{},
+			existing:    existingTableOptions{Engine: "MyISAM", RowFormat: "Fixed", AutoIncrement: &existingAutoIncrement},
+			expected:    []ddlStatement{},
+		},
+		{
+			name:        "unchanged",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{Engine: "innodb", RowFormat: "DYNAMIC"},
+			existing:    existingTableOptions{Engine: "InnoDB", RowFormat: "Dynamic"},
+			expected:    []ddlStatement{},
+		},
+		{
+			name:        "change engine",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{Engine: "InnoDB"},
+			existing:    existingTableOptions{Engine: "MyISAM"},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` engine=InnoDB", Change: ddlChangeEngine},
+			},
+		},
+		{
+			name:        "compress table",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{RowFormat: "compressed", KeyBlockSize: &keyBlockSize},
+			existing:    existingTableOptions{Engine: "InnoDB", RowFormat: "Dynamic"},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` row_format=COMPRESSED", Change: ddlChangeRowFormat},
+				{SQL: "alter table `t` key_block_size=8", Change: ddlChangeRowFormat},
+			},
+		},
+		{
+			name:        "compressed table is unchanged",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{RowFormat: "COMPRESSED", KeyBlockSize: &keyBlockSize},
+			existing:    existingTableOptions{Engine: "InnoDB", RowFormat: "Compressed", CreateOptions: "row_format=COMPRESSED KEY_BLOCK_SIZE=8"},
+			expected:    []ddlStatement{},
+		},
+		{
+			name:        "reset row format to default",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{RowFormat: "DEFAULT"},
+			existing:    existingTableOptions{Engine: "InnoDB", RowFormat: "Compact", CreateOptions: "row_format=COMPACT"},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` row_format=DEFAULT", Change: ddlChangeRowFormat},
+			},
+		},
+		{
+			name:        "move auto increment forward",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{AutoIncrement: &autoIncrement},
+			existing:    existingTableOptions{AutoIncrement: &existingAutoIncrement},
+			expected: []ddlStatement{
+				{SQL: "alter table `t` auto_increment=1000", Change: ddlChangeAutoIncrement},
+			},
+		},
+		{
+			name:        "auto increment is not moved back",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{AutoIncrement: &autoIncrement},
+			existing:    existingTableOptions{AutoIncrement: &laterAutoIncrement},
+			expected:    []ddlStatement{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := TableOptionsStatements("t", test.tableSchema, test.existing)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
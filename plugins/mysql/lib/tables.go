@@ -21,7 +21,8 @@ func (m *MysqlConnection) ListTables() ([]*types.Table, error) {
 	query = `select
 t.table_name,
 t.TABLE_COLLATION,
-c.character_set_name FROM information_schema.TABLES t,
+c.character_set_name,
+t.TABLE_COMMENT FROM information_schema.TABLES t,
 information_schema.COLLATION_CHARACTER_SET_APPLICABILITY c
 WHERE c.collation_name = t.table_collation
 AND t.table_schema = ?`
@@ -34,13 +35,14 @@ AND t.table_schema = ?`
 
 	tables := []*types.Table{}
 	for rows.Next() {
-		var tableName, tableCollation, tableCharset string
-		if err := rows.Scan(&tableName, &tableCollation, &tableCharset); err != nil {
+		var tableName, tableCollation, tableCharset, tableComment string
+		if err := rows.Scan(&tableName, &tableCollation, &tableCharset, &tableComment); err != nil {
 			return nil, err
 		}
 
 		table := types.Table{
-			Name: tableName,
+			Name:    tableName,
+			Comment: tableComment,
 		}
 
 		if tableCollation != databaseDefaultCollation {
@@ -57,10 +59,24 @@ AND t.table_schema = ?`
 }
 
 func (m *MysqlConnection) ListTableIndexes(databaseName string, tableName string) ([]*types.Index, error) {
-	query := `select
+	// functional key parts are only in information_schema on mysql 8.0.13 and later
+	expression := "null"
+	version, err := m.getServerVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get server version")
+	}
+	if !version.MariaDB && version.atLeast(8, 0, 13) {
+		expression = "expression"
+	}
+
+	query := fmt.Sprintf(`select
 	index_name,
 	non_unique,
-	group_concat(column_name order by seq_in_index)
+	index_type,
+	column_name,
+	sub_part,
+	collation,
+	%s
  	from information_schema.statistics
 	 where table_name = ?
 	 and table_schema = ?
@@ -74,9 +90,9 @@ func (m *MysqlConnection) ListTableIndexes(databaseName string, tableName string
 	    on rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
 	  where tc.CONSTRAINT_TYPE = 'FOREIGN KEY'
 	  and kcu.TABLE_NAME = ?
-	  and kcu.TABLE_SCHEMA = ?		
+	  and kcu.TABLE_SCHEMA = ?
         )
-	group by 1, 2`
+	order by index_name, seq_in_index`, expression)
 	rows, err := m.db.Query(query, tableName, databaseName, tableName, databaseName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query indexes")
@@ -84,21 +100,43 @@ func (m *MysqlConnection) ListTableIndexes(databaseName string, tableName string
 	defer rows.Close()
 
 	indexes := make([]*types.Index, 0)
+	var index *types.Index
 	for rows.Next() {
-		var index types.Index
-		var columns string
+		var name, indexType string
 		var nonUnique bool
-		if err := rows.Scan(&index.Name, &nonUnique, &columns); err != nil {
+		var columnName, collation, expression sql.NullString
+		var subPart sql.NullInt64
+		if err := rows.Scan(&name, &nonUnique, &indexType, &columnName, &subPart, &collation, &expression); err != nil {
 			return nil, err
 		}
 
-		index.IsUnique = !nonUnique
+		// each row is a key part, the rows of an index are together and in order
+		if index == nil || index.Name != name {
+			index = &types.Index{
+				Name:     name,
+				IsUnique: !nonUnique,
+				Columns:  []string{},
+			}
+			if indexType == "FULLTEXT" || indexType == "SPATIAL" {
+				index.Type = indexType
+			}
+			indexes = append(indexes, index)
+		}
 
-		// columns are selected as col1,col2
-		columnNames := strings.Split(columns, ",")
-		index.Columns = columnNames
+		part := types.IndexPart{
+			Column:     columnName.String,
+			Expression: expression.String,
+			Descending: collation.String == "D",
+		}
+		// spatial indexes report the size of the geometry as the prefix length
+		if subPart.Valid && indexType != "SPATIAL" {
+			part.Length = int(subPart.Int64)
+		}
+		index.Parts = append(index.Parts, &part)
 
-		indexes = append(indexes, &index)
+		if columnName.Valid {
+			index.Columns = append(index.Columns, columnName.String)
+		}
 	}
 
 	return indexes, nil
@@ -197,7 +235,7 @@ order by kcu.ORDINAL_POSITION`
 }
 
 func (m *MysqlConnection) GetTableSchema(tableName string) ([]*types.Column, error) {
-	query := `select COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE
+	query := `select COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE, COLUMN_COMMENT
 from information_schema.COLUMNS
 where TABLE_NAME = ?
 and TABLE_SCHEMA = ?
@@ -217,15 +255,19 @@ order by ORDINAL_POSITION`
 		}
 
 		var maxLength sql.NullInt64
-		var isNullable, extra string
+		var isNullable, extra, columnComment string
 		var columnDefault sql.NullString
 		var numericPrecision sql.NullInt64
 		var numericScale sql.NullInt64
 
-		if err := rows.Scan(&column.Name, &columnDefault, &isNullable, &extra, &column.DataType, &maxLength, &numericPrecision, &numericScale); err != nil {
+		if err := rows.Scan(&column.Name, &columnDefault, &isNullable, &extra, &column.DataType, &maxLength, &numericPrecision, &numericScale, &columnComment); err != nil {
 			return nil, err
 		}
 
+		if columnComment != "" {
+			column.Comment = &columnComment
+		}
+
 		if isNullable == "NO" {
 			column.Constraints.NotNull = &trueValue
 		} else {
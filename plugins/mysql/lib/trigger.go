@@ -0,0 +1,133 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+func validateTrigger(tableName string, trigger *schemasv1alpha4.MysqlTableTrigger) error {
+	if trigger.Name == "" {
+		return errors.Errorf("trigger on table %s requires a name", tableName)
+	}
+
+	timing := strings.ToUpper(trigger.Timing)
+	if timing != "BEFORE" && timing != "AFTER" {
+		return errors.Errorf("trigger %s has unsupported timing %q", trigger.Name, trigger.Timing)
+	}
+
+	event := strings.ToUpper(trigger.Event)
+	if event != "INSERT" && event != "UPDATE" && event != "DELETE" {
+		return errors.Errorf("trigger %s has unsupported event %q", trigger.Name, trigger.Event)
+	}
+
+	if strings.TrimSpace(trigger.Body) == "" {
+		return errors.Errorf("trigger %s requires a body", trigger.Name)
+	}
+
+	return nil
+}
+
+// CreateTriggerStatement returns the statement to create a trigger on a table
+func CreateTriggerStatement(tableName string, trigger *schemasv1alpha4.MysqlTableTrigger) (string, error) {
+	if err := validateTrigger(tableName, trigger); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("create trigger `%s` %s %s on `%s` for each row\n%s",
+		trigger.Name, strings.ToLower(trigger.Timing), strings.ToLower(trigger.Event), tableName, taggedBody(trigger.Body)), nil
+}
+
+// DropTriggerStatement returns the statement to drop a trigger
+func DropTriggerStatement(triggerName string) string {
+	return fmt.Sprintf("drop trigger if exists `%s`", triggerName)
+}
+
+func triggerChanged(trigger *schemasv1alpha4.MysqlTableTrigger, existing *schemasv1alpha4.MysqlTableTrigger) bool {
+	return !strings.EqualFold(trigger.Timing, existing.Timing) ||
+		!strings.EqualFold(trigger.Event, existing.Event) ||
+		normalizeRoutineBody(trigger.Body) != normalizeRoutineBody(existing.Body)
+}
+
+// TriggerStatements returns the statements to move the triggers on a table from the existing triggers to the
+// triggers in the schema. Triggers cannot be altered, so a changed trigger is dropped and created again.
+func TriggerStatements(tableName string, triggers []*schemasv1alpha4.MysqlTableTrigger, existingTriggers []*schemasv1alpha4.MysqlTableTrigger) ([]string, error) {
+	// triggers are not managed unless they are in the schema
+	if triggers == nil {
+		return []string{}, nil
+	}
+
+	existingByName := map[string]*schemasv1alpha4.MysqlTableTrigger{}
+	for _, existing := range existingTriggers {
+		existingByName[existing.Name] = existing
+	}
+	desiredNames := map[string]bool{}
+	for _, trigger := range triggers {
+		desiredNames[trigger.Name] = true
+	}
+
+	statements := []string{}
+	for _, existing := range existingTriggers {
+		if !desiredNames[existing.Name] {
+			statements = append(statements, DropTriggerStatement(existing.Name))
+		}
+	}
+
+	for _, trigger := range triggers {
+		existing, ok := existingByName[trigger.Name]
+		if ok && !triggerChanged(trigger, existing) {
+			continue
+		}
+
+		createStatement, err := CreateTriggerStatement(tableName, trigger)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			statements = append(statements, DropTriggerStatement(trigger.Name))
+		}
+		statements = append(statements, createStatement)
+	}
+
+	return statements, nil
+}
+
+// listTableTriggers returns the triggers on a table from information_schema.TRIGGERS
+func listTableTriggers(m *MysqlConnection, tableName string) ([]*schemasv1alpha4.MysqlTableTrigger, error) {
+	query := `select TRIGGER_NAME, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT
+from information_schema.TRIGGERS
+where TRIGGER_SCHEMA = ? and EVENT_OBJECT_TABLE = ?
+order by ACTION_ORDER`
+	rows, err := m.db.Query(query, m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query triggers")
+	}
+	defer rows.Close()
+
+	triggers := []*schemasv1alpha4.MysqlTableTrigger{}
+	for rows.Next() {
+		trigger := schemasv1alpha4.MysqlTableTrigger{}
+		if err := rows.Scan(&trigger.Name, &trigger.Timing, &trigger.Event, &trigger.Body); err != nil {
+			return nil, errors.Wrap(err, "failed to scan trigger")
+		}
+		triggers = append(triggers, &trigger)
+	}
+
+	return triggers, nil
+}
+
+// buildTriggerStatements will return the statements needed to change the triggers on a table
+func buildTriggerStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Triggers == nil {
+		return []string{}, nil
+	}
+
+	existingTriggers, err := listTableTriggers(m, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list triggers")
+	}
+
+	return TriggerStatements(tableName, mysqlTableSchema.Triggers, existingTriggers)
+}
@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerStatements(t *testing.T) {
+	audit := &schemasv1alpha4.MysqlTableTrigger{
+		Name:   "orders_audit",
+		Timing: "AFTER",
+		Event:  "UPDATE",
+		Body:   "INSERT INTO orders_audit (order_id, changed_at) VALUES (NEW.id, NOW())",
+	}
+	createAudit := "create trigger `orders_audit` after update on `orders` for each row\n" +
+		"-- Function body follows\n" +
+		"INSERT INTO orders_audit (order_id, changed_at) VALUES (NEW.id, NOW())\n" +
+		"-- Function body follows"
+
+	tests := []struct {
+		name     string
+		triggers []*schemasv1alpha4.MysqlTableTrigger
+		existing []*schemasv1alpha4.MysqlTableTrigger
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "not managed",
+			existing: []*schemasv1alpha4.MysqlTableTrigger{audit},
+			expected: []string{},
+		},
+		{
+			name:     "create",
+			triggers: []*schemasv1alpha4.MysqlTableTrigger{audit},
+			expected: []string{createAudit},
+		},
+		{
+			name:     "unchanged",
+			triggers: []*schemasv1alpha4.MysqlTableTrigger{audit},
+			existing: []*schemasv1alpha4.MysqlTableTrigger{
+				{Name: "orders_audit", Timing: "AFTER", Event: "UPDATE", Body: "INSERT INTO orders_audit (order_id, changed_at)\nVALUES (NEW.id, NOW())"},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "recreate changed trigger and drop removed trigger",
+			triggers: []*schemasv1alpha4.MysqlTableTrigger{audit},
+			existing: []*schemasv1alpha4.MysqlTableTrigger{
+				{Name: "orders_legacy", Timing: "BEFORE", Event: "INSERT", Body: "SET NEW.legacy = 1"},
+				{Name: "orders_audit", Timing: "AFTER", Event: "INSERT", Body: "INSERT INTO orders_audit (order_id, changed_at) VALUES (NEW.id, NOW())"},
+			},
+			expected: []string{
+				"drop trigger if exists `orders_legacy`",
+				"drop trigger if exists `orders_audit`",
+				createAudit,
+			},
+		},
+		{
+			name:     "drop all triggers",
+			triggers: []*schemasv1alpha4.MysqlTableTrigger{},
+			existing: []*schemasv1alpha4.MysqlTableTrigger{audit},
+			expected: []string{"drop trigger if exists `orders_audit`"},
+		},
+		{
+			name:     "invalid event",
+			triggers: []*schemasv1alpha4.MysqlTableTrigger{{Name: "t", Timing: "AFTER", Event: "TRUNCATE", Body: "SET @x = 1"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := TriggerStatements("orders", test.triggers, test.existing)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
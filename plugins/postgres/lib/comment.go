@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// TableCommentStatement returns the statement to set (or, when comment is empty, remove) the comment on a table
+func TableCommentStatement(tableName string, comment string) string {
+	return fmt.Sprintf(`comment on table %s is %s`, pgx.Identifier{tableName}.Sanitize(), commentLiteral(comment))
+}
+
+// ColumnCommentStatement returns the statement to set (or, when comment is empty, remove) the comment on a column
+func ColumnCommentStatement(tableName string, columnName string, comment string) string {
+	return fmt.Sprintf(`comment on column %s.%s is %s`, pgx.Identifier{tableName}.Sanitize(), pgx.Identifier{columnName}.Sanitize(), commentLiteral(comment))
+}
+
+func commentLiteral(comment string) string {
+	if comment == "" {
+		return "null"
+	}
+
+	return escapePostgresString(comment)
+}
+
+// createCommentStatements returns the comment statements for a table that is being created
+func createCommentStatements(tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema) []string {
+	statements := []string{}
+
+	if tableSchema.Comment != nil && *tableSchema.Comment != "" {
+		statements = append(statements, TableCommentStatement(tableName, *tableSchema.Comment))
+	}
+
+	for _, column := range tableSchema.Columns {
+		if column.Comment != nil && *column.Comment != "" {
+			statements = append(statements, ColumnCommentStatement(tableName, column.Name, *column.Comment))
+		}
+	}
+
+	return statements
+}
+
+// BuildCommentStatements compares the table and column comments in the schema with the
+// comments stored in pg_description. Comments that are not set in the schema are left
+// unmanaged, and an empty comment removes the existing one.
+// This should be planned after the column statements so that added columns can be commented.
+func BuildCommentStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	schema := p.schema
+	if postgresTableSchema.Schema != "" {
+		schema = postgresTableSchema.Schema
+	}
+
+	statements := []string{}
+
+	if postgresTableSchema.Comment != nil {
+		query := `select obj_description(c.oid, 'pg_class')
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1 and n.nspname = $2`
+		row := p.conn.QueryRow(context.Background(), query, tableName, schema)
+		var existingComment sql.NullString
+		if err := row.Scan(&existingComment); err != nil {
+			return nil, errors.Wrap(err, "failed to read table comment")
+		}
+
+		if existingComment.String != *postgresTableSchema.Comment {
+			statements = append(statements, TableCommentStatement(tableName, *postgresTableSchema.Comment))
+		}
+	}
+
+	query := `select a.attname, col_description(c.oid, a.attnum)
+from pg_attribute a
+join pg_class c on c.oid = a.attrelid
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1 and n.nspname = $2 and a.attnum > 0 and not a.attisdropped`
+	rows, err := p.conn.Query(context.Background(), query, tableName, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read column comments")
+	}
+	defer rows.Close()
+
+	existingComments := map[string]string{}
+	for rows.Next() {
+		var columnName string
+		var comment sql.NullString
+		if err := rows.Scan(&columnName, &comment); err != nil {
+			return nil, errors.Wrap(err, "failed to scan column comment")
+		}
+
+		existingComments[columnName] = comment.String
+	}
+
+	for _, column := range postgresTableSchema.Columns {
+		if column.Comment == nil {
+			continue
+		}
+
+		// columns that are being added in this plan have no comment yet
+		if existingComments[column.Name] != *column.Comment {
+			statements = append(statements, ColumnCommentStatement(tableName, column.Name, *column.Comment))
+		}
+	}
+
+	return statements, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TableCommentStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		tableName string
+		comment   string
+		expect    string
+	}{
+		{
+			name:      "set comment",
+			tableName: "users",
+			comment:   "all of the users",
+			expect:    `comment on table "users" is 'all of the users'`,
+		},
+		{
+			name:      "remove comment",
+			tableName: "users",
+			comment:   "",
+			expect:    `comment on table "users" is null`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, TableCommentStatement(test.tableName, test.comment))
+		})
+	}
+}
+
+func Test_ColumnCommentStatement(t *testing.T) {
+	tests := []struct {
+		name       string
+		tableName  string
+		columnName string
+		comment    string
+		expect     string
+	}{
+		{
+			name:       "set comment",
+			tableName:  "users",
+			columnName: "email",
+			comment:    "the user's email",
+			expect:     `comment on column "users"."email" is E'the user\'s email'`,
+		},
+		{
+			name:       "remove comment",
+			tableName:  "users",
+			columnName: "email",
+			comment:    "",
+			expect:     `comment on column "users"."email" is null`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, ColumnCommentStatement(test.tableName, test.columnName, test.comment))
+		})
+	}
+}
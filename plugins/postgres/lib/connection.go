@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"github.com/xo/dburl"
 )
@@ -167,11 +168,46 @@ func (p *PostgresConnection) PlanExtensionSchema(extensionName string, extension
 	return PlanPostgresExtension(p.GetConnectionURI(), extensionName, postgresExtension)
 }
 
+// PlanGrantSchema generates SQL statements to grant or revoke privileges on an object
+func (p *PostgresConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	postgresGrant, ok := grantSchema.(*schemasv1alpha4.PostgresqlGrantSchema)
+	if !ok {
+		return nil, errors.New("grantSchema must be *PostgresqlGrantSchema")
+	}
+
+	return PlanPostgresGrant(p.GetConnectionURI(), postgresGrant)
+}
+
+// PlanDatabaseSchema generates SQL statements to create, alter or drop a schema
+func (p *PostgresConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	postgresSchema, ok := databaseSchema.(*schemasv1alpha4.PostgresqlDatabaseSchema)
+	if !ok {
+		return nil, errors.New("databaseSchema must be *PostgresqlDatabaseSchema")
+	}
+
+	return PlanPostgresDatabaseSchema(p.GetConnectionURI(), schemaName, postgresSchema)
+}
+
+// PlanSequenceSchema generates SQL statements to create, alter or drop a sequence
+func (p *PostgresConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	postgresSequence, ok := sequenceSchema.(*schemasv1alpha4.PostgresqlSequenceSchema)
+	if !ok {
+		return nil, errors.New("sequenceSchema must be *PostgresqlSequenceSchema")
+	}
+
+	return PlanPostgresSequence(p.GetConnectionURI(), sequenceName, postgresSequence)
+}
+
 // DeployStatements executes a list of SQL statements
 func (p *PostgresConnection) DeployStatements(statements []string) error {
 	return DeployPostgresStatements(p.GetConnectionURI(), statements)
 }
 
+// GetOnlineCopyProgress returns the progress of a shadow table copy, postgres tables are not rebuilt this way
+func (p *PostgresConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	return nil, nil
+}
+
 func (p *PostgresConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error) {
 	if spec.Schema == nil || spec.Schema.Postgres == nil {
 		return []string{}, nil
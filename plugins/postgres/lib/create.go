@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,30 +15,47 @@ import (
 )
 
 func SeedDataStatements(tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, tableSchema.PrimaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
+
 	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		keys, err := types.SeedDataKeysCondition(rows, tableSchema.PrimaryKey)
+		if err != nil {
+			return nil, err
+		}
+		if keys == "" {
+			statements = append(statements, fmt.Sprintf(`delete from %s`, tableName))
+		} else {
+			statements = append(statements, fmt.Sprintf(`delete from %s where not (%s)`, tableName, keys))
+		}
+	}
 
 	conflictInferenceSpec := findConflictInferenceSpec(tableName, tableSchema)
-	for _, row := range seedData.Rows {
-		cols := []string{}
-		vals := []string{}
+	for _, row := range rows {
 		updateVals := []string{}
 		for _, col := range row.Columns {
-			cols = append(cols, col.Column)
-			updateVals = append(updateVals, fmt.Sprintf("excluded.%s", col.Column))
-			if col.Value.Int != nil {
-				vals = append(vals, strconv.Itoa(*col.Value.Int))
-			} else if col.Value.Str != nil {
-				// Escape string for PostgreSQL, handling multiline strings
-				escapedStr := escapePostgresString(*col.Value.Str)
-				vals = append(vals, escapedStr)
-			}
+			updateVals = append(updateVals, fmt.Sprintf("excluded.%s", col))
 		}
 
+		insert := fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(row.Columns, ", "), strings.Join(row.Values, ", "))
+
 		var statement string
-		if conflictInferenceSpec != "" {
-			statement = fmt.Sprintf(`insert into %s (%s) values (%s) on conflict (%s) do update set (%s) = (%s)`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "), conflictInferenceSpec, strings.Join(cols, ", "), strings.Join(updateVals, ", "))
-		} else {
-			statement = fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "))
+		switch {
+		case seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly:
+			statement = fmt.Sprintf(`%s on conflict do nothing`, insert)
+		case types.SeedDataReadsTable(seedData):
+			statement = fmt.Sprintf(`%s on conflict (%s) do %s`, insert, conflictInferenceSpec, upsertAction(row, tableSchema.PrimaryKey))
+		case conflictInferenceSpec != "":
+			statement = fmt.Sprintf(`%s on conflict (%s) do update set (%s) = (%s)`, insert, conflictInferenceSpec, strings.Join(row.Columns, ", "), strings.Join(updateVals, ", "))
+		default:
+			statement = insert
 		}
 		statements = append(statements, statement)
 	}
@@ -45,6 +63,110 @@ func SeedDataStatements(tableName string, tableSchema *schemasv1alpha4.Postgresq
 	return statements, nil
 }
 
+// upsertAction updates the columns of the row that are not in the primary key
+func upsertAction(row types.SeedDataRowLiterals, primaryKey []string) string {
+	assignments := row.Assignments(primaryKey)
+	if len(assignments) == 0 {
+		return "nothing"
+	}
+	return fmt.Sprintf("update set %s", strings.Join(assignments, ", "))
+}
+
+// SeedDataDiffStatements plans the seed data from the rows in the table, so that there is a statement for each
+// row that is inserted, updated or deleted
+func SeedDataDiffStatements(p *PostgresConnection, tableName string, primaryKey []string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, primaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		deleteStatements, err := seedDataDeleteStatements(p, tableName, primaryKey, rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan seed data deletes")
+		}
+		statements = append(statements, deleteStatements...)
+	}
+
+	for _, row := range rows {
+		where, err := row.Condition(primaryKey, "=")
+		if err != nil {
+			return nil, err
+		}
+		same, err := row.Condition(row.Columns, "is not distinct from")
+		if err != nil {
+			return nil, err
+		}
+
+		isSame := false
+		query := fmt.Sprintf(`select %s from %s where %s`, same, tableName, where)
+		err = p.conn.QueryRow(context.Background(), query).Scan(&isSame)
+		if errors.Is(err, pgx.ErrNoRows) {
+			statements = append(statements, fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(row.Columns, ", "), strings.Join(row.Values, ", ")))
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read seed data row where %s", where)
+		}
+
+		if isSame || seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf(`update %s set %s where %s`, tableName, strings.Join(row.Assignments(primaryKey), ", "), where))
+	}
+
+	return statements, nil
+}
+
+// seedDataDeleteStatements returns a delete statement for each row of the table that is not in the seed data
+func seedDataDeleteStatements(p *PostgresConnection, tableName string, primaryKey []string, rows []types.SeedDataRowLiterals) ([]string, error) {
+	columns := []string{}
+	for _, column := range primaryKey {
+		columns = append(columns, fmt.Sprintf("%s::text", column))
+	}
+
+	query := fmt.Sprintf(`select %s from %s`, strings.Join(columns, ", "), tableName)
+	keys, err := types.SeedDataKeysCondition(rows, primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	if keys != "" {
+		query = fmt.Sprintf(`%s where not (%s)`, query, keys)
+	}
+	query = fmt.Sprintf(`%s order by %s`, query, strings.Join(primaryKey, ", "))
+
+	result, err := p.conn.Query(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	statements := []string{}
+	for result.Next() {
+		values := make([]string, len(primaryKey))
+		dest := []interface{}{}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := result.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		conditions := []string{}
+		for i, column := range primaryKey {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", column, escapePostgresString(values[i])))
+		}
+		statements = append(statements, fmt.Sprintf(`delete from %s where %s`, tableName, strings.Join(conditions, " and ")))
+	}
+
+	return statements, result.Err()
+}
+
 func SeedDataStatementsWithExistingSchema(uri string, tableName string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	// Connect to the database to retrieve existing schema
 	p, err := Connect(uri)
@@ -69,6 +191,14 @@ func SeedDataStatementsWithExistingSchema(uri string, tableName string, seedData
 		return nil, errors.Wrap(err, "failed to get table primary key")
 	}
 
+	if types.SeedDataReadsTable(seedData) {
+		var primaryKeyColumns []string
+		if primaryKey != nil {
+			primaryKeyColumns = primaryKey.Columns
+		}
+		return SeedDataDiffStatements(p, tableName, primaryKeyColumns, seedData)
+	}
+
 	// For seed data, we need to create a minimal PostgresqlTableSchema with just the primary key
 	// since that's what the conflict inference spec calculation needs
 	tableSchema := &schemasv1alpha4.PostgresqlTableSchema{}
@@ -141,6 +271,10 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Postgr
 
 		queries = append(queries, statement)
 	}
+
+	queries = append(queries, createCommentStatements(qualifiedTableName, tableSchema)...)
+	queries = append(queries, createRowLevelSecurityStatements(qualifiedTableName, tableSchema)...)
+
 	return queries, nil
 }
 
@@ -169,6 +303,17 @@ func findConflictInferenceSpec(tableName string, tableSchema *schemasv1alpha4.Po
 	return ""
 }
 
+// seedDataLiterals are the literals of seed data values. A json document is a string that postgres converts to
+// the json or jsonb type of the column
+var seedDataLiterals = types.SeedDataLiterals{
+	String: escapePostgresString,
+	Bool:   strconv.FormatBool,
+	Bytes: func(b []byte) string {
+		return fmt.Sprintf(`'\x%x'::bytea`, b)
+	},
+	JSON: escapePostgresString,
+}
+
 // escapePostgresString properly escapes a string for PostgreSQL, using E'...' syntax for strings containing newlines
 func escapePostgresString(s string) string {
 	// Check if the string contains newlines or single quotes that need escaping
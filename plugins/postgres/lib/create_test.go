@@ -10,6 +10,10 @@ import (
 )
 
 func Test_CreateTableStatement(t *testing.T) {
+	idComment := "the id"
+	tableComment := "a table's comment"
+	tenantExpression := "tenant_id = current_setting('app.tenant')::integer"
+
 	tests := []struct {
 		name               string
 		tableSchema        *schemasv1alpha4.PostgresqlTableSchema
@@ -151,6 +155,59 @@ func Test_CreateTableStatement(t *testing.T) {
 				`create trigger "tgr" after insert on "simple" for each row execute procedure test()`,
 			},
 		},
+		{
+			name: "with comments",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{
+					"id",
+				},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name:    "id",
+						Type:    "integer",
+						Comment: &idComment,
+					},
+					{
+						Name: "other",
+						Type: "text",
+					},
+				},
+				Comment: &tableComment,
+			},
+			tableName: "commented",
+			expectedStatements: []string{
+				`create table "commented" ("id" integer, "other" text, primary key ("id"))`,
+				`comment on table "commented" is E'a table\'s comment'`,
+				`comment on column "commented"."id" is 'the id'`,
+			},
+		},
+		{
+			name: "with row level security",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "tenant_id",
+						Type: "integer",
+					},
+				},
+				RowLevelSecurity: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{
+					Enabled: true,
+				},
+				Policies: []*schemasv1alpha4.PostgresqlTablePolicy{
+					{
+						Name:  "tenant_isolation",
+						Roles: []string{"app"},
+						Using: &tenantExpression,
+					},
+				},
+			},
+			tableName: "secured",
+			expectedStatements: []string{
+				`create table "secured" ("tenant_id" integer)`,
+				`alter table "secured" enable row level security`,
+				`create policy "tenant_isolation" on "secured" as permissive for all to "app" using (tenant_id = current_setting('app.tenant')::integer)`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -164,3 +221,96 @@ func Test_CreateTableStatement(t *testing.T) {
 		})
 	}
 }
+
+func Test_SeedDataStatements(t *testing.T) {
+	id, name, active, doc, data, createdAt := 1, "it's\nhere", true, `{"a": 1}`, "Af8=", "current_timestamp"
+	price := schemasv1alpha4.SeedDataFloat("19.90")
+	seedData := &schemasv1alpha4.SeedData{
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &id}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+					{Column: "active", Value: schemasv1alpha4.SeedDataValue{Bool: &active}},
+					{Column: "price", Value: schemasv1alpha4.SeedDataValue{Float: &price}},
+					{Column: "deleted_at", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+					{Column: "doc", Value: schemasv1alpha4.SeedDataValue{JSON: &doc}},
+					{Column: "data", Value: schemasv1alpha4.SeedDataValue{Bytes: &data}},
+					{Column: "created_at", Value: schemasv1alpha4.SeedDataValue{Expr: &createdAt}},
+				},
+			},
+		},
+	}
+
+	statements, err := SeedDataStatements("users", &schemasv1alpha4.PostgresqlTableSchema{PrimaryKey: []string{"id"}}, seedData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`insert into users (id, name, active, price, deleted_at, doc, data, created_at) values (1, E'it\'s\nhere', true, 19.90, null, '{"a": 1}', '\x01ff'::bytea, current_timestamp) on conflict ("id") do update set (id, name, active, price, deleted_at, doc, data, created_at) = (excluded.id, excluded.name, excluded.active, excluded.price, excluded.deleted_at, excluded.doc, excluded.data, excluded.created_at)`,
+	}, statements)
+
+	seedData.Rows[0].Columns[3].Value.Null = true
+	_, err = SeedDataStatements("users", &schemasv1alpha4.PostgresqlTableSchema{PrimaryKey: []string{"id"}}, seedData)
+	assert.EqualError(t, err, "seed data for column price has more than one value")
+}
+
+func Test_SeedDataStatementsModes(t *testing.T) {
+	one, two, name := 1, 2, "a"
+	rows := []schemasv1alpha4.SeedDataRow{
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &one}},
+				{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+			},
+		},
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &two}},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode            schemasv1alpha4.SeedDataMode
+		expect          []string
+		expectErrSubstr string
+	}{
+		{
+			mode: schemasv1alpha4.SeedDataModeInsertOnly,
+			expect: []string{
+				`insert into users (id, name) values (1, 'a') on conflict do nothing`,
+				`insert into users (id) values (2) on conflict do nothing`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeUpsert,
+			expect: []string{
+				`insert into users (id, name) values (1, 'a') on conflict ("id") do update set name = 'a'`,
+				`insert into users (id) values (2) on conflict ("id") do nothing`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeExact,
+			expect: []string{
+				`delete from users where not ((id = 1) or (id = 2))`,
+				`insert into users (id, name) values (1, 'a') on conflict ("id") do update set name = 'a'`,
+				`insert into users (id) values (2) on conflict ("id") do nothing`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.mode), func(t *testing.T) {
+			statements, err := SeedDataStatements("users", &schemasv1alpha4.PostgresqlTableSchema{PrimaryKey: []string{"id"}}, &schemasv1alpha4.SeedData{Mode: test.mode, Rows: rows})
+			if test.expectErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, statements)
+		})
+	}
+
+	_, err := SeedDataStatements("users", &schemasv1alpha4.PostgresqlTableSchema{}, &schemasv1alpha4.SeedData{Mode: schemasv1alpha4.SeedDataModeUpsert, Rows: rows})
+	assert.EqualError(t, err, "seed data mode upsert requires table users to have a primary key")
+}
@@ -100,6 +100,13 @@ func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schema
 	}
 	statements = append(statements, columnStatements...)
 
+	// table and column comments
+	commentStatements, err := BuildCommentStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build comment statements")
+	}
+	statements = append(statements, commentStatements...)
+
 	// primary key changes
 	primaryKeyStatements, err := BuildPrimaryKeyStatements(p, tableName, postgresTableSchema)
 	if err != nil {
@@ -121,6 +128,18 @@ func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schema
 	}
 	statements = append(statements, indexStatements...)
 
+	// row level security and policy changes
+	rowLevelSecurityStatements, err := BuildRowLevelSecurityStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build row level security statements")
+	}
+	statements = append(statements, rowLevelSecurityStatements...)
+
+	// the rows can be read when the table does not change, otherwise the seed data is written without them
+	if len(statements) == 0 && types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(p, tableName, postgresTableSchema.PrimaryKey, seedData)
+	}
+
 	statements = append(statements, seedDataStatements...)
 
 	return statements, nil
@@ -191,6 +210,10 @@ func PlanPostgresTableSeedDataOnly(uri string, tableName string, seedData *schem
 		postgresSchema.Columns = append(postgresSchema.Columns, postgresCol)
 	}
 
+	if types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(p, tableName, postgresSchema.PrimaryKey, seedData)
+	}
+
 	// Generate seed data statements
 	seedDataStatements, err := SeedDataStatements(tableName, postgresSchema, seedData)
 	if err != nil {
@@ -504,6 +527,18 @@ func CheckIfTableExists(p *PostgresConnection, tableName string) (bool, error) {
 	return tableExists > 0, nil
 }
 
+// CheckIfTableExistsInSchema returns whether the specified table exists in the schema
+func CheckIfTableExistsInSchema(p *PostgresConnection, schema string, tableName string) (bool, error) {
+	query := `select count(1) from information_schema.tables where table_schema = $1 and table_name = $2`
+	row := p.conn.QueryRow(context.Background(), query, schema, tableName)
+	tableExists := 0
+	if err := row.Scan(&tableExists); err != nil {
+		return false, errors.Wrap(err, "failed to scan")
+	}
+
+	return tableExists > 0, nil
+}
+
 // CheckIfFunctionExists returns whether the specified function exists in the database
 func CheckIfFunctionExists(p *PostgresConnection, functionSchema string, functionName string) (bool, error) {
 	query := `select count(1) from information_schema.routines where routine_type = 'FUNCTION' AND routine_schema = $1 AND routine_name = $2`
@@ -552,13 +587,61 @@ func PlanPostgresExtension(uri string, extensionName string, postgresExtensionSc
 		return dropStatements, nil
 	}
 
+	availableVersions, err := listAvailableExtensionVersions(p, extensionName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list available extension versions")
+	}
+
 	if !extensionExists {
+		if postgresExtensionSchema.Version != nil {
+			if err := checkExtensionVersionAvailable(extensionName, *postgresExtensionSchema.Version, availableVersions); err != nil {
+				return nil, err
+			}
+		}
+
 		createStatements, err := CreateExtensionStatements([]*schemasv1alpha4.PostgresDatabaseExtension{postgresExtensionSchema})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create extension statements")
 		}
 		queries = append(queries, createStatements...)
+		return queries, nil
+	}
+
+	query := `select e.extversion, n.nspname
+from pg_extension e
+join pg_namespace n on n.oid = e.extnamespace
+where e.extname = $1`
+	existing := existingExtension{}
+	if err := p.conn.QueryRow(context.Background(), query, extensionName).Scan(&existing.Version, &existing.Schema); err != nil {
+		return nil, errors.Wrap(err, "failed to read installed extension")
+	}
+
+	updateStatements, err := UpdateExtensionStatements(postgresExtensionSchema, &existing, availableVersions)
+	if err != nil {
+		return nil, err
 	}
+	queries = append(queries, updateStatements...)
 
 	return queries, nil
 }
+
+// listAvailableExtensionVersions returns the versions of the extension that can be installed on the server
+func listAvailableExtensionVersions(p *PostgresConnection, extensionName string) ([]string, error) {
+	query := `select version from pg_available_extension_versions where name = $1 order by version`
+	rows, err := p.conn.Query(context.Background(), query, extensionName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query available versions")
+	}
+	defer rows.Close()
+
+	versions := []string{}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, errors.Wrap(err, "failed to scan version")
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
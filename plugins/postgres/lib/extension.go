@@ -18,8 +18,10 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
@@ -54,3 +56,45 @@ func DropExtensionStatements(extensions []*schemasv1alpha4.PostgresDatabaseExten
 
 	return statements, nil
 }
+
+// existingExtension is an installed extension as read from pg_extension
+type existingExtension struct {
+	Version string
+	Schema  string
+}
+
+// UpdateExtensionStatements returns the statements to move an installed extension to the version and schema
+// in the spec. availableVersions are the versions of the extension that the server can install, and are used
+// to return an error when the requested version cannot be installed.
+func UpdateExtensionStatements(extension *schemasv1alpha4.PostgresDatabaseExtension, existing *existingExtension, availableVersions []string) ([]string, error) {
+	statements := []string{}
+
+	if extension.Version != nil && *extension.Version != existing.Version {
+		if err := checkExtensionVersionAvailable(extension.Name, *extension.Version, availableVersions); err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s;", pgx.Identifier{extension.Name}.Sanitize(), escapePostgresString(*extension.Version)))
+	}
+
+	if extension.Schema != nil && *extension.Schema != existing.Schema {
+		statements = append(statements, fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s;", pgx.Identifier{extension.Name}.Sanitize(), pgx.Identifier{*extension.Schema}.Sanitize()))
+	}
+
+	return statements, nil
+}
+
+// checkExtensionVersionAvailable returns an error when the version is not one of the versions the server can install
+func checkExtensionVersionAvailable(extensionName string, version string, availableVersions []string) error {
+	for _, availableVersion := range availableVersions {
+		if availableVersion == version {
+			return nil
+		}
+	}
+
+	if len(availableVersions) == 0 {
+		return errors.Errorf("extension %s is not available on the server", extensionName)
+	}
+	return errors.Errorf("extension %s version %s is not available on the server, available versions are: %s",
+		extensionName, version, strings.Join(availableVersions, ", "))
+}
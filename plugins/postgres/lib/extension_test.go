@@ -117,3 +117,79 @@ func TestCreateExtensionStatements(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestUpdateExtensionStatements(t *testing.T) {
+	tests := []struct {
+		name              string
+		extension         *schemasv1alpha4.PostgresDatabaseExtension
+		existing          *existingExtension
+		availableVersions []string
+		expected          []string
+		wantErr           string
+	}{
+		{
+			name: "unchanged",
+			extension: &schemasv1alpha4.PostgresDatabaseExtension{
+				Name:    "vector",
+				Version: strPtr("0.7.0"),
+				Schema:  strPtr("public"),
+			},
+			existing:          &existingExtension{Version: "0.7.0", Schema: "public"},
+			availableVersions: []string{"0.6.0", "0.7.0"},
+			expected:          []string{},
+		},
+		{
+			name: "version and schema not managed",
+			extension: &schemasv1alpha4.PostgresDatabaseExtension{
+				Name: "vector",
+			},
+			existing: &existingExtension{Version: "0.6.0", Schema: "public"},
+			expected: []string{},
+		},
+		{
+			name: "update version",
+			extension: &schemasv1alpha4.PostgresDatabaseExtension{
+				Name:    "vector",
+				Version: strPtr("0.7.0"),
+			},
+			existing:          &existingExtension{Version: "0.6.0", Schema: "public"},
+			availableVersions: []string{"0.6.0", "0.7.0"},
+			expected: []string{
+				"ALTER EXTENSION \"vector\" UPDATE TO '0.7.0';",
+			},
+		},
+		{
+			name: "move schema",
+			extension: &schemasv1alpha4.PostgresDatabaseExtension{
+				Name:   "vector",
+				Schema: strPtr("extensions"),
+			},
+			existing: &existingExtension{Version: "0.6.0", Schema: "public"},
+			expected: []string{
+				"ALTER EXTENSION \"vector\" SET SCHEMA \"extensions\";",
+			},
+		},
+		{
+			name: "version not available",
+			extension: &schemasv1alpha4.PostgresDatabaseExtension{
+				Name:    "vector",
+				Version: strPtr("0.8.0"),
+			},
+			existing:          &existingExtension{Version: "0.6.0", Schema: "public"},
+			availableVersions: []string{"0.6.0", "0.7.0"},
+			wantErr:           "extension vector version 0.8.0 is not available on the server, available versions are: 0.6.0, 0.7.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := UpdateExtensionStatements(tt.extension, tt.existing, tt.availableVersions)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, statements)
+			}
+		})
+	}
+}
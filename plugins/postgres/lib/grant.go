@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// grantablePrivileges are the privileges that can be granted on each object type, in the
+// order that they are written to statements. "ALL" in a grant expands to this list.
+var grantablePrivileges = map[string][]string{
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"view":     {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+	"schema":   {"USAGE", "CREATE"},
+	"function": {"EXECUTE"},
+}
+
+func PlanPostgresGrant(uri string, grantSchema *schemasv1alpha4.PostgresqlGrantSchema) ([]string, error) {
+	p, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer p.Close()
+
+	existingPrivileges, err := listGrantedPrivileges(p, grantSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list granted privileges")
+	}
+
+	return GrantStatements(grantSchema, existingPrivileges)
+}
+
+// GrantStatements returns the grant and revoke statements needed to make the privileges that the role
+// has on the object match the schema. existingPrivileges maps each privilege the role currently
+// holds to whether it is held with grant option.
+func GrantStatements(grantSchema *schemasv1alpha4.PostgresqlGrantSchema, existingPrivileges map[string]bool) ([]string, error) {
+	objectType := grantSchema.Object.Type
+	allPrivileges, ok := grantablePrivileges[objectType]
+	if !ok {
+		return nil, errors.Errorf("unsupported grant object type %q", objectType)
+	}
+
+	object, err := grantObjectIdentifier(grantSchema.Object)
+	if err != nil {
+		return nil, err
+	}
+	role := grantRoleIdentifier(grantSchema.Role)
+
+	desiredPrivileges := map[string]bool{}
+	if !grantSchema.IsDeleted {
+		for _, privilege := range grantSchema.Privileges {
+			privilege = strings.ToUpper(strings.TrimSpace(privilege))
+			if privilege == "ALL" || privilege == "ALL PRIVILEGES" {
+				for _, p := range allPrivileges {
+					desiredPrivileges[p] = true
+				}
+				continue
+			}
+
+			if !containsPrivilege(allPrivileges, privilege) {
+				return nil, errors.Errorf("privilege %q cannot be granted on a %s", privilege, objectType)
+			}
+			desiredPrivileges[privilege] = true
+		}
+	}
+
+	toGrant := []string{}
+	toRevoke := []string{}
+	toRevokeGrantOption := []string{}
+	for _, privilege := range allPrivileges {
+		grantable, exists := existingPrivileges[privilege]
+		switch {
+		case desiredPrivileges[privilege] && (!exists || (grantSchema.WithGrantOption && !grantable)):
+			toGrant = append(toGrant, privilege)
+		case desiredPrivileges[privilege] && grantable && !grantSchema.WithGrantOption:
+			toRevokeGrantOption = append(toRevokeGrantOption, privilege)
+		case !desiredPrivileges[privilege] && exists:
+			toRevoke = append(toRevoke, privilege)
+		}
+	}
+
+	statements := []string{}
+	if len(toRevoke) > 0 {
+		statements = append(statements, fmt.Sprintf("revoke %s on %s from %s", strings.Join(toRevoke, ", "), object, role))
+	}
+	if len(toRevokeGrantOption) > 0 {
+		statements = append(statements, fmt.Sprintf("revoke grant option for %s on %s from %s", strings.Join(toRevokeGrantOption, ", "), object, role))
+	}
+	if len(toGrant) > 0 {
+		statement := fmt.Sprintf("grant %s on %s to %s", strings.Join(toGrant, ", "), object, role)
+		if grantSchema.WithGrantOption {
+			statement = fmt.Sprintf("%s with grant option", statement)
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+func containsPrivilege(privileges []string, privilege string) bool {
+	for _, p := range privileges {
+		if p == privilege {
+			return true
+		}
+	}
+	return false
+}
+
+// grantObjectIdentifier returns the object clause of a grant statement, such as `table "public"."users"`
+func grantObjectIdentifier(object schemasv1alpha4.GrantObject) (string, error) {
+	if object.Type == "schema" {
+		name := object.Name
+		if name == "" {
+			name = object.Schema
+		}
+		if name == "" {
+			return "", errors.New("schema grants require a schema name")
+		}
+		return fmt.Sprintf("schema %s", pgx.Identifier{name}.Sanitize()), nil
+	}
+
+	if object.Name == "" {
+		return "", errors.Errorf("%s grants require an object name", object.Type)
+	}
+
+	identifier := pgx.Identifier{object.Name}
+	if object.Schema != "" {
+		identifier = pgx.Identifier{object.Schema, object.Name}
+	}
+
+	// views are granted with the table keyword
+	keyword := object.Type
+	if keyword == "view" {
+		keyword = "table"
+	}
+
+	return fmt.Sprintf("%s %s", keyword, identifier.Sanitize()), nil
+}
+
+func grantRoleIdentifier(role string) string {
+	if strings.EqualFold(role, "public") {
+		return "PUBLIC"
+	}
+	return pgx.Identifier{role}.Sanitize()
+}
+
+// listGrantedPrivileges reads the privileges that the role holds on the object
+func listGrantedPrivileges(p *PostgresConnection, grantSchema *schemasv1alpha4.PostgresqlGrantSchema) (map[string]bool, error) {
+	role := grantSchema.Role
+	if strings.EqualFold(role, "public") {
+		role = "PUBLIC"
+	}
+
+	schema := p.schema
+	if grantSchema.Object.Schema != "" {
+		schema = grantSchema.Object.Schema
+	}
+
+	var query string
+	var args []interface{}
+	switch grantSchema.Object.Type {
+	case "table", "view":
+		// information_schema.role_table_grants hides grants to PUBLIC and grants that the connected role
+		// is not part of, so the acl is read from pg_class like the other object types
+		query = `select a.privilege_type, a.is_grantable
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+cross join lateral aclexplode(c.relacl) a
+left join pg_roles r on r.oid = a.grantee
+where coalesce(r.rolname, 'PUBLIC') = $1 and n.nspname = $2 and c.relname = $3 and c.relkind in ('r', 'p', 'v', 'm', 'f')`
+		args = []interface{}{role, schema, grantSchema.Object.Name}
+	case "sequence":
+		query = `select a.privilege_type, a.is_grantable
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+cross join lateral aclexplode(c.relacl) a
+left join pg_roles r on r.oid = a.grantee
+where coalesce(r.rolname, 'PUBLIC') = $1 and n.nspname = $2 and c.relname = $3 and c.relkind = 'S'`
+		args = []interface{}{role, schema, grantSchema.Object.Name}
+	case "schema":
+		name := grantSchema.Object.Name
+		if name == "" {
+			name = grantSchema.Object.Schema
+		}
+		query = `select a.privilege_type, a.is_grantable
+from pg_namespace n
+cross join lateral aclexplode(n.nspacl) a
+left join pg_roles r on r.oid = a.grantee
+where coalesce(r.rolname, 'PUBLIC') = $1 and n.nspname = $2`
+		args = []interface{}{role, name}
+	case "function":
+		query = `select a.privilege_type, a.is_grantable
+from pg_proc f
+join pg_namespace n on n.oid = f.pronamespace
+cross join lateral aclexplode(f.proacl) a
+left join pg_roles r on r.oid = a.grantee
+where coalesce(r.rolname, 'PUBLIC') = $1 and n.nspname = $2 and f.proname = $3`
+		args = []interface{}{role, schema, grantSchema.Object.Name}
+	default:
+		return nil, errors.Errorf("unsupported grant object type %q", grantSchema.Object.Type)
+	}
+
+	rows, err := p.conn.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query privileges")
+	}
+	defer rows.Close()
+
+	privileges := map[string]bool{}
+	for rows.Next() {
+		var privilege string
+		var grantable bool
+		if err := rows.Scan(&privilege, &grantable); err != nil {
+			return nil, errors.Wrap(err, "failed to scan privilege")
+		}
+
+		privileges[privilege] = privileges[privilege] || grantable
+	}
+
+	return privileges, rows.Err()
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		grantSchema        *schemasv1alpha4.PostgresqlGrantSchema
+		existingPrivileges map[string]bool
+		expected           []string
+		wantErr            bool
+	}{
+		{
+			name: "new table grant",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "table", Schema: "public", Name: "users"},
+				Privileges: []string{"select"},
+			},
+			existingPrivileges: map[string]bool{},
+			expected: []string{
+				`grant SELECT on table "public"."users" to "reader"`,
+			},
+		},
+		{
+			name: "already granted",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "view", Name: "active_users"},
+				Privileges: []string{"SELECT"},
+			},
+			existingPrivileges: map[string]bool{"SELECT": false},
+			expected:           []string{},
+		},
+		{
+			name: "add and remove privileges",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "writer",
+				Object:     schemasv1alpha4.GrantObject{Type: "table", Name: "users"},
+				Privileges: []string{"SELECT", "INSERT", "UPDATE"},
+			},
+			existingPrivileges: map[string]bool{"SELECT": false, "DELETE": false},
+			expected: []string{
+				`revoke DELETE on table "users" from "writer"`,
+				`grant INSERT, UPDATE on table "users" to "writer"`,
+			},
+		},
+		{
+			name: "all privileges with grant option",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:            "admin",
+				Object:          schemasv1alpha4.GrantObject{Type: "sequence", Schema: "app", Name: "users_id_seq"},
+				Privileges:      []string{"ALL"},
+				WithGrantOption: true,
+			},
+			existingPrivileges: map[string]bool{"USAGE": true, "SELECT": false},
+			expected: []string{
+				`grant SELECT, UPDATE on sequence "app"."users_id_seq" to "admin" with grant option`,
+			},
+		},
+		{
+			name: "remove grant option",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "app",
+				Object:     schemasv1alpha4.GrantObject{Type: "schema", Name: "app"},
+				Privileges: []string{"USAGE"},
+			},
+			existingPrivileges: map[string]bool{"USAGE": true},
+			expected: []string{
+				`revoke grant option for USAGE on schema "app" from "app"`,
+			},
+		},
+		{
+			name: "function grant to public",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "public",
+				Object:     schemasv1alpha4.GrantObject{Type: "function", Name: "calculate"},
+				Privileges: []string{"EXECUTE"},
+			},
+			existingPrivileges: map[string]bool{},
+			expected: []string{
+				`grant EXECUTE on function "calculate" to PUBLIC`,
+			},
+		},
+		{
+			name: "deleted grant revokes everything",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "table", Name: "users"},
+				Privileges: []string{"SELECT"},
+				IsDeleted:  true,
+			},
+			existingPrivileges: map[string]bool{"SELECT": false, "INSERT": true},
+			expected: []string{
+				`revoke SELECT, INSERT on table "users" from "reader"`,
+			},
+		},
+		{
+			name: "invalid privilege for object type",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "function", Name: "calculate"},
+				Privileges: []string{"SELECT"},
+			},
+			existingPrivileges: map[string]bool{},
+			wantErr:            true,
+		},
+		{
+			name: "missing object name",
+			grantSchema: &schemasv1alpha4.PostgresqlGrantSchema{
+				Role:       "reader",
+				Object:     schemasv1alpha4.GrantObject{Type: "table"},
+				Privileges: []string{"SELECT"},
+			},
+			existingPrivileges: map[string]bool{},
+			wantErr:            true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			actual, err := GrantStatements(test.grantSchema, test.existingPrivileges)
+			if test.wantErr {
+				req.Error(err)
+				return
+			}
+			req.NoError(err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
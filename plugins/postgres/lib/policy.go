@@ -0,0 +1,361 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var (
+	policyCommands = map[string]string{
+		"*": "ALL",
+		"r": "SELECT",
+		"a": "INSERT",
+		"w": "UPDATE",
+		"d": "DELETE",
+	}
+)
+
+// existingPolicy is a policy as read from pg_policy
+type existingPolicy struct {
+	Name        string
+	Command     string
+	Restrictive bool
+	Roles       []string
+	Using       *string
+	WithCheck   *string
+}
+
+// policyExpressions are the using and with check expressions of a desired policy, as deparsed by postgres
+type policyExpressions struct {
+	Using     *string
+	WithCheck *string
+}
+
+func managesRowLevelSecurity(tableSchema *schemasv1alpha4.PostgresqlTableSchema) bool {
+	return tableSchema.RowLevelSecurity != nil || tableSchema.Policies != nil
+}
+
+// RowLevelSecurityStatements returns the statements to change row level security on a table
+func RowLevelSecurityStatements(tableName string, desired *schemasv1alpha4.PostgresqlTableRowLevelSecurity, enabled bool, forced bool) []string {
+	if desired == nil {
+		return []string{}
+	}
+
+	statements := []string{}
+	if desired.Enabled != enabled {
+		action := "enable"
+		if !desired.Enabled {
+			action = "disable"
+		}
+		statements = append(statements, fmt.Sprintf("alter table %s %s row level security", pgx.Identifier{tableName}.Sanitize(), action))
+	}
+	if desired.Forced != forced {
+		action := "force"
+		if !desired.Forced {
+			action = "no force"
+		}
+		statements = append(statements, fmt.Sprintf("alter table %s %s row level security", pgx.Identifier{tableName}.Sanitize(), action))
+	}
+
+	return statements
+}
+
+// CreatePolicyStatement returns the statement to create a policy on a table
+func CreatePolicyStatement(tableName string, policy *schemasv1alpha4.PostgresqlTablePolicy) string {
+	return createPolicyStatement(pgx.Identifier{tableName}, policy)
+}
+
+func createPolicyStatement(table pgx.Identifier, policy *schemasv1alpha4.PostgresqlTablePolicy) string {
+	as := "permissive"
+	if policy.Restrictive {
+		as = "restrictive"
+	}
+
+	stmt := fmt.Sprintf("create policy %s on %s as %s for %s to %s",
+		pgx.Identifier{policy.Name}.Sanitize(), table.Sanitize(), as, strings.ToLower(policyCommand(policy.Command)), policyRolesClause(policy.Roles))
+
+	if policy.Using != nil {
+		stmt = fmt.Sprintf("%s using (%s)", stmt, *policy.Using)
+	}
+	if policy.WithCheck != nil {
+		stmt = fmt.Sprintf("%s with check (%s)", stmt, *policy.WithCheck)
+	}
+
+	return stmt
+}
+
+// DropPolicyStatement returns the statement to drop a policy from a table
+func DropPolicyStatement(tableName string, policyName string) string {
+	return fmt.Sprintf("drop policy %s on %s", pgx.Identifier{policyName}.Sanitize(), pgx.Identifier{tableName}.Sanitize())
+}
+
+// PolicyStatements compares the desired policies with the existing policies on the table. Policies that
+// only differ in roles or expressions are altered in place, other changes drop and recreate the policy.
+// Expressions are compared using the deparsed form of the desired policy when there is one.
+func PolicyStatements(tableName string, desiredPolicies []*schemasv1alpha4.PostgresqlTablePolicy, existingPolicies []*existingPolicy, deparsed map[string]*policyExpressions) []string {
+	statements := []string{}
+
+	existingByName := map[string]*existingPolicy{}
+	for _, existing := range existingPolicies {
+		existingByName[existing.Name] = existing
+	}
+
+	desiredNames := map[string]bool{}
+	for _, desired := range desiredPolicies {
+		desiredNames[desired.Name] = true
+
+		existing, ok := existingByName[desired.Name]
+		if !ok {
+			statements = append(statements, CreatePolicyStatement(tableName, desired))
+			continue
+		}
+
+		recreate := existing.Command != policyCommand(desired.Command) ||
+			existing.Restrictive != desired.Restrictive ||
+			(existing.Using != nil && desired.Using == nil) ||
+			(existing.WithCheck != nil && desired.WithCheck == nil)
+		if recreate {
+			statements = append(statements, DropPolicyStatement(tableName, desired.Name))
+			statements = append(statements, CreatePolicyStatement(tableName, desired))
+			continue
+		}
+
+		alterClauses := []string{}
+		if !policyRolesEqual(existing.Roles, desired.Roles) {
+			alterClauses = append(alterClauses, fmt.Sprintf("to %s", policyRolesClause(desired.Roles)))
+		}
+		desiredUsing, desiredWithCheck := desired.Using, desired.WithCheck
+		if expressions, ok := deparsed[desired.Name]; ok {
+			desiredUsing, desiredWithCheck = expressions.Using, expressions.WithCheck
+		}
+		if desired.Using != nil && !policyExpressionsEqual(existing.Using, desiredUsing) {
+			alterClauses = append(alterClauses, fmt.Sprintf("using (%s)", *desired.Using))
+		}
+		if desired.WithCheck != nil && !policyExpressionsEqual(existing.WithCheck, desiredWithCheck) {
+			alterClauses = append(alterClauses, fmt.Sprintf("with check (%s)", *desired.WithCheck))
+		}
+		if len(alterClauses) > 0 {
+			statements = append(statements, fmt.Sprintf("alter policy %s on %s %s",
+				pgx.Identifier{desired.Name}.Sanitize(), pgx.Identifier{tableName}.Sanitize(), strings.Join(alterClauses, " ")))
+		}
+	}
+
+	for _, existing := range existingPolicies {
+		if !desiredNames[existing.Name] {
+			statements = append(statements, DropPolicyStatement(tableName, existing.Name))
+		}
+	}
+
+	return statements
+}
+
+// createRowLevelSecurityStatements returns the row level security statements for a table that is being created
+func createRowLevelSecurityStatements(tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema) []string {
+	statements := RowLevelSecurityStatements(tableName, tableSchema.RowLevelSecurity, false, false)
+	for _, policy := range tableSchema.Policies {
+		statements = append(statements, CreatePolicyStatement(tableName, policy))
+	}
+
+	return statements
+}
+
+// BuildRowLevelSecurityStatements compares the row level security settings and policies in the schema
+// with pg_class and pg_policy
+func BuildRowLevelSecurityStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	if !managesRowLevelSecurity(postgresTableSchema) {
+		return []string{}, nil
+	}
+
+	schema := p.schema
+	if postgresTableSchema.Schema != "" {
+		schema = postgresTableSchema.Schema
+	}
+
+	query := `select c.relrowsecurity, c.relforcerowsecurity
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1 and n.nspname = $2`
+	row := p.conn.QueryRow(context.Background(), query, tableName, schema)
+	var enabled, forced bool
+	if err := row.Scan(&enabled, &forced); err != nil {
+		return nil, errors.Wrap(err, "failed to read row level security")
+	}
+
+	statements := RowLevelSecurityStatements(tableName, postgresTableSchema.RowLevelSecurity, enabled, forced)
+
+	query = `select pol.polname, pol.polcmd::text, not pol.polpermissive,
+array(select case when r = 0 then 'public' else pg_get_userbyid(r)::text end from unnest(pol.polroles) r),
+pg_get_expr(pol.polqual, pol.polrelid), pg_get_expr(pol.polwithcheck, pol.polrelid)
+from pg_policy pol
+join pg_class c on c.oid = pol.polrelid
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1 and n.nspname = $2
+order by pol.polname`
+	rows, err := p.conn.Query(context.Background(), query, tableName, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query policies")
+	}
+	defer rows.Close()
+
+	existingPolicies := []*existingPolicy{}
+	for rows.Next() {
+		policy := existingPolicy{}
+		var command string
+		if err := rows.Scan(&policy.Name, &command, &policy.Restrictive, &policy.Roles, &policy.Using, &policy.WithCheck); err != nil {
+			return nil, errors.Wrap(err, "failed to scan policy")
+		}
+		policy.Command = policyCommands[command]
+		existingPolicies = append(existingPolicies, &policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read policies")
+	}
+
+	existingNames := map[string]bool{}
+	for _, existing := range existingPolicies {
+		existingNames[existing.Name] = true
+	}
+
+	deparsed := map[string]*policyExpressions{}
+	for _, desired := range postgresTableSchema.Policies {
+		if !existingNames[desired.Name] || (desired.Using == nil && desired.WithCheck == nil) {
+			continue
+		}
+		expressions, err := deparsePolicyExpressions(p, tableName, schema, desired)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to deparse expressions of policy %s", desired.Name)
+		}
+		deparsed[desired.Name] = expressions
+	}
+
+	statements = append(statements, PolicyStatements(tableName, postgresTableSchema.Policies, existingPolicies, deparsed)...)
+
+	return statements, nil
+}
+
+// deparsePolicyExpressions creates the policy under a temporary name in a transaction that is rolled back, and
+// reads its expressions back from pg_policy so they can be compared with the existing policy. Creating the
+// policy requires the planning role to own the table, which the role applying the migration needs anyway
+func deparsePolicyExpressions(p *PostgresConnection, tableName string, schema string, policy *schemasv1alpha4.PostgresqlTablePolicy) (*policyExpressions, error) {
+	ctx := context.Background()
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	temporary := &schemasv1alpha4.PostgresqlTablePolicy{
+		Name:      "schemahero_deparse",
+		Using:     policy.Using,
+		WithCheck: policy.WithCheck,
+	}
+	if _, err := tx.Exec(ctx, createPolicyStatement(pgx.Identifier{schema, tableName}, temporary)); err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary policy")
+	}
+
+	query := `select pg_get_expr(pol.polqual, pol.polrelid), pg_get_expr(pol.polwithcheck, pol.polrelid)
+from pg_policy pol
+join pg_class c on c.oid = pol.polrelid
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1 and n.nspname = $2 and pol.polname = $3`
+	expressions := policyExpressions{}
+	if err := tx.QueryRow(ctx, query, tableName, schema, temporary.Name).Scan(&expressions.Using, &expressions.WithCheck); err != nil {
+		return nil, errors.Wrap(err, "failed to read temporary policy")
+	}
+
+	return &expressions, nil
+}
+
+func policyCommand(command string) string {
+	if command == "" {
+		return "ALL"
+	}
+	return strings.ToUpper(command)
+}
+
+func policyRolesClause(roles []string) string {
+	if len(roles) == 0 {
+		return "PUBLIC"
+	}
+
+	identifiers := []string{}
+	for _, role := range roles {
+		identifiers = append(identifiers, grantRoleIdentifier(role))
+	}
+	return strings.Join(identifiers, ", ")
+}
+
+func policyRolesEqual(existing []string, desired []string) bool {
+	if len(desired) == 0 {
+		desired = []string{"public"}
+	}
+	if len(existing) != len(desired) {
+		return false
+	}
+
+	a := append([]string{}, existing...)
+	b := []string{}
+	for _, role := range desired {
+		if strings.EqualFold(role, "public") {
+			role = "public"
+		}
+		b = append(b, role)
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// policyExpressionsEqual compares an existing expression with a desired one. Only whitespace and redundant
+// outer parentheses are ignored, so the desired expression should be deparsed by postgres first.
+func policyExpressionsEqual(existing *string, desired *string) bool {
+	if existing == nil || desired == nil {
+		return existing == desired
+	}
+	return normalizePolicyExpression(*existing) == normalizePolicyExpression(*desired)
+}
+
+func normalizePolicyExpression(expression string) string {
+	normalized := strings.Join(strings.Fields(expression), " ")
+	for strings.HasPrefix(normalized, "(") && closingParen(normalized) == len(normalized)-1 {
+		normalized = strings.TrimSpace(normalized[1 : len(normalized)-1])
+	}
+	return normalized
+}
+
+// closingParen returns the index of the parenthesis that closes the one at the start of the expression,
+// skipping quoted strings and identifiers
+func closingParen(expression string) int {
+	depth := 0
+	var quote rune
+	for i, r := range expression {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowLevelSecurityStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  *schemasv1alpha4.PostgresqlTableRowLevelSecurity
+		enabled  bool
+		forced   bool
+		expected []string
+	}{
+		{
+			name:     "unmanaged",
+			desired:  nil,
+			enabled:  true,
+			expected: []string{},
+		},
+		{
+			name:    "enable and force",
+			desired: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{Enabled: true, Forced: true},
+			expected: []string{
+				`alter table "t" enable row level security`,
+				`alter table "t" force row level security`,
+			},
+		},
+		{
+			name:    "disable",
+			desired: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{},
+			enabled: true,
+			forced:  true,
+			expected: []string{
+				`alter table "t" disable row level security`,
+				`alter table "t" no force row level security`,
+			},
+		},
+		{
+			name:     "no change",
+			desired:  &schemasv1alpha4.PostgresqlTableRowLevelSecurity{Enabled: true},
+			enabled:  true,
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := RowLevelSecurityStatements("t", test.desired, test.enabled, test.forced)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestPolicyStatements(t *testing.T) {
+	using := "tenant_id = current_setting('app.tenant')::integer"
+	existingUsing := "(tenant_id = (current_setting('app.tenant'::text))::integer)"
+	check := "owner = current_user"
+	regrouped := "(tenant_id = 1 AND a) OR b"
+	existingGrouped := "((tenant_id = 1) AND (a OR b))"
+	deparsedRegrouped := "(((tenant_id = 1) AND a) OR b)"
+
+	tests := []struct {
+		name     string
+		desired  []*schemasv1alpha4.PostgresqlTablePolicy
+		existing []*existingPolicy
+		deparsed map[string]*policyExpressions
+		expected []string
+	}{
+		{
+			name: "create policy",
+			desired: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{
+					Name:        "only_owner",
+					Command:     "update",
+					Restrictive: true,
+					Roles:       []string{"app", "public"},
+					Using:       &check,
+					WithCheck:   &check,
+				},
+			},
+			existing: []*existingPolicy{},
+			expected: []string{
+				`create policy "only_owner" on "t" as restrictive for update to "app", PUBLIC using (owner = current_user) with check (owner = current_user)`,
+			},
+		},
+		{
+			name: "deparsed expression is unchanged",
+			desired: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{
+					Name:  "tenant_isolation",
+					Using: &using,
+				},
+			},
+			existing: []*existingPolicy{
+				{
+					Name:    "tenant_isolation",
+					Command: "ALL",
+					Roles:   []string{"public"},
+					Using:   &existingUsing,
+				},
+			},
+			deparsed: map[string]*policyExpressions{
+				"tenant_isolation": {Using: &existingUsing},
+			},
+			expected: []string{},
+		},
+		{
+			name: "regrouped expression is altered",
+			desired: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{
+					Name:  "tenant_isolation",
+					Using: &regrouped,
+				},
+			},
+			existing: []*existingPolicy{
+				{
+					Name:    "tenant_isolation",
+					Command: "ALL",
+					Roles:   []string{"public"},
+					Using:   &existingGrouped,
+				},
+			},
+			deparsed: map[string]*policyExpressions{
+				"tenant_isolation": {Using: &deparsedRegrouped},
+			},
+			expected: []string{
+				`alter policy "tenant_isolation" on "t" using ((tenant_id = 1 AND a) OR b)`,
+			},
+		},
+		{
+			name: "alter roles and expression",
+			desired: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{
+					Name:  "tenant_isolation",
+					Roles: []string{"app"},
+					Using: &check,
+				},
+			},
+			existing: []*existingPolicy{
+				{
+					Name:    "tenant_isolation",
+					Command: "ALL",
+					Roles:   []string{"public"},
+					Using:   &existingUsing,
+				},
+			},
+			expected: []string{
+				`alter policy "tenant_isolation" on "t" to "app" using (owner = current_user)`,
+			},
+		},
+		{
+			name: "command change recreates and removed policies are dropped",
+			desired: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{
+					Name:    "tenant_isolation",
+					Command: "SELECT",
+					Using:   &using,
+				},
+			},
+			existing: []*existingPolicy{
+				{
+					Name:    "tenant_isolation",
+					Command: "ALL",
+					Roles:   []string{"public"},
+					Using:   &existingUsing,
+				},
+				{
+					Name:    "old_policy",
+					Command: "ALL",
+					Roles:   []string{"public"},
+				},
+			},
+			expected: []string{
+				`drop policy "tenant_isolation" on "t"`,
+				`create policy "tenant_isolation" on "t" as permissive for select to PUBLIC using (tenant_id = current_setting('app.tenant')::integer)`,
+				`drop policy "old_policy" on "t"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := PolicyStatements("t", test.desired, test.existing, test.deparsed)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_createPolicyStatement(t *testing.T) {
+	using := "tenant_id = 1"
+	policy := &schemasv1alpha4.PostgresqlTablePolicy{Name: "schemahero_deparse", Using: &using}
+
+	tests := []struct {
+		name     string
+		table    pgx.Identifier
+		expected string
+	}{
+		{
+			name:     "unqualified",
+			table:    pgx.Identifier{"users"},
+			expected: `create policy "schemahero_deparse" on "users" as permissive for all to PUBLIC using (tenant_id = 1)`,
+		},
+		{
+			name:     "schema qualified",
+			table:    pgx.Identifier{"tenants", "users"},
+			expected: `create policy "schemahero_deparse" on "tenants"."users" as permissive for all to PUBLIC using (tenant_id = 1)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, createPolicyStatement(test.table, policy))
+		})
+	}
+}
+
+func Test_normalizePolicyExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"((tenant_id = 1))", "tenant_id = 1"},
+		{"(a) OR (b)", "(a) OR (b)"},
+		{"(name = ')(')", "name = ')('"},
+		{"(id)::text  =  'x'", "(id)::text = 'x'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizePolicyExpression(test.expression))
+		})
+	}
+}
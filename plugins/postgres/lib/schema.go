@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// existingDatabaseSchema is a schema as read from pg_namespace
+type existingDatabaseSchema struct {
+	Owner   string
+	Comment string
+}
+
+func PlanPostgresDatabaseSchema(uri string, schemaName string, databaseSchema *schemasv1alpha4.PostgresqlDatabaseSchema) ([]string, error) {
+	p, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer p.Close()
+
+	query := `select pg_get_userbyid(nspowner), obj_description(oid, 'pg_namespace')
+from pg_namespace
+where nspname = $1`
+	row := p.conn.QueryRow(context.Background(), query, schemaName)
+
+	var owner string
+	var comment sql.NullString
+	var existing *existingDatabaseSchema
+	if err := row.Scan(&owner, &comment); err != nil {
+		if err != pgx.ErrNoRows {
+			return nil, errors.Wrap(err, "failed to read schema")
+		}
+	} else {
+		existing = &existingDatabaseSchema{
+			Owner:   owner,
+			Comment: comment.String,
+		}
+	}
+
+	return DatabaseSchemaStatements(schemaName, databaseSchema, existing), nil
+}
+
+// DatabaseSchemaStatements returns the statements to make the schema match the spec. existing is nil
+// when the schema does not exist. The owner and comment are only changed when they are set in the spec.
+func DatabaseSchemaStatements(schemaName string, databaseSchema *schemasv1alpha4.PostgresqlDatabaseSchema, existing *existingDatabaseSchema) []string {
+	identifier := pgx.Identifier{schemaName}.Sanitize()
+
+	if databaseSchema.IsDeleted {
+		if existing == nil {
+			return []string{}
+		}
+		return []string{fmt.Sprintf("drop schema %s", identifier)}
+	}
+
+	statements := []string{}
+	if existing == nil {
+		statement := fmt.Sprintf("create schema %s", identifier)
+		if databaseSchema.Owner != "" {
+			statement = fmt.Sprintf("%s authorization %s", statement, pgx.Identifier{databaseSchema.Owner}.Sanitize())
+		}
+		statements = append(statements, statement)
+
+		if databaseSchema.Comment != nil && *databaseSchema.Comment != "" {
+			statements = append(statements, fmt.Sprintf("comment on schema %s is %s", identifier, commentLiteral(*databaseSchema.Comment)))
+		}
+		return statements
+	}
+
+	if databaseSchema.Owner != "" && databaseSchema.Owner != existing.Owner {
+		statements = append(statements, fmt.Sprintf("alter schema %s owner to %s", identifier, pgx.Identifier{databaseSchema.Owner}.Sanitize()))
+	}
+	if databaseSchema.Comment != nil && *databaseSchema.Comment != existing.Comment {
+		statements = append(statements, fmt.Sprintf("comment on schema %s is %s", identifier, commentLiteral(*databaseSchema.Comment)))
+	}
+
+	return statements
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseSchemaStatements(t *testing.T) {
+	tests := []struct {
+		name           string
+		databaseSchema *schemasv1alpha4.PostgresqlDatabaseSchema
+		existing       *existingDatabaseSchema
+		expected       []string
+	}{
+		{
+			name:           "create",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{},
+			expected:       []string{`create schema "app"`},
+		},
+		{
+			name: "create with owner and comment",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{
+				Owner:   "app_owner",
+				Comment: strPtr("application tables"),
+			},
+			expected: []string{
+				`create schema "app" authorization "app_owner"`,
+				`comment on schema "app" is 'application tables'`,
+			},
+		},
+		{
+			name: "no changes",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{
+				Owner:   "app_owner",
+				Comment: strPtr("application tables"),
+			},
+			existing: &existingDatabaseSchema{Owner: "app_owner", Comment: "application tables"},
+			expected: []string{},
+		},
+		{
+			name:           "unmanaged owner and comment",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{},
+			existing:       &existingDatabaseSchema{Owner: "postgres", Comment: "something"},
+			expected:       []string{},
+		},
+		{
+			name: "change owner and remove comment",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{
+				Owner:   "app_owner",
+				Comment: strPtr(""),
+			},
+			existing: &existingDatabaseSchema{Owner: "postgres", Comment: "old"},
+			expected: []string{
+				`alter schema "app" owner to "app_owner"`,
+				`comment on schema "app" is null`,
+			},
+		},
+		{
+			name:           "drop",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{IsDeleted: true},
+			existing:       &existingDatabaseSchema{Owner: "postgres"},
+			expected:       []string{`drop schema "app"`},
+		},
+		{
+			name:           "drop missing",
+			databaseSchema: &schemasv1alpha4.PostgresqlDatabaseSchema{IsDeleted: true},
+			expected:       []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := DatabaseSchemaStatements("app", test.databaseSchema, test.existing)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
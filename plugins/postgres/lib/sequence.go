@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// existingSequence is a sequence as read from pg_sequences
+type existingSequence struct {
+	Increment int64
+	MinValue  int64
+	MaxValue  int64
+	Start     int64
+	Cache     int64
+	Cycle     bool
+	// OwnedBy is the table.column that owns the sequence, or empty
+	OwnedBy string
+}
+
+func PlanPostgresSequence(uri string, sequenceName string, sequenceSchema *schemasv1alpha4.PostgresqlSequenceSchema) ([]string, error) {
+	p, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer p.Close()
+
+	schema := p.schema
+	if sequenceSchema.Schema != "" {
+		schema = sequenceSchema.Schema
+	}
+
+	query := `select increment_by, min_value, max_value, start_value, cache_size, cycle
+from pg_sequences
+where schemaname = $1 and sequencename = $2`
+	row := p.conn.QueryRow(context.Background(), query, schema, sequenceName)
+
+	var existing *existingSequence
+	s := existingSequence{}
+	if err := row.Scan(&s.Increment, &s.MinValue, &s.MaxValue, &s.Start, &s.Cache, &s.Cycle); err != nil {
+		if err != pgx.ErrNoRows {
+			return nil, errors.Wrap(err, "failed to read sequence")
+		}
+	} else {
+		ownedBy, err := readSequenceOwnedBy(p, schema, sequenceName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read sequence owner")
+		}
+		s.OwnedBy = ownedBy
+		existing = &s
+	}
+
+	// the owning table may be created later in the same deploy, sequences are planned first. the table
+	// is always in the schema of the sequence
+	ownerTableExists := false
+	if sequenceSchema.OwnedBy != "" {
+		tableName, _, err := parseSequenceOwnedBy(sequenceSchema.OwnedBy)
+		if err != nil {
+			return nil, err
+		}
+		ownerTableExists, err = CheckIfTableExistsInSchema(p, schema, tableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check if owning table exists")
+		}
+	}
+
+	return SequenceStatements(sequenceName, sequenceSchema, existing, ownerTableExists)
+}
+
+// SequenceStatements returns the statements to make the sequence match the spec. existing is nil when
+// the sequence does not exist. Options that are not set in the spec are not changed. The owned by
+// clause is only written when the owning table exists, otherwise it is added on a later plan.
+func SequenceStatements(sequenceName string, sequenceSchema *schemasv1alpha4.PostgresqlSequenceSchema, existing *existingSequence, ownerTableExists bool) ([]string, error) {
+	identifier := sequenceIdentifier(sequenceName, sequenceSchema.Schema)
+
+	if sequenceSchema.IsDeleted {
+		if existing == nil {
+			return []string{}, nil
+		}
+		return []string{fmt.Sprintf("drop sequence %s", identifier)}, nil
+	}
+
+	ownedBy := ""
+	if sequenceSchema.OwnedBy != "" && ownerTableExists {
+		tableName, columnName, err := parseSequenceOwnedBy(sequenceSchema.OwnedBy)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil || existing.OwnedBy != fmt.Sprintf("%s.%s", tableName, columnName) {
+			ownedBy = sequenceIdentifier(tableName, sequenceSchema.Schema) + "." + pgx.Identifier{columnName}.Sanitize()
+		}
+	}
+
+	clauses := []string{}
+	if sequenceSchema.Increment != nil && (existing == nil || existing.Increment != *sequenceSchema.Increment) {
+		clauses = append(clauses, fmt.Sprintf("increment by %d", *sequenceSchema.Increment))
+	}
+	if sequenceSchema.MinValue != nil && (existing == nil || existing.MinValue != *sequenceSchema.MinValue) {
+		clauses = append(clauses, fmt.Sprintf("minvalue %d", *sequenceSchema.MinValue))
+	}
+	if sequenceSchema.MaxValue != nil && (existing == nil || existing.MaxValue != *sequenceSchema.MaxValue) {
+		clauses = append(clauses, fmt.Sprintf("maxvalue %d", *sequenceSchema.MaxValue))
+	}
+	if sequenceSchema.Start != nil && (existing == nil || existing.Start != *sequenceSchema.Start) {
+		clauses = append(clauses, fmt.Sprintf("start with %d", *sequenceSchema.Start))
+	}
+	if sequenceSchema.Cache != nil && (existing == nil || existing.Cache != *sequenceSchema.Cache) {
+		clauses = append(clauses, fmt.Sprintf("cache %d", *sequenceSchema.Cache))
+	}
+	if existing == nil || existing.Cycle != sequenceSchema.Cycle {
+		if sequenceSchema.Cycle {
+			clauses = append(clauses, "cycle")
+		} else if existing != nil {
+			clauses = append(clauses, "no cycle")
+		}
+	}
+	if ownedBy != "" {
+		clauses = append(clauses, fmt.Sprintf("owned by %s", ownedBy))
+	}
+
+	if existing == nil {
+		statement := fmt.Sprintf("create sequence %s", identifier)
+		if len(clauses) > 0 {
+			statement = fmt.Sprintf("%s %s", statement, strings.Join(clauses, " "))
+		}
+		return []string{statement}, nil
+	}
+
+	if len(clauses) == 0 {
+		return []string{}, nil
+	}
+	return []string{fmt.Sprintf("alter sequence %s %s", identifier, strings.Join(clauses, " "))}, nil
+}
+
+func sequenceIdentifier(name string, schema string) string {
+	if schema == "" {
+		return pgx.Identifier{name}.Sanitize()
+	}
+	return pgx.Identifier{schema, name}.Sanitize()
+}
+
+// parseSequenceOwnedBy splits an owned by value in the form table.column
+func parseSequenceOwnedBy(ownedBy string) (string, string, error) {
+	parts := strings.Split(ownedBy, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("ownedBy %q must be in the form table.column", ownedBy)
+	}
+	return parts[0], parts[1], nil
+}
+
+// readSequenceOwnedBy returns the table.column that owns the sequence, or an empty string
+func readSequenceOwnedBy(p *PostgresConnection, schema string, sequenceName string) (string, error) {
+	query := `select t.relname, a.attname
+from pg_class s
+join pg_namespace n on n.oid = s.relnamespace
+join pg_depend d on d.objid = s.oid and d.classid = 'pg_class'::regclass and d.deptype = 'a'
+join pg_class t on t.oid = d.refobjid
+join pg_attribute a on a.attrelid = d.refobjid and a.attnum = d.refobjsubid
+where n.nspname = $1 and s.relname = $2 and s.relkind = 'S'`
+	row := p.conn.QueryRow(context.Background(), query, schema, sequenceName)
+
+	var tableName, columnName string
+	if err := row.Scan(&tableName, &columnName); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s", tableName, columnName), nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestSequenceStatements(t *testing.T) {
+	defaultSequence := &existingSequence{
+		Increment: 1,
+		MinValue:  1,
+		MaxValue:  9223372036854775807,
+		Start:     1,
+		Cache:     1,
+	}
+
+	tests := []struct {
+		name             string
+		sequenceSchema   *schemasv1alpha4.PostgresqlSequenceSchema
+		existing         *existingSequence
+		ownerTableExists bool
+		expected         []string
+		wantErr          bool
+	}{
+		{
+			name:           "create with defaults",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{},
+			expected:       []string{`create sequence "order_number"`},
+		},
+		{
+			name: "create with options",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				Schema:    "app",
+				Increment: int64Ptr(10),
+				MinValue:  int64Ptr(100),
+				MaxValue:  int64Ptr(100000),
+				Start:     int64Ptr(100),
+				Cache:     int64Ptr(20),
+				Cycle:     true,
+				OwnedBy:   "orders.number",
+			},
+			ownerTableExists: true,
+			expected: []string{
+				`create sequence "app"."order_number" increment by 10 minvalue 100 maxvalue 100000 start with 100 cache 20 cycle owned by "app"."orders"."number"`,
+			},
+		},
+		{
+			name: "create before owning table",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				OwnedBy: "orders.number",
+			},
+			expected: []string{`create sequence "order_number"`},
+		},
+		{
+			name: "no changes",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				Increment: int64Ptr(1),
+				Start:     int64Ptr(1),
+			},
+			existing: defaultSequence,
+			expected: []string{},
+		},
+		{
+			name: "alter",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				Increment: int64Ptr(5),
+				Cache:     int64Ptr(1),
+				Cycle:     true,
+				OwnedBy:   "orders.number",
+			},
+			existing:         defaultSequence,
+			ownerTableExists: true,
+			expected: []string{
+				`alter sequence "order_number" increment by 5 cycle owned by "orders"."number"`,
+			},
+		},
+		{
+			name:           "remove cycle",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{},
+			existing: &existingSequence{
+				Increment: 1,
+				Cycle:     true,
+				OwnedBy:   "orders.number",
+			},
+			expected: []string{`alter sequence "order_number" no cycle`},
+		},
+		{
+			name: "already owned",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				OwnedBy: "orders.number",
+			},
+			existing:         &existingSequence{OwnedBy: "orders.number"},
+			ownerTableExists: true,
+			expected:         []string{},
+		},
+		{
+			name: "invalid owned by",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{
+				OwnedBy: "number",
+			},
+			ownerTableExists: true,
+			wantErr:          true,
+		},
+		{
+			name:           "drop",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{IsDeleted: true},
+			existing:       defaultSequence,
+			expected:       []string{`drop sequence "order_number"`},
+		},
+		{
+			name:           "drop missing",
+			sequenceSchema: &schemasv1alpha4.PostgresqlSequenceSchema{IsDeleted: true},
+			expected:       []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := SequenceStatements("order_number", test.sequenceSchema, test.existing, test.ownerTableExists)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -19,7 +19,8 @@ func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
 	tables := []*types.Table{}
 
 	for _, schema := range p.schemas {
-		query := "select table_name from information_schema.tables where table_catalog = $1 and table_schema = $2"
+		query := `select table_name, obj_description(format('%I.%I', table_schema, table_name)::regclass, 'pg_class')
+from information_schema.tables where table_catalog = $1 and table_schema = $2`
 
 		rows, err := p.conn.Query(context.Background(), query, p.databaseName, schema)
 		if err != nil {
@@ -28,7 +29,8 @@ func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
 
 		for rows.Next() {
 			tableName := ""
-			if err := rows.Scan(&tableName); err != nil {
+			var comment sql.NullString
+			if err := rows.Scan(&tableName, &comment); err != nil {
 				rows.Close()
 				return nil, errors.Wrap(err, "failed to scan row")
 			}
@@ -39,8 +41,9 @@ func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
 			}
 
 			tables = append(tables, &types.Table{
-				Name:   qualifiedName,
-				Schema: schema,
+				Name:    qualifiedName,
+				Schema:  schema,
+				Comment: comment.String,
 			})
 		}
 		rows.Close()
@@ -287,7 +290,9 @@ func (p *PostgresConnection) GetTableSchema(tableName string) ([]*types.Column,
 		actualTableName = parts[1]
 	}
 
-	query := "select column_name, data_type, character_maximum_length, column_default, is_nullable from information_schema.columns where table_name = $1 and table_schema = $2 and table_catalog = $3"
+	query := `select column_name, data_type, character_maximum_length, column_default, is_nullable,
+col_description(format('%I.%I', table_schema, table_name)::regclass, ordinal_position)
+from information_schema.columns where table_name = $1 and table_schema = $2 and table_catalog = $3`
 
 	rows, err := p.conn.Query(context.Background(), query, actualTableName, schema, p.databaseName)
 	if err != nil {
@@ -302,11 +307,16 @@ func (p *PostgresConnection) GetTableSchema(tableName string) ([]*types.Column,
 		var maxLength sql.NullInt64
 		var isNullable string
 		var columnDefault sql.NullString
+		var comment sql.NullString
 
-		if err := rows.Scan(&column.Name, &column.DataType, &maxLength, &columnDefault, &isNullable); err != nil {
+		if err := rows.Scan(&column.Name, &column.DataType, &maxLength, &columnDefault, &isNullable, &comment); err != nil {
 			return nil, err
 		}
 
+		if comment.Valid {
+			column.Comment = &comment.String
+		}
+
 		if isNullable == "NO" {
 			column.Constraints = &types.ColumnConstraints{
 				NotNull: &trueValue,
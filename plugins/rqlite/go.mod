@@ -7,7 +7,7 @@ replace github.com/schemahero/schemahero => ../..
 require (
 	github.com/hashicorp/go-plugin v1.8.0
 	github.com/pkg/errors v0.9.1
-	github.com/rqlite/gorqlite v0.0.0-20240122221808-a8a425b1a6aa
+	github.com/rqlite/gorqlite v0.0.0-20260504155303-50d445fd0ab9
 	github.com/schemahero/schemahero v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.11.1
 )
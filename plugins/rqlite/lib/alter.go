@@ -1,47 +1,83 @@
 package rqlite
 
 import (
-	"crypto/sha256"
-	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RecreateTableStatements(tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema) ([]string, error) {
-	statements := []string{}
+// RecreateTableStatements returns the statements that rebuild the table with the schema, for the changes that
+// sqlite cannot make with alter table. The rows of the columns that are in both the existing table and the schema
+// are copied to the new table. The statements do not begin and commit a transaction, rqlite does not allow them and
+// the deploy runs all the statements in one.
+func RecreateTableStatements(tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, existing *rebuild.Existing) ([]string, error) {
+	tempTableName, err := rebuild.TempTableName(tableName, rqliteTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate temp table name")
+	}
 
-	// to make this deterministic (and testable) generate a hash of the new schema
-	b, err := json.Marshal(rqliteTableSchema)
+	// the indexes and triggers are created after the rows are copied and the table is renamed
+	createSchema := rqliteTableSchema.DeepCopy()
+	createSchema.Indexes = nil
+	createSchema.Triggers = nil
+	createTableStatement, err := createTableStatement(tableName, tempTableName, createSchema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal")
+		return nil, errors.Wrap(err, "failed to generate create table statement")
 	}
-	sum := sha256.Sum256(b)
 
-	tempTableName := fmt.Sprintf("%s_%x", tableName, sum)
+	indexStatements := []string{}
+	for _, index := range rqliteTableSchema.Indexes {
+		indexStatements = append(indexStatements, AddIndexStatement(tableName, index))
+	}
 
-	statements = append(statements, fmt.Sprintf(`alter table "%s" rename to "%s"`, tableName, tempTableName))
+	var triggerStatements []string
+	if rqliteTableSchema.Triggers != nil {
+		triggerStatements = []string{}
+		for _, trigger := range rqliteTableSchema.Triggers {
+			statement, err := rebuild.CreateTriggerStatement(tableName, trigger)
+			if err != nil {
+				return nil, err
+			}
+			triggerStatements = append(triggerStatements, statement)
+		}
+	}
 
-	createTableStatement, err := CreateTableStatements(tableName, rqliteTableSchema)
+	columns, err := rebuildColumns(rqliteTableSchema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate create table statements")
+		return nil, errors.Wrap(err, "failed to convert columns")
 	}
-	statements = append(statements, createTableStatement...)
 
-	columnNames := []string{}
-	for _, column := range rqliteTableSchema.Columns {
-		columnNames = append(columnNames, column.Name)
-	}
-	statements = append(statements,
-		fmt.Sprintf("insert into %s (%s) select %s from %s", tableName, strings.Join(columnNames, ", "), strings.Join(columnNames, ", "), tempTableName),
-	)
+	return rebuild.Statements(&rebuild.Table{
+		Name:        tableName,
+		TempName:    tempTableName,
+		CreateTable: createTableStatement,
+		Columns:     columns,
+		Indexes:     indexStatements,
+		Triggers:    triggerStatements,
+	}, existing), nil
+}
 
-	statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
+// rebuildColumns returns the columns of the schema
+func rebuildColumns(rqliteTableSchema *schemasv1alpha4.RqliteTableSchema) ([]rebuild.Column, error) {
+	columns := []rebuild.Column{}
+	for _, schemaColumn := range rqliteTableSchema.Columns {
+		column, err := schemaColumnToColumn(schemaColumn)
+		if err != nil {
+			return nil, err
+		}
 
-	return statements, nil
+		columns = append(columns, rebuild.Column{
+			Name:       schemaColumn.Name,
+			Type:       column.DataType,
+			NotNull:    column.Constraints != nil && column.Constraints.NotNull != nil && *column.Constraints.NotNull,
+			HasDefault: column.ColumnDefault != nil,
+			Generated:  schemaColumn.Generated != nil,
+		})
+	}
+	return columns, nil
 }
 
 func BuildAlterIndexStatements(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema) ([]string, error) {
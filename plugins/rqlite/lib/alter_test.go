@@ -3,9 +3,12 @@ package rqlite
 import (
 	"testing"
 
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ColumnsMatch(t *testing.T) {
@@ -60,3 +63,44 @@ func Test_ColumnsMatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecreateTableStatements(t *testing.T) {
+	tableSchema := &schemasv1alpha4.RqliteTableSchema{
+		Columns: []*schemasv1alpha4.RqliteTableColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text", Constraints: &schemasv1alpha4.RqliteTableColumnConstraints{NotNull: &trueValue}},
+			{Name: "domain", Type: "text", Generated: &schemasv1alpha4.RqliteTableColumnGenerated{Expression: "substr(email, instr(email, '@') + 1)"}},
+		},
+		PrimaryKey: []string{"id"},
+	}
+	existing := &rebuild.Existing{
+		Columns: []rebuild.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text"},
+		},
+		Dependents: []*rebuild.Object{
+			{Type: "trigger", Name: "users_audit", SQL: `CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`},
+			{Type: "view", Name: "user_domains", SQL: `CREATE VIEW user_domains AS select domain from users`},
+		},
+		ForeignKeys: true,
+	}
+
+	statements, err := RecreateTableStatements("users", tableSchema, existing)
+	require.NoError(t, err)
+
+	tempTableName, err := rebuild.TempTableName("users", tableSchema)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"pragma foreign_keys = off",
+		`drop view "user_domains"`,
+		`create table "` + tempTableName + `" ("id" integer, "email" text not null, "domain" text generated always as (substr(email, instr(email, '@') + 1)) virtual, primary key ("id"))`,
+		types.WarningComment("column email of table users becomes not null, the rebuild fails if a row has a null value in it") + "\n" +
+			`insert into "` + tempTableName + `" ("id", "email") select "id", "email" from "users"`,
+		types.RebuildMarker + ` drop table "users"`,
+		`alter table "` + tempTableName + `" rename to "users"`,
+		`CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`,
+		`CREATE VIEW user_domains AS select domain from users`,
+		`pragma foreign_key_check("users")`,
+		"pragma foreign_keys = on",
+	}, statements)
+}
@@ -3,6 +3,7 @@ package rqlite
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
@@ -69,9 +70,24 @@ func rqliteColumnAsInsert(column *schemasv1alpha4.RqliteTableColumn) (string, er
 		formatted = fmt.Sprintf("%s default '%s'", formatted, *rqliteColumn.ColumnDefault)
 	}
 
+	if column.Generated != nil {
+		if rqliteColumn.ColumnDefault != nil {
+			return "", errors.Errorf("generated column %s cannot have a default", column.Name)
+		}
+		formatted = fmt.Sprintf("%s %s", formatted, generatedClause(column.Generated))
+	}
+
 	return formatted, nil
 }
 
+func generatedClause(generated *schemasv1alpha4.RqliteTableColumnGenerated) string {
+	storage := "virtual"
+	if generated.Stored {
+		storage = "stored"
+	}
+	return fmt.Sprintf("generated always as (%s) %s", generated.Expression, storage)
+}
+
 func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.RqliteTableColumn) (string, error) {
 	columnFields, err := rqliteColumnAsInsert(desiredColumn)
 	if err != nil {
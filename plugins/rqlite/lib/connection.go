@@ -7,27 +7,48 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rqlite/gorqlite"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
 type RqliteConnection struct {
 	db  *gorqlite.Connection
 	uri string
+
+	readConsistency string
+	queuedSeedData  bool
 }
 
 func Connect(url string) (*RqliteConnection, error) {
-	db, err := gorqlite.Open(url)
+	gorqliteURL, options, err := parseConnectionURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := options.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorqlite.OpenWithClient(gorqliteURL, client)
 	if err != nil {
 		return nil, err
 	}
 
 	rqliteConnection := RqliteConnection{
-		db:  db,
-		uri: url,
+		db:              db,
+		uri:             url,
+		readConsistency: options.readConsistency,
+		queuedSeedData:  options.queuedSeedData,
 	}
 
 	return &rqliteConnection, nil
 }
 
+// ReadConsistency returns the consistency level of the reads that plan the changes
+func (r *RqliteConnection) ReadConsistency() string {
+	return r.readConsistency
+}
+
 func (s RqliteConnection) Close() error {
 	if s.db == nil {
 		return nil
@@ -93,6 +114,22 @@ func (r *RqliteConnection) PlanExtensionSchema(extensionName string, extensionSc
 	return nil, errors.New("RQLite does not support extensions")
 }
 
+// PlanGrantSchema implements interfaces.SchemaHeroDatabaseConnection.PlanGrantSchema()
+func (r *RqliteConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	// RQLite doesn't have roles or privileges
+	return nil, errors.New("RQLite does not support grants")
+}
+
+// PlanDatabaseSchema implements interfaces.SchemaHeroDatabaseConnection.PlanDatabaseSchema()
+func (r *RqliteConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	return nil, errors.New("RQLite does not support schemas")
+}
+
+// PlanSequenceSchema implements interfaces.SchemaHeroDatabaseConnection.PlanSequenceSchema()
+func (r *RqliteConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	return nil, errors.New("RQLite does not support sequences")
+}
+
 // DeployStatements implements interfaces.SchemaHeroDatabaseConnection.DeployStatements()
 func (r *RqliteConnection) DeployStatements(statements []string) error {
 	if r.uri == "" {
@@ -101,6 +138,12 @@ func (r *RqliteConnection) DeployStatements(statements []string) error {
 	return DeployRqliteStatements(r.uri, statements)
 }
 
+// GetOnlineCopyProgress implements interfaces.SchemaHeroDatabaseConnection.GetOnlineCopyProgress()
+func (r *RqliteConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	// RQLite tables are not rebuilt through a shadow table copy
+	return nil, nil
+}
+
 // GenerateFixtures generates SQL statements to create tables and seed data for fixtures
 func (r *RqliteConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error) {
 	if spec.Schema == nil || spec.Schema.RQLite == nil {
@@ -120,7 +163,7 @@ func (r *RqliteConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]
 
 	// Add seed data if present
 	if spec.SeedData != nil {
-		seedStatements, err := SeedDataStatements(spec.Name, spec.SeedData)
+		seedStatements, err := SeedDataStatements(spec.Name, spec.Schema.RQLite.PrimaryKey, spec.SeedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create seed data statements")
 		}
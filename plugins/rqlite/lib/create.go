@@ -2,40 +2,217 @@ package rqlite
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func SeedDataStatements(tableName string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
-	statements := []string{}
+func SeedDataStatements(tableName string, primaryKey []string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, primaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, row := range seedData.Rows {
-		cols := []string{}
-		vals := []string{}
-		for _, col := range row.Columns {
-			cols = append(cols, col.Column)
-			if col.Value.Int != nil {
-				vals = append(vals, strconv.Itoa(*col.Value.Int))
-			} else if col.Value.Str != nil {
-				vals = append(vals, fmt.Sprintf("'%s'", *col.Value.Str))
-			}
+	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		keys, err := types.SeedDataKeysCondition(rows, primaryKey)
+		if err != nil {
+			return nil, err
+		}
+		if keys == "" {
+			statements = append(statements, fmt.Sprintf(`delete from %s`, tableName))
+		} else {
+			statements = append(statements, fmt.Sprintf(`delete from %s where not (%s)`, tableName, keys))
 		}
+	}
 
-		statement := fmt.Sprintf(`replace into %s (%s) values (%s)`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "))
+	for _, row := range rows {
+		cols, vals := strings.Join(row.Columns, ", "), strings.Join(row.Values, ", ")
+
+		var statement string
+		switch {
+		case seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly:
+			statement = fmt.Sprintf(`insert or ignore into %s (%s) values (%s)`, tableName, cols, vals)
+		case types.SeedDataReadsTable(seedData):
+			statement = fmt.Sprintf(`insert into %s (%s) values (%s) on conflict (%s) do %s`, tableName, cols, vals, strings.Join(primaryKey, ", "), upsertAction(row, primaryKey))
+		default:
+			statement = fmt.Sprintf(`replace into %s (%s) values (%s)`, tableName, cols, vals)
+		}
 		statements = append(statements, statement)
 	}
 
 	return statements, nil
 }
 
+// upsertAction updates the columns of the row that are not in the primary key
+func upsertAction(row types.SeedDataRowLiterals, primaryKey []string) string {
+	assignments := row.Assignments(primaryKey)
+	if len(assignments) == 0 {
+		return "nothing"
+	}
+	return fmt.Sprintf("update set %s", strings.Join(assignments, ", "))
+}
+
+// SeedDataDiffStatements plans the seed data from the rows in the table, so that there is a statement for each
+// row that is inserted, updated or deleted
+func SeedDataDiffStatements(r *RqliteConnection, tableName string, primaryKey []string, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+	if err := types.SeedDataRequiresPrimaryKey(tableName, seedData, primaryKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := types.SeedDataRowsLiterals(seedData, seedDataLiterals)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{}
+	if seedData.Mode == schemasv1alpha4.SeedDataModeExact {
+		deleteStatements, err := seedDataDeleteStatements(r, tableName, primaryKey, rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan seed data deletes")
+		}
+		statements = append(statements, deleteStatements...)
+	}
+
+	for _, row := range rows {
+		where, err := row.Condition(primaryKey, "=")
+		if err != nil {
+			return nil, err
+		}
+		same, err := row.Condition(row.Columns, "is")
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := r.db.QueryOne(fmt.Sprintf(`select %s from %s where %s`, same, tableName, where))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read seed data row where %s", where)
+		}
+		if !result.Next() {
+			statements = append(statements, fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(row.Columns, ", "), strings.Join(row.Values, ", ")))
+			continue
+		}
+
+		isSame := false
+		if err := result.Scan(&isSame); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan seed data row where %s", where)
+		}
+
+		if isSame || seedData.Mode == schemasv1alpha4.SeedDataModeInsertOnly {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf(`update %s set %s where %s`, tableName, strings.Join(row.Assignments(primaryKey), ", "), where))
+	}
+
+	return statements, nil
+}
+
+// seedDataDeleteStatements returns a delete statement for each row of the table that is not in the seed data
+func seedDataDeleteStatements(r *RqliteConnection, tableName string, primaryKey []string, rows []types.SeedDataRowLiterals) ([]string, error) {
+	columns := []string{}
+	for _, column := range primaryKey {
+		columns = append(columns, fmt.Sprintf("quote(%s)", column))
+	}
+
+	query := fmt.Sprintf(`select %s from %s`, strings.Join(columns, ", "), tableName)
+	keys, err := types.SeedDataKeysCondition(rows, primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	if keys != "" {
+		query = fmt.Sprintf(`%s where not (%s)`, query, keys)
+	}
+	query = fmt.Sprintf(`%s order by %s`, query, strings.Join(primaryKey, ", "))
+
+	result, err := r.db.QueryOne(query)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{}
+	for result.Next() {
+		values := make([]string, len(primaryKey))
+		dest := []interface{}{}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := result.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		conditions := []string{}
+		for i, column := range primaryKey {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", column, values[i]))
+		}
+		statements = append(statements, fmt.Sprintf(`delete from %s where %s`, tableName, strings.Join(conditions, " and ")))
+	}
+
+	return statements, nil
+}
+
+// seedDataLiterals are the literals of seed data values. sqlite stores a bool as an integer and a json document as
+// text
+var seedDataLiterals = types.SeedDataLiterals{
+	String: sqliteString,
+	Bool: func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	},
+	Bytes: func(b []byte) string {
+		return fmt.Sprintf("x'%x'", b)
+	},
+	JSON: sqliteString,
+}
+
+// sqliteString returns the string literal. The lines of a multiline string are joined with char(10), because the
+// lines of a statement are joined when it is read from a ddl file
+func sqliteString(s string) string {
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		lines = append(lines, fmt.Sprintf("'%s'", strings.ReplaceAll(line, "'", "''")))
+	}
+	return strings.Join(lines, " || char(10) || ")
+}
+
 func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.RqliteTableSchema) ([]string, error) {
+	query, err := createTableStatement(tableName, tableName, tableSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{query}
+	for _, index := range tableSchema.Indexes {
+		statements = append(statements, AddIndexStatement(tableName, index))
+	}
+
+	for _, trigger := range tableSchema.Triggers {
+		statement, err := rebuild.CreateTriggerStatement(tableName, trigger)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+// createTableStatement returns the statement that creates the table with createName. A rebuild creates the
+// table with a temporary name before it replaces the existing table
+func createTableStatement(tableName string, createName string, tableSchema *schemasv1alpha4.RqliteTableSchema) (string, error) {
 	columns := []string{}
 	for _, desiredColumn := range tableSchema.Columns {
 		columnFields, err := rqliteColumnAsInsert(desiredColumn)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		columns = append(columns, columnFields)
 	}
@@ -55,16 +232,21 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Rqlite
 		}
 	}
 
-	query := fmt.Sprintf(`create table "%s" (%s)`, tableName, strings.Join(columns, ", "))
-	if tableSchema.Strict {
-		query = fmt.Sprintf("%s strict", query)
+	for _, check := range tableSchema.Checks {
+		columns = append(columns, checkConstraintClause(check))
 	}
 
-	statements := []string{query}
-	for _, index := range tableSchema.Indexes {
-		statements = append(statements, AddIndexStatement(tableName, index))
+	query := fmt.Sprintf(`create table "%s" (%s)`, createName, strings.Join(columns, ", "))
+	if tableSchema.Strict {
+		query = fmt.Sprintf("%s strict", query)
 	}
 
-	return statements, nil
+	return query, nil
 }
 
+func checkConstraintClause(check *schemasv1alpha4.RqliteTableCheck) string {
+	if check.Name == "" {
+		return fmt.Sprintf("check (%s)", check.Expression)
+	}
+	return fmt.Sprintf(`constraint "%s" check (%s)`, check.Name, check.Expression)
+}
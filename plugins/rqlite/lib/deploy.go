@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rqlite/gorqlite"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
@@ -51,8 +52,17 @@ func PlanRqliteTableSeedDataOnly(url string, tableName string, seedData *schemas
 		return nil, errors.Errorf("table %s does not exist, cannot apply seed data without schema", tableName)
 	}
 
+	primaryKey, err := r.GetTablePrimaryKeyColumns(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table primary key")
+	}
+
+	if types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(r, tableName, primaryKey, seedData)
+	}
+
 	// Generate seed data statements
-	seedDataStatements, err := SeedDataStatements(tableName, seedData)
+	seedDataStatements, err := SeedDataStatements(tableName, primaryKey, seedData)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create seed data statements")
 	}
@@ -91,7 +101,7 @@ func PlanRqliteTable(url string, tableName string, rqliteTableSchema *schemasv1a
 
 	seedDataStatements := []string{}
 	if seedData != nil {
-		seedDataStatements, err = SeedDataStatements(tableName, seedData)
+		seedDataStatements, err = SeedDataStatements(tableName, rqliteTableSchema.PrimaryKey, seedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "create seed data statements")
 		}
@@ -111,6 +121,12 @@ func PlanRqliteTable(url string, tableName string, rqliteTableSchema *schemasv1a
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build column statements")
 	}
+
+	// the rows can be read when the table does not change, otherwise the seed data is written without them
+	if len(statements) == 0 && types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(r, tableName, rqliteTableSchema.PrimaryKey, seedData)
+	}
+
 	statements = append(statements, seedDataStatements...)
 
 	return statements, nil
@@ -124,7 +140,7 @@ p.pk AS col_is_pk,
 p.dflt_value AS col_default_val,
 p.[notnull] AS col_is_not_null
 FROM sqlite_master m
-LEFT OUTER JOIN pragma_table_info((m.name)) p
+LEFT OUTER JOIN pragma_table_xinfo((m.name)) p
 WHERE m.type = 'table'
 AND m.name = ?`
 
@@ -184,13 +200,23 @@ AND m.name = ?`
 	}
 
 	if tableNeedsRecreate {
-		hardWayStatements, err := RecreateTableStatements(tableName, rqliteTableSchema)
+		existing, err := readExistingTable(r, tableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read existing table")
+		}
+
+		hardWayStatements, err := RecreateTableStatements(tableName, rqliteTableSchema, existing)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create recreate table statements")
 		}
 
 		statements = append(statements, hardWayStatements...)
 	} else {
+		dependents, err := readDependents(r, tableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read triggers and views")
+		}
+
 		// add new columns
 		for _, desiredColumn := range rqliteTableSchema.Columns {
 			isColumnPresent := false
@@ -235,12 +261,102 @@ AND m.name = ?`
 				statements = append(statements, statement)
 			}
 		}
+
+		triggerStatements, err := rebuild.TriggerStatements(tableName, rqliteTableSchema.Triggers, rebuild.Filter(dependents, "trigger"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build trigger statements")
+		}
+		statements = append(statements, triggerStatements...)
 	}
 
 	return statements, nil
 }
 
+// readExistingTable reads the columns, triggers, views and foreign keys that a rebuild of the table has to preserve
+func readExistingTable(r *RqliteConnection, tableName string) (*rebuild.Existing, error) {
+	existing := rebuild.Existing{
+		Columns: []rebuild.Column{},
+	}
+
+	// generated columns are hidden 2 and 3
+	rows, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select name, type, "notnull", dflt_value is not null, hidden from pragma_table_xinfo(?) where hidden != 1`,
+		Arguments: []interface{}{tableName},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query columns")
+	}
+
+	for rows.Next() {
+		var column rebuild.Column
+		var hidden int64
+		if err := rows.Scan(&column.Name, &column.Type, &column.NotNull, &column.HasDefault, &hidden); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		column.Generated = hidden > 1
+		existing.Columns = append(existing.Columns, column)
+	}
+
+	dependents, err := readDependents(r, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read triggers and views")
+	}
+	existing.Dependents = dependents
+
+	row, err := r.db.QueryOne("pragma foreign_keys")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query foreign keys setting")
+	}
+	row.Next()
+	if err := row.Scan(&existing.ForeignKeys); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if !existing.ForeignKeys {
+		return &existing, nil
+	}
+
+	rows, err = r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select distinct m.name from sqlite_master m join pragma_foreign_key_list(m.name) f where m.type = 'table' and m.name != ? and f."table" = ? collate nocase`,
+		Arguments: []interface{}{tableName, tableName},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query referencing tables")
+	}
+
+	for rows.Next() {
+		var referencingTable string
+		if err := rows.Scan(&referencingTable); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		existing.ReferencingTables = append(existing.ReferencingTables, referencingTable)
+	}
+
+	return &existing, nil
+}
+
 func checkTableNeedsRecreate(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, existingColumns []types.Column) (bool, error) {
+	// check if the checks or generated columns changed
+	row, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     "select sql from sqlite_master where type = ? and name = ?",
+		Arguments: []interface{}{"table", tableName},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read table definition")
+	}
+	row.Next()
+
+	var createTableSQL string
+	if err := row.Scan(&createTableSQL); err != nil {
+		return false, errors.Wrap(err, "failed to scan")
+	}
+	existingColumnNames := map[string]bool{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames[existingColumn.Name] = true
+	}
+	if rebuild.DefinitionChanged(rqliteTableSchema, rebuild.ParseDefinition(createTableSQL), existingColumnNames) {
+		return true, nil
+	}
+
 	// check if primary keys match
 	existingPrimaryKey, err := r.GetTablePrimaryKeyColumns(tableName)
 	if err != nil {
@@ -361,6 +477,10 @@ func DeployRqliteStatements(url string, statements []string) error {
 	return nil
 }
 
+// executeStatements sends the statements to rqlite in one request that runs in a transaction, so that a failed
+// statement rolls back the others. sqlite ignores the foreign_keys pragma in a transaction, so the pragmas of a
+// rebuild are written before and after the request. When the connection queues seed data, it is written after the
+// request with queued writes, which rqlite acknowledges before they are applied.
 func executeStatements(r *RqliteConnection, statements []string) error {
 	filteredStatements := []string{}
 
@@ -380,7 +500,75 @@ func executeStatements(r *RqliteConnection, statements []string) error {
 		fmt.Println(statement)
 	}
 
-	if wrs, err := r.db.Write(filteredStatements); err != nil {
+	before, requestStatements, after, seedDataStatements := splitStatements(filteredStatements, r.queuedSeedData)
+
+	if err := writeWithoutTransaction(r, before); err != nil {
+		return err
+	}
+
+	err := request(r, requestStatements)
+	// the foreign keys are turned on again when the request fails
+	if afterErr := writeWithoutTransaction(r, after); afterErr != nil && err == nil {
+		err = afterErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(seedDataStatements) > 0 {
+		if _, err := r.db.Queue(seedDataStatements); err != nil {
+			return errors.Wrap(err, "failed to queue seed data")
+		}
+	}
+
+	return nil
+}
+
+// splitStatements returns the statements to write before the request, the statements of the request, the
+// statements to write after it and the seed data statements to queue
+func splitStatements(statements []string, queuedSeedData bool) ([]string, []string, []string, []string) {
+	before, requestStatements, after, seedDataStatements := []string{}, []string{}, []string{}, []string{}
+
+	for _, statement := range statements {
+		switch {
+		case statement == rebuild.ForeignKeysOff:
+			before = append(before, statement)
+		case statement == rebuild.ForeignKeysOn:
+			after = append(after, statement)
+		case queuedSeedData && isSeedDataStatement(statement):
+			seedDataStatements = append(seedDataStatements, statement)
+		default:
+			requestStatements = append(requestStatements, statement)
+		}
+	}
+
+	return before, requestStatements, after, seedDataStatements
+}
+
+// isSeedDataStatement returns true when the statement is from SeedDataStatements or SeedDataDiffStatements. Seed
+// data does not quote the table name, unlike the insert that copies the rows of a rebuilt table
+func isSeedDataStatement(statement string) bool {
+	statement = strings.ToLower(statement)
+	for _, prefix := range []string{"replace into ", "insert or ignore into ", "update ", "delete from "} {
+		if strings.HasPrefix(statement, prefix) {
+			return true
+		}
+	}
+
+	return strings.HasPrefix(statement, "insert into ") && !strings.HasPrefix(statement, `insert into "`)
+}
+
+func writeWithoutTransaction(r *RqliteConnection, statements []string) error {
+	if len(statements) == 0 {
+		return nil
+	}
+
+	if err := r.db.SetExecutionWithTransaction(false); err != nil {
+		return errors.Wrap(err, "failed to disable transaction")
+	}
+	defer r.db.SetExecutionWithTransaction(true)
+
+	if wrs, err := r.db.Write(statements); err != nil {
 		wrErrs := []error{}
 		for _, wr := range wrs {
 			wrErrs = append(wrErrs, wr.Err)
@@ -390,3 +578,72 @@ func executeStatements(r *RqliteConnection, statements []string) error {
 
 	return nil
 }
+
+// request sends the statements in one request that runs in a transaction
+func request(r *RqliteConnection, statements []string) error {
+	if len(statements) == 0 {
+		return nil
+	}
+
+	parameterizedStatements := []gorqlite.ParameterizedStatement{}
+	for _, statement := range statements {
+		parameterizedStatements = append(parameterizedStatements, requestStatement(statement))
+	}
+
+	results, err := r.db.RequestParameterized(parameterizedStatements)
+	if err == nil {
+		return nil
+	}
+
+	resultErrs := []string{}
+	for i, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		if i < len(statements) && rebuild.IsForeignKeyCheck(statements[i]) {
+			resultErrs = append(resultErrs, fmt.Sprintf("foreign key check failed: a row of table %s references a missing row", rebuild.ForeignKeyCheckTableName(statements[i])))
+			continue
+		}
+		resultErrs = append(resultErrs, result.Err.Error())
+	}
+	return fmt.Errorf("failed to execute request: %v: %v", err, resultErrs)
+}
+
+// requestStatement returns the statement to send in the request. A foreign key check returns the violations as
+// rows, so it is sent as a query that fails when there are rows, to roll back the transaction
+func requestStatement(statement string) gorqlite.ParameterizedStatement {
+	if !rebuild.IsForeignKeyCheck(statement) {
+		return gorqlite.ParameterizedStatement{Query: statement}
+	}
+
+	// json fails on the malformed value
+	return gorqlite.ParameterizedStatement{
+		Query:     "select case when exists (select 1 from pragma_foreign_key_check(?)) then json('foreign key check failed') end",
+		Arguments: []interface{}{rebuild.ForeignKeyCheckTableName(statement)},
+	}
+}
+
+// readDependents returns the triggers on the table and the views that use it
+func readDependents(r *RqliteConnection, tableName string) ([]*rebuild.Object, error) {
+	rows, err := r.db.QueryOne(rebuild.DependentsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query triggers and views")
+	}
+
+	dependents := []*rebuild.Object{}
+	for rows.Next() {
+		var objectType, name, objectTableName, sql string
+		if err := rows.Scan(&objectType, &name, &objectTableName, &sql); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if rebuild.IsDependent(objectType, objectTableName, sql, tableName) {
+			dependents = append(dependents, &rebuild.Object{
+				Type: objectType,
+				Name: name,
+				SQL:  sql,
+			})
+		}
+	}
+	return dependents, nil
+}
@@ -0,0 +1,64 @@
+package rqlite
+
+import (
+	"testing"
+
+	"github.com/rqlite/gorqlite"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitStatements(t *testing.T) {
+	statements := []string{
+		rebuild.ForeignKeysOff,
+		`create table "users_new" ("id" integer)`,
+		`insert into "users_new" ("id") select "id" from "users"`,
+		`drop table "users"`,
+		`alter table "users_new" rename to "users"`,
+		rebuild.ForeignKeyCheckStatement("users"),
+		rebuild.ForeignKeysOn,
+		`replace into users (id) values (1)`,
+	}
+
+	before, requestStatements, after, seedDataStatements := splitStatements(statements, false)
+	assert.Equal(t, []string{rebuild.ForeignKeysOff}, before)
+	assert.Equal(t, []string{statements[1], statements[2], statements[3], statements[4], statements[5], statements[7]}, requestStatements)
+	assert.Equal(t, []string{rebuild.ForeignKeysOn}, after)
+	assert.Empty(t, seedDataStatements)
+
+	_, requestStatements, _, seedDataStatements = splitStatements(statements, true)
+	assert.Equal(t, statements[1:6], requestStatements)
+	assert.Equal(t, []string{`replace into users (id) values (1)`}, seedDataStatements)
+}
+
+func Test_requestStatement(t *testing.T) {
+	assert.Equal(t, gorqlite.ParameterizedStatement{
+		Query: `drop table "users"`,
+	}, requestStatement(`drop table "users"`))
+
+	assert.Equal(t, gorqlite.ParameterizedStatement{
+		Query:     "select case when exists (select 1 from pragma_foreign_key_check(?)) then json('foreign key check failed') end",
+		Arguments: []interface{}{"orders"},
+	}, requestStatement(rebuild.ForeignKeyCheckStatement("orders")))
+}
+
+func Test_isSeedDataStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		expect    bool
+	}{
+		{`replace into users (id) values (1)`, true},
+		{`insert or ignore into users (id) values (1)`, true},
+		{`insert into users (id) values (1) on conflict (id) do nothing`, true},
+		{`update users set name = 'a' where id = 1`, true},
+		{`delete from users where id = 2`, true},
+		{`insert into "users_new" ("id") select "id" from "users"`, false},
+		{`create table users (id integer)`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.statement, func(t *testing.T) {
+			assert.Equal(t, test.expect, isSeedDataStatement(test.statement))
+		})
+	}
+}
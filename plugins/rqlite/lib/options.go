@@ -0,0 +1,114 @@
+package rqlite
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	nurl "net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultReadConsistency = "weak"
+	defaultTimeoutSeconds  = 10
+)
+
+// connectionOptions are the options of the connection in the query string of the url. level and timeout are
+// options of gorqlite, the others are removed from the url before it is opened
+type connectionOptions struct {
+	readConsistency       string
+	timeoutSeconds        int
+	queuedSeedData        bool
+	tlsCACertFile         string
+	tlsInsecureSkipVerify bool
+}
+
+// parseConnectionURL returns the url to open with gorqlite and the options of the connection
+func parseConnectionURL(url string) (string, *connectionOptions, error) {
+	u, err := nurl.Parse(url)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to parse url")
+	}
+
+	options := connectionOptions{
+		readConsistency: defaultReadConsistency,
+		timeoutSeconds:  defaultTimeoutSeconds,
+	}
+
+	query := u.Query()
+	if level := query.Get("level"); level != "" {
+		switch level {
+		case "none", "weak", "linearizable", "strong":
+			options.readConsistency = level
+		default:
+			return "", nil, errors.Errorf("invalid read consistency %q, expected none, weak, linearizable or strong", level)
+		}
+	}
+
+	if timeout := query.Get("timeout"); timeout != "" {
+		options.timeoutSeconds, err = strconv.Atoi(timeout)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse timeout")
+		}
+	}
+
+	if queuedSeedData := query.Get("queuedSeedData"); queuedSeedData != "" {
+		options.queuedSeedData, err = strconv.ParseBool(queuedSeedData)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse queuedSeedData")
+		}
+	}
+
+	options.tlsCACertFile = query.Get("tlsCACertFile")
+
+	if tlsInsecureSkipVerify := query.Get("tlsInsecureSkipVerify"); tlsInsecureSkipVerify != "" {
+		options.tlsInsecureSkipVerify, err = strconv.ParseBool(tlsInsecureSkipVerify)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse tlsInsecureSkipVerify")
+		}
+	}
+
+	query.Del("queuedSeedData")
+	query.Del("tlsCACertFile")
+	query.Del("tlsInsecureSkipVerify")
+	u.RawQuery = query.Encode()
+
+	return u.String(), &options, nil
+}
+
+// httpClient returns the client that gorqlite sends the requests with
+func (o *connectionOptions) httpClient() (*http.Client, error) {
+	client := http.Client{
+		Timeout: time.Duration(o.timeoutSeconds) * time.Second,
+	}
+
+	if o.tlsCACertFile == "" && !o.tlsInsecureSkipVerify {
+		return &client, nil
+	}
+
+	tlsConfig := tls.Config{
+		InsecureSkipVerify: o.tlsInsecureSkipVerify,
+	}
+	if o.tlsCACertFile != "" {
+		caCert, err := os.ReadFile(o.tlsCACertFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read tls ca cert file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse tls ca cert")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tlsConfig
+	client.Transport = transport
+
+	return &client, nil
+}
@@ -116,7 +116,7 @@ WHERE m.type='table' AND m.name=? AND il.name=? AND il.origin!='pk'
 }
 
 func (r *RqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*types.ForeignKey, error) {
-	query := `SELECT id, 'from' as child_column, 'table' as parent_table, 'to' as parent_column, 'on_delete' FROM pragma_foreign_key_list(?)`
+	query := `SELECT id, "from" as child_column, "table" as parent_table, "to" as parent_column, on_delete FROM pragma_foreign_key_list(?)`
 	rows, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
 		Query:     query,
 		Arguments: []interface{}{tableName},
@@ -1,50 +1,99 @@
 package sqlite
 
 import (
-	"crypto/sha256"
-	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RecreateTableStatements(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]string, error) {
-	statements := []string{
-		"begin transaction",
+// RecreateTableStatements returns the statements that rebuild the table with the schema, for the changes that
+// sqlite cannot make with alter table. The rows of the columns that are in both the existing table and the schema
+// are copied to the new table.
+func RecreateTableStatements(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existing *rebuild.Existing) ([]string, error) {
+	tempTableName, err := rebuild.TempTableName(tableName, sqliteTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate temp table name")
 	}
 
-	// to make this deterministic (and testable) generate a hash of the new schema
-	b, err := json.Marshal(sqliteTableSchema)
+	// the indexes and triggers are created after the rows are copied and the table is renamed
+	createSchema := sqliteTableSchema.DeepCopy()
+	createSchema.Indexes = nil
+	createSchema.Triggers = nil
+	createTableStatement, err := createTableStatement(tableName, tempTableName, createSchema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal")
+		return nil, errors.Wrap(err, "failed to generate create table statement")
 	}
-	sum := sha256.Sum256(b)
-
-	tempTableName := fmt.Sprintf("%s_%x", tableName, sum)
 
-	statements = append(statements, fmt.Sprintf(`alter table "%s" rename to "%s"`, tableName, tempTableName))
+	indexStatements := []string{}
+	for _, index := range sqliteTableSchema.Indexes {
+		indexStatements = append(indexStatements, AddIndexStatement(tableName, index))
+	}
+
+	var triggerStatements []string
+	if sqliteTableSchema.Virtual != nil {
+		// sqlite does not allow triggers on virtual tables
+		triggerStatements = []string{}
+	} else if sqliteTableSchema.Triggers != nil {
+		triggerStatements = []string{}
+		for _, trigger := range sqliteTableSchema.Triggers {
+			statement, err := rebuild.CreateTriggerStatement(tableName, trigger)
+			if err != nil {
+				return nil, err
+			}
+			triggerStatements = append(triggerStatements, statement)
+		}
+	}
 
-	createTableStatement, err := CreateTableStatements(tableName, sqliteTableSchema)
+	columns, err := rebuildColumns(sqliteTableSchema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate create table statements")
-	}
-	statements = append(statements, createTableStatement...)
+		return nil, errors.Wrap(err, "failed to convert columns")
+	}
+
+	return rebuild.Statements(&rebuild.Table{
+		Name:        tableName,
+		TempName:    tempTableName,
+		CreateTable: createTableStatement,
+		Columns:     columns,
+		Indexes:     indexStatements,
+		Triggers:    triggerStatements,
+		Transaction: true,
+	}, existing), nil
+}
 
-	columnNames := []string{}
-	for _, column := range sqliteTableSchema.Columns {
-		columnNames = append(columnNames, column.Name)
+// rebuildColumns returns the columns of the schema
+func rebuildColumns(sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]rebuild.Column, error) {
+	columns := []rebuild.Column{}
+	if sqliteTableSchema.Virtual != nil {
+		for _, columnName := range virtualTableColumns(sqliteTableSchema.Virtual) {
+			columns = append(columns, rebuild.Column{Name: columnName})
+		}
+		return columns, nil
 	}
-	statements = append(statements,
-		fmt.Sprintf("insert into %s (%s) select %s from %s", tableName, strings.Join(columnNames, ", "), strings.Join(columnNames, ", "), tempTableName),
-	)
 
-	statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
-	statements = append(statements, "commit")
+	for _, schemaColumn := range sqliteTableSchema.Columns {
+		column, err := schemaColumnToColumn(schemaColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		notNull := column.Constraints != nil && column.Constraints.NotNull != nil && *column.Constraints.NotNull
+		// the primary key columns of a without rowid table are always not null
+		if sqliteTableSchema.WithoutRowid && isPrimaryKeyColumn(sqliteTableSchema, schemaColumn.Name) {
+			notNull = true
+		}
 
-	return statements, nil
+		columns = append(columns, rebuild.Column{
+			Name:       schemaColumn.Name,
+			Type:       column.DataType,
+			NotNull:    notNull,
+			HasDefault: column.ColumnDefault != nil,
+			Generated:  schemaColumn.Generated != nil,
+		})
+	}
+	return columns, nil
 }
 
 func BuildAlterIndexStatements(r *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]string, error) {
@@ -3,9 +3,12 @@ package sqlite
 import (
 	"testing"
 
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ColumnsMatch(t *testing.T) {
@@ -60,3 +63,54 @@ func Test_ColumnsMatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecreateTableStatements(t *testing.T) {
+	tableSchema := &schemasv1alpha4.SqliteTableSchema{
+		Columns: []*schemasv1alpha4.SqliteTableColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text"},
+			{Name: "domain", Type: "text", Generated: &schemasv1alpha4.SqliteTableColumnGenerated{Expression: "substr(email, instr(email, '@') + 1)"}},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []*schemasv1alpha4.SqliteTableIndex{
+			{Name: "idx_users_email", Columns: []string{"email"}, IsUnique: true},
+		},
+	}
+	existing := &rebuild.Existing{
+		Columns: []rebuild.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text"},
+			{Name: "name", Type: "text"},
+		},
+		Dependents: []*rebuild.Object{
+			{Type: "trigger", Name: "users_audit", SQL: `CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`},
+			{Type: "view", Name: "user_domains", SQL: `CREATE VIEW user_domains AS select domain from users`},
+		},
+		ForeignKeys:       true,
+		ReferencingTables: []string{"orders"},
+	}
+
+	statements, err := RecreateTableStatements("users", tableSchema, existing)
+	require.NoError(t, err)
+	require.Len(t, statements, 14)
+
+	tempTableName, err := rebuild.TempTableName("users", tableSchema)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"pragma foreign_keys = off",
+		"begin transaction",
+		`drop view "user_domains"`,
+		`create table "` + tempTableName + `" ("id" integer, "email" text, "domain" text generated always as (substr(email, instr(email, '@') + 1)) virtual, primary key ("id"))`,
+		types.WarningComment("the data in column name of table users is dropped") + "\n" +
+			`insert into "` + tempTableName + `" ("id", "email") select "id", "email" from "users"`,
+		types.RebuildMarker + ` drop table "users"`,
+		`alter table "` + tempTableName + `" rename to "users"`,
+		`create unique index idx_users_email on users (email)`,
+		`CREATE TRIGGER "users_audit" AFTER INSERT ON "users" BEGIN select 1; END`,
+		`CREATE VIEW user_domains AS select domain from users`,
+		`pragma foreign_key_check("users")`,
+		`pragma foreign_key_check("orders")`,
+		"commit",
+		"pragma foreign_keys = on",
+	}, statements)
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
@@ -57,9 +58,24 @@ func sqliteColumnAsInsert(column *schemasv1alpha4.SqliteTableColumn) (string, er
 		formatted = fmt.Sprintf("%s default '%s'", formatted, *sqliteColumn.ColumnDefault)
 	}
 
+	if column.Generated != nil {
+		if sqliteColumn.ColumnDefault != nil {
+			return "", errors.Errorf("generated column %s cannot have a default", column.Name)
+		}
+		formatted = fmt.Sprintf("%s %s", formatted, generatedClause(column.Generated))
+	}
+
 	return formatted, nil
 }
 
+func generatedClause(generated *schemasv1alpha4.SqliteTableColumnGenerated) string {
+	storage := "virtual"
+	if generated.Stored {
+		storage = "stored"
+	}
+	return fmt.Sprintf("generated always as (%s) %s", generated.Expression, storage)
+}
+
 func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.SqliteTableColumn) (string, error) {
 	columnFields, err := sqliteColumnAsInsert(desiredColumn)
 	if err != nil {
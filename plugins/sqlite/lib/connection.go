@@ -7,6 +7,7 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
 type SqliteConnection struct {
@@ -101,11 +102,33 @@ func (s *SqliteConnection) PlanExtensionSchema(extensionName string, extensionSc
 	return nil, errors.New("SQLite does not support extensions")
 }
 
+// PlanGrantSchema generates SQL statements for managing privileges
+func (s *SqliteConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	// SQLite doesn't have roles or privileges
+	return nil, errors.New("SQLite does not support grants")
+}
+
+// PlanDatabaseSchema generates SQL statements for managing schemas
+func (s *SqliteConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	return nil, errors.New("SQLite does not support schemas")
+}
+
+// PlanSequenceSchema generates SQL statements for managing sequences
+func (s *SqliteConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	return nil, errors.New("SQLite does not support sequences")
+}
+
 // DeployStatements executes a list of SQL statements
 func (s *SqliteConnection) DeployStatements(statements []string) error {
 	return DeploySqliteStatements(s.uri, statements)
 }
 
+// GetOnlineCopyProgress returns the progress of a shadow table copy
+func (s *SqliteConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	// SQLite tables are not rebuilt through a shadow table copy
+	return nil, nil
+}
+
 // GenerateFixtures generates SQL statements to create tables and seed data for fixtures
 func (s *SqliteConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error) {
 	if spec.Schema == nil || spec.Schema.SQLite == nil {
@@ -125,7 +148,7 @@ func (s *SqliteConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]
 
 	// Add seed data if present
 	if spec.SeedData != nil {
-		seedStatements, err := SeedDataStatements(spec.Name, spec.SeedData)
+		seedStatements, err := SeedDataStatements(spec.Name, spec.Schema.SQLite.PrimaryKey, spec.SeedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create seed data statements")
 		}
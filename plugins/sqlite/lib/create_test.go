@@ -123,6 +123,90 @@ func Test_CreateTableStatement(t *testing.T) {
 				`create index idx_email on simple (email)`,
 			},
 		},
+		{
+			name: "with checks, generated columns and triggers",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				PrimaryKey: []string{
+					"id",
+				},
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+					{
+						Name: "price",
+						Type: "real",
+					},
+					{
+						Name: "quantity",
+						Type: "integer",
+					},
+					{
+						Name: "total",
+						Type: "real",
+						Generated: &schemasv1alpha4.SqliteTableColumnGenerated{
+							Expression: "price * quantity",
+							Stored:     true,
+						},
+					},
+				},
+				Checks: []*schemasv1alpha4.SqliteTableCheck{
+					{
+						Name:       "positive_price",
+						Expression: "price > 0",
+					},
+					{
+						Expression: "quantity >= 0",
+					},
+				},
+				Triggers: []*schemasv1alpha4.SqliteTableTrigger{
+					{
+						Name:       "orders_audit",
+						Event:      "after update of quantity",
+						Statements: []string{"insert into audit (order_id) values (new.id);"},
+					},
+				},
+			},
+			tableName: "orders",
+			expectedStatements: []string{
+				`create table "orders" ("id" integer, "price" real, "quantity" integer, "total" real generated always as (price * quantity) stored, primary key ("id"), constraint "positive_price" check (price > 0), check (quantity >= 0))`,
+				`create trigger "orders_audit" after update of quantity on "orders" for each row begin insert into audit (order_id) values (new.id); end`,
+			},
+		},
+		{
+			name: "strict without rowid",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				PrimaryKey: []string{
+					"name",
+				},
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{
+						Name: "name",
+						Type: "text",
+					},
+				},
+				WithoutRowid: true,
+				Strict:       true,
+			},
+			tableName: "tags",
+			expectedStatements: []string{
+				`create table "tags" ("name" text, primary key ("name")) without rowid, strict`,
+			},
+		},
+		{
+			name: "fts5 virtual table",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Virtual: &schemasv1alpha4.SqliteTableVirtual{
+					Module:    "fts5",
+					Arguments: []string{"title", "body", "tokenize = 'porter'"},
+				},
+			},
+			tableName: "docs",
+			expectedStatements: []string{
+				`create virtual table "docs" using fts5(title, body, tokenize = 'porter')`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -136,3 +220,135 @@ func Test_CreateTableStatement(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateTableStatementErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableSchema *schemasv1alpha4.SqliteTableSchema
+	}{
+		{
+			name: "without rowid requires a primary key",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "name", Type: "text"},
+				},
+				WithoutRowid: true,
+			},
+		},
+		{
+			name: "virtual table requires a module",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Virtual: &schemasv1alpha4.SqliteTableVirtual{},
+			},
+		},
+		{
+			name: "virtual table with columns",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{Name: "title", Type: "text"},
+				},
+				Virtual: &schemasv1alpha4.SqliteTableVirtual{Module: "fts5"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := CreateTableStatements("t", test.tableSchema)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_SeedDataStatements(t *testing.T) {
+	id, name, active, doc, data, createdAt := 1, "it's\nhere", true, `{"a": 1}`, "Af8=", "current_timestamp"
+	price := schemasv1alpha4.SeedDataFloat("19.90")
+	seedData := &schemasv1alpha4.SeedData{
+		Rows: []schemasv1alpha4.SeedDataRow{
+			{
+				Columns: []schemasv1alpha4.Column{
+					{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &id}},
+					{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+					{Column: "active", Value: schemasv1alpha4.SeedDataValue{Bool: &active}},
+					{Column: "price", Value: schemasv1alpha4.SeedDataValue{Float: &price}},
+					{Column: "deleted_at", Value: schemasv1alpha4.SeedDataValue{Null: true}},
+					{Column: "doc", Value: schemasv1alpha4.SeedDataValue{JSON: &doc}},
+					{Column: "data", Value: schemasv1alpha4.SeedDataValue{Bytes: &data}},
+					{Column: "created_at", Value: schemasv1alpha4.SeedDataValue{Expr: &createdAt}},
+				},
+			},
+		},
+	}
+
+	statements, err := SeedDataStatements("users", nil, seedData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`replace into users (id, name, active, price, deleted_at, doc, data, created_at) values (1, 'it''s' || char(10) || 'here', 1, 19.90, null, '{"a": 1}', x'01ff', current_timestamp)`,
+	}, statements)
+
+	seedData.Rows[0].Columns[3].Value.Null = true
+	_, err = SeedDataStatements("users", nil, seedData)
+	assert.EqualError(t, err, "seed data for column price has more than one value")
+}
+
+func Test_SeedDataStatementsModes(t *testing.T) {
+	one, two, name := 1, 2, "a"
+	rows := []schemasv1alpha4.SeedDataRow{
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &one}},
+				{Column: "name", Value: schemasv1alpha4.SeedDataValue{Str: &name}},
+			},
+		},
+		{
+			Columns: []schemasv1alpha4.Column{
+				{Column: "id", Value: schemasv1alpha4.SeedDataValue{Int: &two}},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode            schemasv1alpha4.SeedDataMode
+		expect          []string
+		expectErrSubstr string
+	}{
+		{
+			mode: schemasv1alpha4.SeedDataModeInsertOnly,
+			expect: []string{
+				`insert or ignore into users (id, name) values (1, 'a')`,
+				`insert or ignore into users (id) values (2)`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeUpsert,
+			expect: []string{
+				`insert into users (id, name) values (1, 'a') on conflict (id) do update set name = 'a'`,
+				`insert into users (id) values (2) on conflict (id) do nothing`,
+			},
+		},
+		{
+			mode: schemasv1alpha4.SeedDataModeExact,
+			expect: []string{
+				`delete from users where not ((id = 1) or (id = 2))`,
+				`insert into users (id, name) values (1, 'a') on conflict (id) do update set name = 'a'`,
+				`insert into users (id) values (2) on conflict (id) do nothing`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.mode), func(t *testing.T) {
+			statements, err := SeedDataStatements("users", []string{"id"}, &schemasv1alpha4.SeedData{Mode: test.mode, Rows: rows})
+			if test.expectErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, statements)
+		})
+	}
+
+	_, err := SeedDataStatements("users", nil, &schemasv1alpha4.SeedData{Mode: schemasv1alpha4.SeedDataModeUpsert, Rows: rows})
+	assert.EqualError(t, err, "seed data mode upsert requires table users to have a primary key")
+}
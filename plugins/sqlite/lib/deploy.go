@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
@@ -40,8 +41,17 @@ func PlanSqliteTableSeedDataOnly(dsn string, tableName string, seedData *schemas
 		return nil, errors.Errorf("table %s does not exist, cannot apply seed data without schema", tableName)
 	}
 
+	primaryKey, err := s.GetTablePrimaryKeyColumns(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table primary key")
+	}
+
+	if types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(s, tableName, primaryKey, seedData)
+	}
+
 	// Generate seed data statements
-	seedDataStatements, err := SeedDataStatements(tableName, seedData)
+	seedDataStatements, err := SeedDataStatements(tableName, primaryKey, seedData)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create seed data statements")
 	}
@@ -72,7 +82,7 @@ func PlanSqliteTable(dsn string, tableName string, sqliteTableSchema *schemasv1a
 
 	seedDataStatements := []string{}
 	if seedData != nil {
-		seedDataStatements, err = SeedDataStatements(tableName, seedData)
+		seedDataStatements, err = SeedDataStatements(tableName, sqliteTableSchema.PrimaryKey, seedData)
 		if err != nil {
 			return nil, errors.Wrap(err, "create seed data statements")
 		}
@@ -92,12 +102,28 @@ func PlanSqliteTable(dsn string, tableName string, sqliteTableSchema *schemasv1a
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build column statements")
 	}
+
+	// the rows can be read when the table does not change, otherwise the seed data is written without them
+	if len(statements) == 0 && types.SeedDataReadsTable(seedData) {
+		return SeedDataDiffStatements(s, tableName, sqliteTableSchema.PrimaryKey, seedData)
+	}
+
 	statements = append(statements, seedDataStatements...)
 
 	return statements, nil
 }
 
 func buildStatements(s *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]string, error) {
+	createTableSQL, err := readCreateTableSQL(s, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read table definition")
+	}
+
+	existingVirtual := parseVirtualTable(createTableSQL)
+	if existingVirtual != nil || sqliteTableSchema.Virtual != nil {
+		return buildVirtualTableStatements(s, tableName, sqliteTableSchema, existingVirtual)
+	}
+
 	query := `SELECT
 p.name AS col_name,
 p.type AS col_type,
@@ -105,7 +131,7 @@ p.pk AS col_is_pk,
 p.dflt_value AS col_default_val,
 p.[notnull] AS col_is_not_null
 FROM sqlite_master m
-LEFT OUTER JOIN pragma_table_info((m.name)) p
+LEFT OUTER JOIN pragma_table_xinfo((m.name)) p
 WHERE m.type = 'table'
 AND m.name = ?`
 
@@ -157,19 +183,29 @@ AND m.name = ?`
 		existingColumns = append(existingColumns, existingColumn)
 	}
 
-	tableNeedsRecreate, err := checkTableNeedsRecreate(s, tableName, sqliteTableSchema, existingColumns)
+	tableNeedsRecreate, err := checkTableNeedsRecreate(s, tableName, createTableSQL, sqliteTableSchema, existingColumns)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to check if table needs recreate")
 	}
 
 	if tableNeedsRecreate {
-		hardWayStatements, err := RecreateTableStatements(tableName, sqliteTableSchema)
+		existing, err := readExistingTable(s, tableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read existing table")
+		}
+
+		hardWayStatements, err := RecreateTableStatements(tableName, sqliteTableSchema, existing)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create recreate table statements")
 		}
 
 		statements = append(statements, hardWayStatements...)
 	} else {
+		dependents, err := readDependents(s, tableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read triggers and views")
+		}
+
 		// add new columns
 		for _, desiredColumn := range sqliteTableSchema.Columns {
 			isColumnPresent := false
@@ -214,12 +250,124 @@ AND m.name = ?`
 				statements = append(statements, statement)
 			}
 		}
+
+		triggerStatements, err := rebuild.TriggerStatements(tableName, sqliteTableSchema.Triggers, rebuild.Filter(dependents, "trigger"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build trigger statements")
+		}
+		statements = append(statements, triggerStatements...)
+	}
+
+	return statements, nil
+}
+
+// buildVirtualTableStatements returns the statements to move the table to the schema when the table or the schema
+// is a virtual table. A virtual table cannot be altered, so a change recreates it and copies the rows.
+func buildVirtualTableStatements(s *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingVirtual *schemasv1alpha4.SqliteTableVirtual) ([]string, error) {
+	if virtualTablesEqual(sqliteTableSchema.Virtual, existingVirtual) {
+		return []string{}, nil
+	}
+
+	existing, err := readExistingTable(s, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing table")
+	}
+
+	statements, err := RecreateTableStatements(tableName, sqliteTableSchema, existing)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create recreate table statements")
 	}
 
 	return statements, nil
 }
 
-func checkTableNeedsRecreate(s *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingColumns []types.Column) (bool, error) {
+// readExistingTable reads the columns, triggers, views and foreign keys that a rebuild of the table has to preserve
+func readExistingTable(s *SqliteConnection, tableName string) (*rebuild.Existing, error) {
+	existing := rebuild.Existing{
+		Columns: []rebuild.Column{},
+	}
+
+	// the hidden columns of a virtual table cannot be copied, generated columns are hidden 2 and 3
+	rows, err := s.db.Query(`select name, type, "notnull", dflt_value is not null, hidden from pragma_table_xinfo(?) where hidden != 1`, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query columns")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column rebuild.Column
+		var hidden int
+		if err := rows.Scan(&column.Name, &column.Type, &column.NotNull, &column.HasDefault, &hidden); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		column.Generated = hidden > 1
+		existing.Columns = append(existing.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read columns")
+	}
+
+	dependents, err := readDependents(s, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read triggers and views")
+	}
+	existing.Dependents = dependents
+
+	if err := s.db.QueryRow("pragma foreign_keys").Scan(&existing.ForeignKeys); err != nil {
+		return nil, errors.Wrap(err, "failed to read foreign keys setting")
+	}
+	if !existing.ForeignKeys {
+		return &existing, nil
+	}
+
+	referencingRows, err := s.db.Query(`select distinct m.name from sqlite_master m join pragma_foreign_key_list(m.name) f where m.type = 'table' and m.name != ?1 and f."table" = ?1 collate nocase`, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query referencing tables")
+	}
+	defer referencingRows.Close()
+
+	for referencingRows.Next() {
+		var referencingTable string
+		if err := referencingRows.Scan(&referencingTable); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+		existing.ReferencingTables = append(existing.ReferencingTables, referencingTable)
+	}
+	if err := referencingRows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read referencing tables")
+	}
+
+	return &existing, nil
+}
+
+func isPrimaryKeyColumn(sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, columnName string) bool {
+	for _, primaryKeyColumn := range sqliteTableSchema.PrimaryKey {
+		if primaryKeyColumn == columnName {
+			return true
+		}
+	}
+	return false
+}
+
+func readCreateTableSQL(s *SqliteConnection, tableName string) (string, error) {
+	var createTableSQL string
+	row := s.db.QueryRow("select sql from sqlite_master where type = 'table' and name = ?", tableName)
+	if err := row.Scan(&createTableSQL); err != nil {
+		return "", errors.Wrap(err, "failed to scan")
+	}
+	return createTableSQL, nil
+}
+
+func checkTableNeedsRecreate(s *SqliteConnection, tableName string, createTableSQL string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingColumns []types.Column) (bool, error) {
+	// check if the checks, generated columns or rowid changed
+	existingColumnNames := map[string]bool{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames[existingColumn.Name] = true
+	}
+	if rebuild.DefinitionChanged(sqliteTableSchema, rebuild.ParseDefinition(createTableSQL), existingColumnNames) {
+		return true, nil
+	}
+
 	// check if primary keys match
 	existingPrimaryKey, err := s.GetTablePrimaryKeyColumns(tableName)
 	if err != nil {
@@ -264,6 +412,13 @@ nextForeignKey:
 				if err != nil {
 					return false, errors.Wrap(err, "failed to convert desired column")
 				}
+				// the primary key columns of a without rowid table are always not null
+				if sqliteTableSchema.WithoutRowid && isPrimaryKeyColumn(sqliteTableSchema, desiredColumn.Name) {
+					if col.Constraints == nil {
+						col.Constraints = &types.ColumnConstraints{}
+					}
+					col.Constraints.NotNull = &trueValue
+				}
 				if !columnsMatch(*col, existingColumn) {
 					return true, nil
 				}
@@ -337,15 +492,87 @@ func DeploySqliteStatements(dsn string, statements []string) error {
 }
 
 func executeStatements(s *SqliteConnection, statements []string) error {
+	// pragmas and transactions are set on a connection, so all statements run on the same connection
+	conn, err := s.db.Conn(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer conn.Close()
+
 	for _, statement := range statements {
 		if statement == "" {
 			continue
 		}
+		if rebuild.IsForeignKeyCheck(statement) {
+			if err := checkForeignKeys(conn, statement); err != nil {
+				return err
+			}
+			continue
+		}
 		// Statement is already printed by the main process
-		if _, err := s.db.ExecContext(context.Background(), statement); err != nil {
+		if _, err := conn.ExecContext(context.Background(), statement); err != nil {
 			return errors.Wrap(err, "failed to execute")
 		}
 	}
 
 	return nil
 }
+
+// checkForeignKeys runs a foreign key check and returns an error when a row violates a foreign key. An open
+// transaction is rolled back when the connection is closed
+func checkForeignKeys(conn *sql.Conn, statement string) error {
+	rows, err := conn.QueryContext(context.Background(), statement)
+	if err != nil {
+		return errors.Wrap(err, "failed to check foreign keys")
+	}
+	defer rows.Close()
+
+	violations := []string{}
+	for rows.Next() {
+		var tableName, parentTableName string
+		var rowID sql.NullInt64
+		var foreignKeyID int
+		if err := rows.Scan(&tableName, &rowID, &parentTableName, &foreignKeyID); err != nil {
+			return errors.Wrap(err, "failed to scan")
+		}
+		violations = append(violations, fmt.Sprintf("row %d of table %s references a missing row of table %s", rowID.Int64, tableName, parentTableName))
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to read foreign key check")
+	}
+
+	if len(violations) > 0 {
+		return errors.Errorf("foreign key check failed: %s", strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// readDependents returns the triggers on the table and the views that use it
+func readDependents(s *SqliteConnection, tableName string) ([]*rebuild.Object, error) {
+	rows, err := s.db.Query(rebuild.DependentsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query triggers and views")
+	}
+	defer rows.Close()
+
+	dependents := []*rebuild.Object{}
+	for rows.Next() {
+		var objectType, name, objectTableName, sql string
+		if err := rows.Scan(&objectType, &name, &objectTableName, &sql); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if rebuild.IsDependent(objectType, objectTableName, sql, tableName) {
+			dependents = append(dependents, &rebuild.Object{
+				Type: objectType,
+				Name: name,
+				SQL:  sql,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read triggers and views")
+	}
+
+	return dependents, nil
+}
@@ -8,7 +8,9 @@ import (
 )
 
 func (s *SqliteConnection) ListTables() ([]*types.Table, error) {
-	query := "SELECT name FROM sqlite_master WHERE type='table'"
+	// the shadow tables that store the content of virtual tables, such as the _data and _idx tables of fts5, are
+	// managed by the module
+	query := "SELECT name FROM sqlite_master WHERE type='table' AND name NOT IN (SELECT name FROM pragma_table_list WHERE type='shadow')"
 
 	rows, err := s.db.Query(query)
 	if err != nil {
@@ -98,7 +100,7 @@ WHERE m.type='table' AND m.name=? AND il.name=? AND il.origin!='pk'
 }
 
 func (s *SqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*types.ForeignKey, error) {
-	query := `SELECT id, 'from' as child_column, 'table' as parent_table, 'to' as parent_column, 'on_delete' FROM pragma_foreign_key_list(?)`
+	query := `SELECT id, "from" as child_column, "table" as parent_table, "to" as parent_column, on_delete FROM pragma_foreign_key_list(?)`
 	rows, err := s.db.Query(query, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query foreign keys")
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
+)
+
+var virtualTableRegexp = regexp.MustCompile(`(?is)^\s*create\s+virtual\s+table\s+(?:if\s+not\s+exists\s+)?(?:"[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\S+)\s+using\s+(\w+)\s*(\(.*)?$`)
+
+// CreateVirtualTableStatement returns the statement to create a table with a module. The columns and options of
+// a virtual table are the arguments of the module, so the schema cannot declare columns, keys or indexes.
+func CreateVirtualTableStatement(tableName string, tableSchema *schemasv1alpha4.SqliteTableSchema) (string, error) {
+	virtual := tableSchema.Virtual
+	if virtual.Module == "" {
+		return "", errors.Errorf("virtual table %s requires a module", tableName)
+	}
+	if len(tableSchema.Columns) > 0 || len(tableSchema.PrimaryKey) > 0 || len(tableSchema.ForeignKeys) > 0 || len(tableSchema.Indexes) > 0 {
+		return "", errors.Errorf("virtual table %s cannot have columns, keys or indexes, they are set by the module arguments", tableName)
+	}
+	if len(tableSchema.Checks) > 0 || len(tableSchema.Triggers) > 0 {
+		return "", errors.Errorf("virtual table %s cannot have checks or triggers", tableName)
+	}
+	if tableSchema.Strict || tableSchema.WithoutRowid {
+		return "", errors.Errorf("virtual table %s cannot be strict or without rowid", tableName)
+	}
+
+	stmt := fmt.Sprintf(`create virtual table "%s" using %s`, tableName, virtual.Module)
+	if len(virtual.Arguments) > 0 {
+		stmt = fmt.Sprintf("%s(%s)", stmt, strings.Join(virtual.Arguments, ", "))
+	}
+
+	return stmt, nil
+}
+
+// parseVirtualTable returns the module and arguments of a create virtual table statement, or nil when the
+// statement creates an ordinary table
+func parseVirtualTable(createTableSQL string) *schemasv1alpha4.SqliteTableVirtual {
+	match := virtualTableRegexp.FindStringSubmatch(createTableSQL)
+	if match == nil {
+		return nil
+	}
+
+	virtual := schemasv1alpha4.SqliteTableVirtual{
+		Module: match[1],
+	}
+	if match[2] != "" {
+		if body, _ := rebuild.EnclosedExpression(match[2]); body != "" {
+			virtual.Arguments = rebuild.SplitTopLevel(body)
+		}
+	}
+
+	return &virtual
+}
+
+func virtualTablesEqual(a *schemasv1alpha4.SqliteTableVirtual, b *schemasv1alpha4.SqliteTableVirtual) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if !strings.EqualFold(a.Module, b.Module) || len(a.Arguments) != len(b.Arguments) {
+		return false
+	}
+	for i := range a.Arguments {
+		if rebuild.NormalizeExpression(a.Arguments[i]) != rebuild.NormalizeExpression(b.Arguments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// virtualTableColumns returns the columns in the arguments of a virtual table. Arguments that set an option,
+// such as tokenize = 'porter', are not columns
+func virtualTableColumns(virtual *schemasv1alpha4.SqliteTableVirtual) []string {
+	columns := []string{}
+	for _, argument := range virtual.Arguments {
+		if strings.Contains(argument, "=") {
+			continue
+		}
+		fields := strings.Fields(argument)
+		if len(fields) == 0 {
+			continue
+		}
+		// rtree auxiliary columns start with a +
+		columns = append(columns, rebuild.UnquoteIdentifier(strings.TrimPrefix(fields[0], "+")))
+	}
+	return columns
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/rebuild"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseVirtualTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		sql    string
+		expect *schemasv1alpha4.SqliteTableVirtual
+	}{
+		{
+			name: "fts5",
+			sql:  `CREATE VIRTUAL TABLE "docs" USING fts5(title, body, tokenize = 'porter ascii')`,
+			expect: &schemasv1alpha4.SqliteTableVirtual{
+				Module:    "fts5",
+				Arguments: []string{"title", "body", "tokenize = 'porter ascii'"},
+			},
+		},
+		{
+			name: "no arguments",
+			sql:  `CREATE VIRTUAL TABLE temp_series USING generate_series`,
+			expect: &schemasv1alpha4.SqliteTableVirtual{
+				Module: "generate_series",
+			},
+		},
+		{
+			name:   "ordinary table",
+			sql:    `CREATE TABLE "docs" ("title" text)`,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, parseVirtualTable(test.sql))
+		})
+	}
+}
+
+func Test_virtualTablesEqual(t *testing.T) {
+	existing := parseVirtualTable(`CREATE VIRTUAL TABLE "docs" USING fts5(title, body, tokenize = 'porter')`)
+
+	assert.True(t, virtualTablesEqual(&schemasv1alpha4.SqliteTableVirtual{
+		Module:    "FTS5",
+		Arguments: []string{"title", "body", "tokenize='porter'"},
+	}, existing))
+	assert.False(t, virtualTablesEqual(&schemasv1alpha4.SqliteTableVirtual{
+		Module:    "fts5",
+		Arguments: []string{"title", "body", "summary", "tokenize = 'porter'"},
+	}, existing))
+	assert.False(t, virtualTablesEqual(nil, existing))
+}
+
+func Test_RecreateVirtualTableStatements(t *testing.T) {
+	tableSchema := &schemasv1alpha4.SqliteTableSchema{
+		Virtual: &schemasv1alpha4.SqliteTableVirtual{
+			Module:    "fts5",
+			Arguments: []string{"title", "body UNINDEXED", "summary", "tokenize = 'porter'"},
+		},
+	}
+	existing := &rebuild.Existing{
+		Columns: []rebuild.Column{
+			{Name: "title"},
+			{Name: "body"},
+		},
+		Dependents: []*rebuild.Object{
+			{Type: "view", Name: "doc_titles", SQL: `CREATE VIEW doc_titles AS select title from docs`},
+		},
+	}
+
+	statements, err := RecreateTableStatements("docs", tableSchema, existing)
+	require.NoError(t, err)
+
+	tempTableName, err := rebuild.TempTableName("docs", tableSchema)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"begin transaction",
+		`drop view "doc_titles"`,
+		`create virtual table "` + tempTableName + `" using fts5(title, body UNINDEXED, summary, tokenize = 'porter')`,
+		`insert into "` + tempTableName + `" ("title", "body") select "title", "body" from "docs"`,
+		types.RebuildMarker + ` drop table "docs"`,
+		`alter table "` + tempTableName + `" rename to "docs"`,
+		`CREATE VIEW doc_titles AS select title from docs`,
+		"commit",
+	}, statements)
+}
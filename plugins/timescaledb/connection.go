@@ -105,10 +105,26 @@ func (t *TimescaleDBConnection) PlanExtensionSchema(extensionName string, extens
 	return t.PostgresConnection.PlanExtensionSchema(extensionName, extensionSchema)
 }
 
+func (t *TimescaleDBConnection) PlanGrantSchema(grantName string, grantSchema interface{}) ([]string, error) {
+	return t.PostgresConnection.PlanGrantSchema(grantName, grantSchema)
+}
+
+func (t *TimescaleDBConnection) PlanDatabaseSchema(schemaName string, databaseSchema interface{}) ([]string, error) {
+	return t.PostgresConnection.PlanDatabaseSchema(schemaName, databaseSchema)
+}
+
+func (t *TimescaleDBConnection) PlanSequenceSchema(sequenceName string, sequenceSchema interface{}) ([]string, error) {
+	return t.PostgresConnection.PlanSequenceSchema(sequenceName, sequenceSchema)
+}
+
 func (t *TimescaleDBConnection) DeployStatements(statements []string) error {
 	return t.PostgresConnection.DeployStatements(statements)
 }
 
+func (t *TimescaleDBConnection) GetOnlineCopyProgress(tableName string) (*types.OnlineCopyProgress, error) {
+	return t.PostgresConnection.GetOnlineCopyProgress(tableName)
+}
+
 // GenerateFixtures generates SQL statements to create tables and seed data for fixtures
 func (t *TimescaleDBConnection) GenerateFixtures(spec *schemasv1alpha4.TableSpec) ([]string, error) {
 	if spec.Schema == nil {
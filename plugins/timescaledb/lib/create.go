@@ -11,21 +11,33 @@ import (
 )
 
 func CreateViewStatements(viewName string, viewSchema *schemasv1alpha4.TimescaleDBViewSchema) ([]string, error) {
-	if viewSchema.IsContinuousAggregate != nil && *viewSchema.IsContinuousAggregate {
+	if isContinuousAggregateSchema(viewSchema) {
 		// continuous aggregate views are created with "create materialized view"
 		withDataStatement := "with data"
 		if viewSchema.WithNoData != nil && *viewSchema.WithNoData {
 			withDataStatement = "with no data"
 		}
 
+		options := "timescaledb.continuous"
+		if viewSchema.MaterializedOnly != nil {
+			options = fmt.Sprintf("%s, timescaledb.materialized_only = %t", options, *viewSchema.MaterializedOnly)
+		}
+
 		statements := []string{
-			fmt.Sprintf(`create materialized view %s with (timescaledb.continuous) as %s %s`,
+			fmt.Sprintf(`create materialized view %s with (%s) as %s %s`,
 				pgx.Identifier{viewName}.Sanitize(),
+				options,
 				viewSchema.Query,
 				withDataStatement),
+			queryCommentStatement(viewName, viewSchema.Query),
+		}
+
+		settingsStatements, err := continuousAggregateSettingsStatements(viewName, viewSchema, nil)
+		if err != nil {
+			return nil, err
 		}
 
-		return statements, nil
+		return append(statements, settingsStatements...), nil
 	}
 
 	// create the views as a postgres view
@@ -51,21 +63,11 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Timesc
 		}
 	}
 
-	// check for compression
-	if tableSchema.Hypertable != nil && tableSchema.Hypertable.Compression != nil {
-		stmts, err := createCompressionStatements(tableName, tableSchema.Hypertable, tableSchema.Columns)
-		if err != nil {
-			return nil, errors.Wrap(err, "create compression statements")
-		}
-
-		statements = append(statements, stmts...)
-	}
-
-	// check for retention
-	if tableSchema.Hypertable != nil && tableSchema.Hypertable.Retention != nil {
-		stmts, err := createRetentionStatements(tableName, tableSchema.Hypertable, tableSchema.Columns)
+	// compression settings and policies
+	if tableSchema.Hypertable != nil {
+		stmts, err := PolicyStatements(tableName, tableSchema.Hypertable, tableSchema.Columns, nil)
 		if err != nil {
-			return nil, errors.Wrap(err, "create retention statements")
+			return nil, errors.Wrap(err, "create policy statements")
 		}
 
 		statements = append(statements, stmts...)
@@ -74,55 +76,6 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Timesc
 	return statements, nil
 }
 
-func createRetentionStatements(tableName string, hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn) ([]string, error) {
-	if hypertable.Retention == nil {
-		return nil, nil
-	}
-
-	stmts := []string{}
-
-	if hypertable.Retention != nil {
-		if hypertable.Retention.Interval != "" {
-			if !isValidInterval(hypertable.Retention.Interval) {
-				return nil, errors.New("invalid interval")
-			}
-
-			stmt := fmt.Sprintf(`select add_retention_policy(%s, interval '%s')`, strings.ReplaceAll(pgx.Identifier{tableName}.Sanitize(), "\"", "'"), hypertable.Retention.Interval)
-			stmts = append(stmts, stmt)
-		}
-	}
-
-	return stmts, nil
-}
-
-func createCompressionStatements(tableName string, hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn) ([]string, error) {
-	if hypertable.Compression == nil {
-		return nil, nil
-	}
-
-	stmts := []string{}
-
-	if hypertable.Compression.SegmentBy != nil {
-		if !columnExists(*hypertable.Compression.SegmentBy, columns) {
-			return nil, errors.New("compression column not found")
-		}
-
-		stmt := fmt.Sprintf(`alter table %s set (timescaledb.compress, timescaledb.compress_segmentby = %s)`, pgx.Identifier{tableName}.Sanitize(), pgx.Identifier{*hypertable.Compression.SegmentBy}.Sanitize())
-		stmts = append(stmts, stmt)
-	}
-
-	if hypertable.Compression.Interval != nil {
-		if !isValidInterval(*hypertable.Compression.Interval) {
-			return nil, errors.New("invalid interval")
-		}
-
-		stmt := fmt.Sprintf(`select add_compression_policy(%s, INTERVAL '%s')`, strings.ReplaceAll(pgx.Identifier{tableName}.Sanitize(), "\"", "'"), *hypertable.Compression.Interval)
-		stmts = append(stmts, stmt)
-	}
-
-	return stmts, nil
-}
-
 func toPostgresTableSchema(tableSchema *schemasv1alpha4.TimescaleDBTableSchema) *schemasv1alpha4.PostgresqlTableSchema {
 	return &schemasv1alpha4.PostgresqlTableSchema{
 		PrimaryKey:  tableSchema.PrimaryKey,
@@ -130,6 +83,7 @@ func toPostgresTableSchema(tableSchema *schemasv1alpha4.TimescaleDBTableSchema)
 		Indexes:     tableSchema.Indexes,
 		Columns:     tableSchema.Columns,
 		IsDeleted:   tableSchema.IsDeleted,
+		Comment:     tableSchema.Comment,
 		Triggers:    tableSchema.Triggers,
 	}
 }
@@ -154,8 +108,15 @@ func columnExists(name string, columns []*schemasv1alpha4.PostgresqlTableColumn)
 	return false
 }
 
-func isValidInterval(val string) bool {
-	return true // TODO
+// isValidInterval returns true when the interval can be passed to timescaledb. The intervals of an integer time
+// column must be integers, other intervals are checked by postgres and only need to be quotable
+func isValidInterval(val string, integer bool) bool {
+	if integer {
+		_, ok := parseIntegerInterval(val)
+		return ok
+	}
+
+	return strings.TrimSpace(val) != "" && !strings.Contains(val, "'")
 }
 
 func getHypertableParams(hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn) ([]string, error) {
@@ -182,11 +143,12 @@ func getHypertableParams(hypertable *schemasv1alpha4.TimescaleDBHypertable, colu
 	}
 
 	if hypertable.ChunkTimeInterval != nil {
-		if !isValidInterval(*hypertable.ChunkTimeInterval) {
-			return nil, errors.New("invalid chunk time interval")
+		interval, err := intervalArgument(*hypertable.ChunkTimeInterval, isIntegerTimeColumn(hypertable, columns))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid chunk time interval")
 		}
 
-		params = append(params, fmt.Sprintf("chunk_time_interval => INTERVAL '%s'", *hypertable.ChunkTimeInterval))
+		params = append(params, fmt.Sprintf("chunk_time_interval => %s", interval))
 	}
 
 	if hypertable.CreateDefaultIndexes != nil {
@@ -55,8 +55,13 @@ func PlanTimescaleDBView(uri string, viewName string, viewSchema *schemasv1alpha
 				fmt.Sprintf(`drop materialized view %s`, pgx.Identifier{viewName}.Sanitize()),
 			}, nil
 		}
-	} else if viewExists > 0 && !viewSchema.IsDeleted {
-		// TODO: Alter view.  Some properties can be altered, but to alter the SQL, a new view should be created.
+	} else if viewExists > 0 && isContinuousAggregate && isContinuousAggregateSchema(viewSchema) {
+		existing, err := getContinuousAggregate(p, viewName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get continuous aggregate")
+		}
+
+		return ContinuousAggregateStatements(viewName, viewSchema, existing)
 	}
 
 	// if the view doesn't exist, shortcut to create
@@ -124,6 +129,13 @@ func PlanTimescaleDBTable(uri string, tableName string, tableSchema *schemasv1al
 	}
 	statements = append(statements, columnStatements...)
 
+	// table and column comments
+	commentStatements, err := postgres.BuildCommentStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build comment statements")
+	}
+	statements = append(statements, commentStatements...)
+
 	// primary key changes
 	primaryKeyStatements, err := postgres.BuildPrimaryKeyStatements(p, tableName, postgresTableSchema)
 	if err != nil {
@@ -152,7 +164,17 @@ func PlanTimescaleDBTable(uri string, tableName string, tableSchema *schemasv1al
 	}
 	statements = append(statements, hypertableStatements...)
 
-	// seed data
+	// compression settings and policies
+	policyStatements, err := BuildPolicyStatements(p, tableName, tableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build policy statements")
+	}
+	statements = append(statements, policyStatements...)
+
+	// seed data, read from the rows of the table when it does not change
+	if len(statements) == 0 && types.SeedDataReadsTable(seedData) {
+		return postgres.SeedDataDiffStatements(p, tableName, postgresTableSchema.PrimaryKey, seedData)
+	}
 	statements = append(statements, seedDataStatements...)
 
 	return statements, nil
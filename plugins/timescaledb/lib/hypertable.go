@@ -3,6 +3,7 @@ package timescaledb
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
@@ -11,41 +12,109 @@ import (
 	postgres "github.com/schemahero/schemahero/plugins/postgres/lib"
 )
 
-func BuildHypertableStatements(p *postgres.PostgresConnection, tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema) ([]string, error) {
-	hypertableStatements := []string{}
+// existingHypertable is a hypertable read from timescaledb_information.dimensions
+type existingHypertable struct {
+	TimeColumnName string
+	// ChunkTimeInterval is the chunk interval of a time column, or the integer interval of an integer time column
+	ChunkTimeInterval string
+
+	// PartitioningColumn is the space dimension, empty when the hypertable is only partitioned by time
+	PartitioningColumn string
+	NumberPartitions   int
+}
 
+func BuildHypertableStatements(p *postgres.PostgresConnection, tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema) ([]string, error) {
 	currentHypertable, err := getHypertableForTable(p, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get hypertable for table")
 	}
 
-	if currentHypertable == nil && tableSchema.Hypertable == nil {
-		return []string{}, nil
+	return HypertableStatements(tableName, tableSchema, currentHypertable)
+}
+
+// HypertableStatements returns the statements to move the hypertable from the existing state to the schema.
+// The chunk interval and the number of space partitions can be changed, and a space dimension can be added.
+// Changes that timescaledb cannot make, such as changing the time column or converting a hypertable back
+// to a regular table, are returned as an error.
+func HypertableStatements(tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema, existing *existingHypertable) ([]string, error) {
+	hypertable := tableSchema.Hypertable
+	if hypertable != nil && hypertable.TimeColumnName == nil {
+		hypertable = nil
 	}
 
-	if currentHypertable == nil && tableSchema.Hypertable != nil {
-		// create hypertable
-		createStmt, err := createHypertableStatement(tableName, tableSchema.Hypertable, tableSchema.Columns)
+	if existing == nil {
+		if hypertable == nil {
+			return []string{}, nil
+		}
+
+		createStmt, err := createHypertableStatement(tableName, hypertable, tableSchema.Columns)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create hypertable statement")
 		}
 
-		hypertableStatements = append(hypertableStatements, createStmt)
+		return []string{createStmt}, nil
+	}
+
+	if hypertable == nil {
+		return nil, errors.Errorf("table %s is a hypertable, timescaledb cannot convert a hypertable back to a regular table", tableName)
+	}
 
-		return hypertableStatements, nil
+	if *hypertable.TimeColumnName != existing.TimeColumnName {
+		return nil, errors.Errorf("cannot change the time column of hypertable %s from %s to %s", tableName, existing.TimeColumnName, *hypertable.TimeColumnName)
 	}
 
-	// else we need to drop the hypertable
-	// but that's not possible, we need to migrate the table to a regular table
-	// This is not currently supported
+	tableLiteral := strings.ReplaceAll(pgx.Identifier{tableName}.Sanitize(), "\"", "'")
 
-	// else we need to modify the hypertable
-	// This is not currently supported
+	statements := []string{}
 
-	return hypertableStatements, nil
+	if hypertable.ChunkTimeInterval != nil && !intervalsEqual(*hypertable.ChunkTimeInterval, existing.ChunkTimeInterval) {
+		interval, err := intervalArgument(*hypertable.ChunkTimeInterval, isIntegerTimeColumn(hypertable, tableSchema.Columns))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid chunk time interval")
+		}
+
+		statements = append(statements, fmt.Sprintf(`select set_chunk_time_interval(%s, %s)`, tableLiteral, interval))
+	}
+
+	if hypertable.PartitioningColumn == nil {
+		if existing.PartitioningColumn != "" {
+			return nil, errors.Errorf("cannot remove the partitioning column %s from hypertable %s, timescaledb cannot drop a dimension", existing.PartitioningColumn, tableName)
+		}
+
+		return statements, nil
+	}
+
+	if hypertable.NumberPartitions == nil || *hypertable.NumberPartitions == 0 {
+		return nil, errors.New("must specify number partitions when specifying additional partitioning columns")
+	}
+
+	columnLiteral := strings.ReplaceAll(pgx.Identifier{*hypertable.PartitioningColumn}.Sanitize(), "\"", "'")
+
+	if existing.PartitioningColumn == "" {
+		if *hypertable.PartitioningColumn == existing.TimeColumnName {
+			return nil, errors.New("additional partitioning columns cannot be the same as the time column")
+		}
+		if !columnExists(*hypertable.PartitioningColumn, tableSchema.Columns) {
+			return nil, errors.New("additional partitioning column not defined in schema")
+		}
+
+		// timescaledb only adds a dimension to a hypertable that has no data
+		statements = append(statements, fmt.Sprintf(`select add_dimension(%s, %s, number_partitions => %d)`, tableLiteral, columnLiteral, *hypertable.NumberPartitions))
+		return statements, nil
+	}
+
+	if *hypertable.PartitioningColumn != existing.PartitioningColumn {
+		return nil, errors.Errorf("cannot change the partitioning column of hypertable %s from %s to %s", tableName, existing.PartitioningColumn, *hypertable.PartitioningColumn)
+	}
+
+	if *hypertable.NumberPartitions != existing.NumberPartitions {
+		statements = append(statements, fmt.Sprintf(`select set_number_partitions(%s, %d, %s)`, tableLiteral, *hypertable.NumberPartitions, columnLiteral))
+	}
+
+	return statements, nil
 }
 
-func getHypertableForTable(p *postgres.PostgresConnection, tableName string) (*schemasv1alpha4.TimescaleDBHypertable, error) {
+func getHypertableForTable(p *postgres.PostgresConnection, tableName string) (*existingHypertable, error) {
 	conn := p.GetConnection()
 
 	hypertableQuery := `
@@ -61,23 +130,50 @@ func getHypertableForTable(p *postgres.PostgresConnection, tableName string) (*s
 		return nil, errors.Wrap(err, "failed to query hypertable information")
 	}
 
-	timeColumnQuery := `SELECT column_name
+	dimensionsQuery := `SELECT column_name, dimension_type, time_interval::text, integer_interval, num_partitions
 FROM timescaledb_information.dimensions
 WHERE hypertable_name = $1
-AND dimension_type = 'Time'`
-	var timeColumnName string
-	err = conn.QueryRow(context.Background(), timeColumnQuery, tableName).Scan(&timeColumnName)
-	if err == pgx.ErrNoRows {
-		return nil, nil
-	} else if err != nil {
-		return nil, errors.Wrap(err, "failed to query time column name")
+ORDER BY dimension_number`
+	rows, err := conn.Query(context.Background(), dimensionsQuery, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query hypertable dimensions")
+	}
+	defer rows.Close()
+
+	hypertable := existingHypertable{}
+	for rows.Next() {
+		var columnName, dimensionType string
+		var timeInterval *string
+		var integerInterval *int64
+		var numPartitions *int16
+		if err := rows.Scan(&columnName, &dimensionType, &timeInterval, &integerInterval, &numPartitions); err != nil {
+			return nil, errors.Wrap(err, "failed to scan hypertable dimension")
+		}
+
+		switch dimensionType {
+		case "Time":
+			hypertable.TimeColumnName = columnName
+			if timeInterval != nil {
+				hypertable.ChunkTimeInterval = *timeInterval
+			} else if integerInterval != nil {
+				hypertable.ChunkTimeInterval = strconv.FormatInt(*integerInterval, 10)
+			}
+		case "Space":
+			hypertable.PartitioningColumn = columnName
+			if numPartitions != nil {
+				hypertable.NumberPartitions = int(*numPartitions)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read hypertable dimensions")
 	}
 
-	hypertable := &schemasv1alpha4.TimescaleDBHypertable{
-		TimeColumnName: &timeColumnName,
+	if hypertable.TimeColumnName == "" {
+		return nil, errors.Errorf("hypertable %s has no time dimension", tableName)
 	}
 
-	return hypertable, nil
+	return &hypertable, nil
 }
 
 func createHypertableStatement(tableName string, hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn) (string, error) {
@@ -0,0 +1,251 @@
+package timescaledb
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HypertableStatements(t *testing.T) {
+	otherColumnName := "created_at"
+	integerColumnName := "seq"
+	oneDay := "1 day"
+	oneMillion := "1000000"
+	twentyFourHours := "24 hours"
+	four := 4
+
+	columns := []*schemasv1alpha4.PostgresqlTableColumn{
+		{Name: timeColumnName, Type: "timestamptz"},
+		{Name: otherColumnName, Type: "timestamptz"},
+		{Name: locationHash, Type: "text"},
+		{Name: integerColumnName, Type: "BIGINT"},
+	}
+
+	tests := []struct {
+		name               string
+		hypertable         *schemasv1alpha4.TimescaleDBHypertable
+		existing           *existingHypertable
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name:               "not a hypertable",
+			expectedStatements: []string{},
+		},
+		{
+			name: "create hypertable",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName: &timeColumnName,
+			},
+			expectedStatements: []string{
+				`select create_hypertable('table1', 'time')`,
+			},
+		},
+		{
+			name: "unchanged",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &timeColumnName,
+				ChunkTimeInterval: &twentyFourHours,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    timeColumnName,
+				ChunkTimeInterval: "1 day",
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "chunk time interval changed",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &timeColumnName,
+				ChunkTimeInterval: &oneDay,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    timeColumnName,
+				ChunkTimeInterval: "7 days",
+			},
+			expectedStatements: []string{
+				`select set_chunk_time_interval('table1', INTERVAL '1 day')`,
+			},
+		},
+		{
+			name: "create hypertable with integer time column",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &integerColumnName,
+				ChunkTimeInterval: &oneMillion,
+			},
+			expectedStatements: []string{
+				`select create_hypertable('table1', 'seq', chunk_time_interval => 1000000)`,
+			},
+		},
+		{
+			name: "integer chunk time interval unchanged",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &integerColumnName,
+				ChunkTimeInterval: &oneMillion,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    integerColumnName,
+				ChunkTimeInterval: "1000000",
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "integer chunk time interval changed",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &integerColumnName,
+				ChunkTimeInterval: &oneMillion,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    integerColumnName,
+				ChunkTimeInterval: "86400",
+			},
+			expectedStatements: []string{
+				`select set_chunk_time_interval('table1', 1000000)`,
+			},
+		},
+		{
+			name: "time chunk interval on integer time column",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:    &integerColumnName,
+				ChunkTimeInterval: &oneDay,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    integerColumnName,
+				ChunkTimeInterval: "86400",
+			},
+			wantErr: `invalid chunk time interval: interval "1 day" of an integer time column must be an integer`,
+		},
+		{
+			name: "add space dimension",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:     &timeColumnName,
+				PartitioningColumn: &locationHash,
+				NumberPartitions:   &four,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    timeColumnName,
+				ChunkTimeInterval: "7 days",
+			},
+			expectedStatements: []string{
+				`select add_dimension('table1', 'location_hash', number_partitions => 4)`,
+			},
+		},
+		{
+			name: "number partitions changed",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName:     &timeColumnName,
+				PartitioningColumn: &locationHash,
+				NumberPartitions:   &four,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:     timeColumnName,
+				ChunkTimeInterval:  "7 days",
+				PartitioningColumn: locationHash,
+				NumberPartitions:   2,
+			},
+			expectedStatements: []string{
+				`select set_number_partitions('table1', 4, 'location_hash')`,
+			},
+		},
+		{
+			name: "time column changed",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName: &otherColumnName,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:    timeColumnName,
+				ChunkTimeInterval: "7 days",
+			},
+			wantErr: "cannot change the time column of hypertable table1 from time to created_at",
+		},
+		{
+			name: "hypertable removed",
+			existing: &existingHypertable{
+				TimeColumnName:    timeColumnName,
+				ChunkTimeInterval: "7 days",
+			},
+			wantErr: "table table1 is a hypertable, timescaledb cannot convert a hypertable back to a regular table",
+		},
+		{
+			name: "space dimension removed",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				TimeColumnName: &timeColumnName,
+			},
+			existing: &existingHypertable{
+				TimeColumnName:     timeColumnName,
+				ChunkTimeInterval:  "7 days",
+				PartitioningColumn: locationHash,
+				NumberPartitions:   2,
+			},
+			wantErr: "cannot remove the partitioning column location_hash from hypertable table1, timescaledb cannot drop a dimension",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			tableSchema := &schemasv1alpha4.TimescaleDBTableSchema{
+				Columns:    columns,
+				Hypertable: test.hypertable,
+			}
+
+			statements, err := HypertableStatements("table1", tableSchema, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
+
+func Test_intervalsEqual(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want bool
+	}{
+		{a: "7 days", b: "7 days", want: true},
+		{a: "1 week", b: "7 days", want: true},
+		{a: "24 hours", b: "1 day", want: true},
+		{a: "1 day 02:00:00", b: "26 hours", want: true},
+		{a: "01:00:00", b: "60 minutes", want: true},
+		{a: "1 day", b: "2 days", want: false},
+		{a: "86400000000", b: "86400000000", want: true},
+		{a: "86400", b: " 86400", want: true},
+		{a: "86400", b: "3600", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"="+test.b, func(t *testing.T) {
+			assert.Equal(t, test.want, intervalsEqual(test.a, test.b))
+		})
+	}
+}
+
+func Test_isValidInterval(t *testing.T) {
+	tests := []struct {
+		val     string
+		integer bool
+		want    bool
+	}{
+		{val: "7 days", want: true},
+		{val: "86400", want: true},
+		{val: "", want: false},
+		{val: "1 day'; drop table t; --", want: false},
+		{val: "86400", integer: true, want: true},
+		{val: "7 days", integer: true, want: false},
+		{val: "1.5", integer: true, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.val, func(t *testing.T) {
+			assert.Equal(t, test.want, isValidInterval(test.val, test.integer))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package timescaledb
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+var (
+	intervalPartRegexp  = regexp.MustCompile(`^([-+]?\d+(?:\.\d+)?)\s*([a-z]+)`)
+	intervalClockRegexp = regexp.MustCompile(`^([-+]?)(\d+):(\d+)(?::(\d+(?:\.\d+)?))?`)
+)
+
+// intervalUnits is the length of each postgres interval unit. Like postgres, a month is 30 days
+var intervalUnits = map[string]time.Duration{
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond, "us": time.Microsecond, "usec": time.Microsecond, "usecs": time.Microsecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond, "ms": time.Millisecond, "msec": time.Millisecond, "msecs": time.Millisecond,
+	"second": time.Second, "seconds": time.Second, "s": time.Second, "sec": time.Second, "secs": time.Second,
+	"minute": time.Minute, "minutes": time.Minute, "m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"hour": time.Hour, "hours": time.Hour, "h": time.Hour, "hr": time.Hour, "hrs": time.Hour,
+	"day": 24 * time.Hour, "days": 24 * time.Hour, "d": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour, "w": 7 * 24 * time.Hour,
+	"mon": 30 * 24 * time.Hour, "mons": 30 * 24 * time.Hour, "month": 30 * 24 * time.Hour, "months": 30 * 24 * time.Hour,
+	"year": 365 * 24 * time.Hour, "years": 365 * 24 * time.Hour, "y": 365 * 24 * time.Hour, "yr": 365 * 24 * time.Hour, "yrs": 365 * 24 * time.Hour,
+}
+
+// parseInterval returns the length of a postgres interval such as "7 days" or "1 day 02:00:00"
+func parseInterval(val string) (time.Duration, bool) {
+	remaining := strings.TrimSpace(strings.ToLower(val))
+	if remaining == "" {
+		return 0, false
+	}
+
+	var total time.Duration
+	for remaining != "" {
+		if match := intervalClockRegexp.FindStringSubmatch(remaining); match != nil {
+			hours, _ := strconv.Atoi(match[2])
+			minutes, _ := strconv.Atoi(match[3])
+			seconds := 0.0
+			if match[4] != "" {
+				seconds, _ = strconv.ParseFloat(match[4], 64)
+			}
+			clock := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+			if match[1] == "-" {
+				clock = -clock
+			}
+			total += clock
+			remaining = strings.TrimSpace(remaining[len(match[0]):])
+			continue
+		}
+
+		match := intervalPartRegexp.FindStringSubmatch(remaining)
+		if match == nil {
+			return 0, false
+		}
+		unit, ok := intervalUnits[match[2]]
+		if !ok {
+			return 0, false
+		}
+		quantity, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(quantity * float64(unit))
+		remaining = strings.TrimSpace(remaining[len(match[0]):])
+	}
+
+	return total, true
+}
+
+// integerTimeTypes are the column types that timescaledb partitions by integer intervals instead of time intervals
+var integerTimeTypes = map[string]bool{
+	"smallint": true, "int2": true,
+	"integer": true, "int": true, "int4": true,
+	"bigint": true, "int8": true,
+}
+
+// isIntegerTimeColumn returns true when the time column of the hypertable is an integer column. The chunk interval
+// and the policy intervals of the hypertable are then integers in the unit of the column
+func isIntegerTimeColumn(hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn) bool {
+	if hypertable == nil || hypertable.TimeColumnName == nil {
+		return false
+	}
+
+	for _, column := range columns {
+		if column.Name == *hypertable.TimeColumnName {
+			return integerTimeTypes[strings.ToLower(strings.TrimSpace(column.Type))]
+		}
+	}
+
+	return false
+}
+
+// parseIntegerInterval returns the value of the integer interval of an integer time column
+func parseIntegerInterval(val string) (int64, bool) {
+	i, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// intervalArgument returns the interval as the argument of a timescaledb function, an integer for an integer
+// time column and an interval otherwise
+func intervalArgument(val string, integer bool) (string, error) {
+	if !isValidInterval(val, integer) {
+		if integer {
+			return "", errors.Errorf("interval %q of an integer time column must be an integer", val)
+		}
+		return "", errors.Errorf("invalid interval %q", val)
+	}
+
+	if integer {
+		i, _ := parseIntegerInterval(val)
+		return strconv.FormatInt(i, 10), nil
+	}
+	return fmt.Sprintf("INTERVAL '%s'", val), nil
+}
+
+// intervalsEqual returns true when two intervals are the same length, such as "1 day" and "24 hours", or
+// the same integer interval of an integer time column. Other intervals that cannot be parsed are compared as text
+func intervalsEqual(a string, b string) bool {
+	aInteger, aIsInteger := parseIntegerInterval(a)
+	bInteger, bIsInteger := parseIntegerInterval(b)
+	if aIsInteger && bIsInteger {
+		return aInteger == bInteger
+	}
+
+	aDuration, aOK := parseInterval(a)
+	bDuration, bOK := parseInterval(b)
+	if aOK && bOK {
+		return aDuration == bDuration
+	}
+
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
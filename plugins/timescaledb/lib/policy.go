@@ -0,0 +1,299 @@
+package timescaledb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	postgres "github.com/schemahero/schemahero/plugins/postgres/lib"
+)
+
+// existingPolicies are the compression settings and background policies of a hypertable read from
+// timescaledb_information.jobs and timescaledb_information.compression_settings
+type existingPolicies struct {
+	CompressionEnabled bool
+	SegmentBy          []string
+	OrderBy            []string
+
+	// the interval of each policy, empty when the policy does not exist
+	CompressAfter string
+	DropAfter     string
+	MoveAfter     string
+
+	// ReorderIndex is the index of the reorder policy, empty when the policy does not exist
+	ReorderIndex string
+}
+
+// splitSetting returns the columns of a compress_segmentby or compress_orderby setting for comparison
+func splitSetting(setting string) []string {
+	columns := []string{}
+	for _, column := range strings.Split(setting, ",") {
+		column = strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(column, `"`, ""))), " ")
+		if column == "" {
+			continue
+		}
+		// ascending is the default order
+		column = strings.TrimSuffix(column, " asc")
+		column = strings.TrimSuffix(column, " nulls last")
+		column = strings.TrimSuffix(column, " asc")
+		if strings.HasSuffix(column, " desc nulls first") {
+			column = strings.TrimSuffix(column, " nulls first")
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func settingsEqual(a []string, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+func compressionSettingsStatement(tableName string, compression *schemasv1alpha4.TimescaleDBCompression, columns []*schemasv1alpha4.PostgresqlTableColumn) (string, error) {
+	settings := []string{"timescaledb.compress"}
+
+	if compression.SegmentBy != nil {
+		if !columnExists(*compression.SegmentBy, columns) {
+			return "", errors.New("compression column not found")
+		}
+
+		settings = append(settings, fmt.Sprintf("timescaledb.compress_segmentby = %s", pgx.Identifier{*compression.SegmentBy}.Sanitize()))
+	}
+
+	if compression.OrderBy != nil {
+		for _, column := range splitSetting(*compression.OrderBy) {
+			if !columnExists(strings.Fields(column)[0], columns) {
+				return "", errors.Errorf("compression order by column %s not found", strings.Fields(column)[0])
+			}
+		}
+
+		settings = append(settings, fmt.Sprintf("timescaledb.compress_orderby = '%s'", strings.ReplaceAll(*compression.OrderBy, "'", "''")))
+	}
+
+	return fmt.Sprintf(`alter table %s set (%s)`, pgx.Identifier{tableName}.Sanitize(), strings.Join(settings, ", ")), nil
+}
+
+// intervalPolicyStatements returns the statements to move a policy with an interval from the existing interval to the
+// desired interval. An empty interval is no policy. timescaledb cannot alter the interval of a policy, so a changed
+// policy is removed and added again. The intervals of a hypertable with an integer time column are integers.
+func intervalPolicyStatements(policy string, tableLiteral string, desired string, existing string, integer bool) ([]string, error) {
+	if desired == existing || (desired != "" && existing != "" && intervalsEqual(desired, existing)) {
+		return []string{}, nil
+	}
+
+	statements := []string{}
+	if existing != "" {
+		statements = append(statements, fmt.Sprintf(`select remove_%s_policy(%s, if_exists => true)`, policy, tableLiteral))
+	}
+	if desired != "" {
+		interval, err := intervalArgument(desired, integer)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, fmt.Sprintf(`select add_%s_policy(%s, %s)`, policy, tableLiteral, interval))
+	}
+
+	return statements, nil
+}
+
+// PolicyStatements returns the statements to move the compression settings and the compression, retention,
+// reorder and tiering policies of a hypertable from the existing state to the schema. When existing is nil,
+// the hypertable is new and has no settings or policies.
+func PolicyStatements(tableName string, hypertable *schemasv1alpha4.TimescaleDBHypertable, columns []*schemasv1alpha4.PostgresqlTableColumn, existing *existingPolicies) ([]string, error) {
+	if existing == nil {
+		existing = &existingPolicies{}
+	}
+	if hypertable == nil {
+		hypertable = &schemasv1alpha4.TimescaleDBHypertable{}
+	}
+
+	tableLiteral := strings.ReplaceAll(pgx.Identifier{tableName}.Sanitize(), "\"", "'")
+	integer := isIntegerTimeColumn(hypertable, columns)
+
+	statements := []string{}
+
+	// compression
+	compressAfter := ""
+	if hypertable.Compression != nil && hypertable.Compression.Interval != nil {
+		compressAfter = *hypertable.Compression.Interval
+	}
+	compressionPolicyStatements, err := intervalPolicyStatements("compression", tableLiteral, compressAfter, existing.CompressAfter, integer)
+	if err != nil {
+		return nil, errors.Wrap(err, "compression policy")
+	}
+
+	if hypertable.Compression == nil {
+		statements = append(statements, compressionPolicyStatements...)
+		if existing.CompressionEnabled {
+			statements = append(statements, fmt.Sprintf(`alter table %s set (timescaledb.compress = false)`, pgx.Identifier{tableName}.Sanitize()))
+		}
+	} else {
+		settingsChanged := !existing.CompressionEnabled
+		if hypertable.Compression.SegmentBy != nil && !settingsEqual(splitSetting(*hypertable.Compression.SegmentBy), existing.SegmentBy) {
+			settingsChanged = true
+		}
+		if hypertable.Compression.OrderBy != nil && !settingsEqual(splitSetting(*hypertable.Compression.OrderBy), existing.OrderBy) {
+			settingsChanged = true
+		}
+
+		if settingsChanged {
+			stmt, err := compressionSettingsStatement(tableName, hypertable.Compression, columns)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, stmt)
+		}
+		statements = append(statements, compressionPolicyStatements...)
+	}
+
+	// retention
+	dropAfter := ""
+	if hypertable.Retention != nil {
+		dropAfter = hypertable.Retention.Interval
+	}
+	retentionStatements, err := intervalPolicyStatements("retention", tableLiteral, dropAfter, existing.DropAfter, integer)
+	if err != nil {
+		return nil, errors.Wrap(err, "retention policy")
+	}
+	statements = append(statements, retentionStatements...)
+
+	// reorder
+	reorderIndex := ""
+	if hypertable.Reorder != nil {
+		reorderIndex = hypertable.Reorder.Index
+	}
+	if reorderIndex != existing.ReorderIndex {
+		if existing.ReorderIndex != "" {
+			statements = append(statements, fmt.Sprintf(`select remove_reorder_policy(%s, if_exists => true)`, tableLiteral))
+		}
+		if reorderIndex != "" {
+			statements = append(statements, fmt.Sprintf(`select add_reorder_policy(%s, %s)`, tableLiteral, strings.ReplaceAll(pgx.Identifier{reorderIndex}.Sanitize(), "\"", "'")))
+		}
+	}
+
+	// tiering
+	moveAfter := ""
+	if hypertable.Tiering != nil {
+		moveAfter = hypertable.Tiering.Interval
+	}
+	tieringStatements, err := intervalPolicyStatements("tiering", tableLiteral, moveAfter, existing.MoveAfter, integer)
+	if err != nil {
+		return nil, errors.Wrap(err, "tiering policy")
+	}
+	statements = append(statements, tieringStatements...)
+
+	return statements, nil
+}
+
+func BuildPolicyStatements(p *postgres.PostgresConnection, tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema) ([]string, error) {
+	existing, err := getPoliciesForTable(p, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get policies for table")
+	}
+
+	return PolicyStatements(tableName, tableSchema.Hypertable, tableSchema.Columns, existing)
+}
+
+// jobConfigString returns a value from the config of a job, intervals are strings and integer intervals are numbers
+func jobConfigString(config map[string]interface{}, key string) string {
+	switch value := config[key].(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%d", int64(value))
+	}
+	return ""
+}
+
+func getPoliciesForTable(p *postgres.PostgresConnection, tableName string) (*existingPolicies, error) {
+	conn := p.GetConnection()
+
+	policies := existingPolicies{}
+
+	compressionQuery := `SELECT compression_enabled FROM timescaledb_information.hypertables WHERE hypertable_name = $1`
+	err := conn.QueryRow(context.Background(), compressionQuery, tableName).Scan(&policies.CompressionEnabled)
+	if err == pgx.ErrNoRows {
+		// not a hypertable yet, so there are no settings or policies
+		return &policies, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to query compression enabled")
+	}
+
+	settingsQuery := `SELECT attname, segmentby_column_index, orderby_column_index, orderby_asc, orderby_nullsfirst
+FROM timescaledb_information.compression_settings
+WHERE hypertable_name = $1
+ORDER BY segmentby_column_index, orderby_column_index`
+	rows, err := conn.Query(context.Background(), settingsQuery, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query compression settings")
+	}
+	for rows.Next() {
+		var attname string
+		var segmentByIndex, orderByIndex *int16
+		var orderByAsc, orderByNullsFirst *bool
+		if err := rows.Scan(&attname, &segmentByIndex, &orderByIndex, &orderByAsc, &orderByNullsFirst); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "failed to scan compression settings")
+		}
+
+		if segmentByIndex != nil {
+			policies.SegmentBy = append(policies.SegmentBy, strings.ToLower(attname))
+		}
+		if orderByIndex != nil {
+			column := strings.ToLower(attname)
+			if orderByAsc != nil && !*orderByAsc {
+				column = fmt.Sprintf("%s desc", column)
+				if orderByNullsFirst != nil && !*orderByNullsFirst {
+					column = fmt.Sprintf("%s nulls last", column)
+				}
+			} else if orderByNullsFirst != nil && *orderByNullsFirst {
+				column = fmt.Sprintf("%s nulls first", column)
+			}
+			policies.OrderBy = append(policies.OrderBy, column)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read compression settings")
+	}
+
+	jobsQuery := `SELECT proc_name, config::text FROM timescaledb_information.jobs WHERE hypertable_name = $1`
+	rows, err = conn.Query(context.Background(), jobsQuery, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query jobs")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var procName string
+		var configJSON *string
+		if err := rows.Scan(&procName, &configJSON); err != nil {
+			return nil, errors.Wrap(err, "failed to scan job")
+		}
+
+		config := map[string]interface{}{}
+		if configJSON != nil {
+			if err := json.Unmarshal([]byte(*configJSON), &config); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse config of job %s", procName)
+			}
+		}
+
+		switch procName {
+		case "policy_compression":
+			policies.CompressAfter = jobConfigString(config, "compress_after")
+		case "policy_retention":
+			policies.DropAfter = jobConfigString(config, "drop_after")
+		case "policy_reorder":
+			policies.ReorderIndex = jobConfigString(config, "index_name")
+		case "policy_tiering":
+			policies.MoveAfter = jobConfigString(config, "move_after")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read jobs")
+	}
+
+	return &policies, nil
+}
@@ -0,0 +1,179 @@
+package timescaledb
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PolicyStatements(t *testing.T) {
+	oneDay := "1 day"
+	timeDesc := "time DESC"
+	locationDesc := "location_hash desc"
+
+	columns := []*schemasv1alpha4.PostgresqlTableColumn{
+		{Name: timeColumnName, Type: "timestamptz"},
+		{Name: locationHash, Type: "text"},
+	}
+
+	tests := []struct {
+		name               string
+		hypertable         *schemasv1alpha4.TimescaleDBHypertable
+		existing           *existingPolicies
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "new hypertable",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				Compression: &schemasv1alpha4.TimescaleDBCompression{
+					SegmentBy: &locationHash,
+					OrderBy:   &timeDesc,
+					Interval:  &interval,
+				},
+				Retention: &schemasv1alpha4.TimescaleDBRetention{
+					Interval: "90 days",
+				},
+				Reorder: &schemasv1alpha4.TimescaleDBReorder{
+					Index: "table1_time_idx",
+				},
+			},
+			expectedStatements: []string{
+				`alter table "table1" set (timescaledb.compress, timescaledb.compress_segmentby = "location_hash", timescaledb.compress_orderby = 'time DESC')`,
+				`select add_compression_policy('table1', INTERVAL '7 days')`,
+				`select add_retention_policy('table1', INTERVAL '90 days')`,
+				`select add_reorder_policy('table1', 'table1_time_idx')`,
+			},
+		},
+		{
+			name: "unchanged",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				Compression: &schemasv1alpha4.TimescaleDBCompression{
+					SegmentBy: &locationHash,
+					OrderBy:   &timeDesc,
+					Interval:  &interval,
+				},
+				Retention: &schemasv1alpha4.TimescaleDBRetention{
+					Interval: "1 week",
+				},
+			},
+			existing: &existingPolicies{
+				CompressionEnabled: true,
+				SegmentBy:          []string{locationHash},
+				OrderBy:            []string{"time desc"},
+				CompressAfter:      "7 days",
+				DropAfter:          "7 days",
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "changed intervals and order by",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				Compression: &schemasv1alpha4.TimescaleDBCompression{
+					SegmentBy: &locationHash,
+					OrderBy:   &locationDesc,
+					Interval:  &oneDay,
+				},
+				Retention: &schemasv1alpha4.TimescaleDBRetention{
+					Interval: "30 days",
+				},
+				Tiering: &schemasv1alpha4.TimescaleDBTiering{
+					Interval: "14 days",
+				},
+			},
+			existing: &existingPolicies{
+				CompressionEnabled: true,
+				SegmentBy:          []string{locationHash},
+				OrderBy:            []string{"time desc"},
+				CompressAfter:      "7 days",
+				DropAfter:          "90 days",
+			},
+			expectedStatements: []string{
+				`alter table "table1" set (timescaledb.compress, timescaledb.compress_segmentby = "location_hash", timescaledb.compress_orderby = 'location_hash desc')`,
+				`select remove_compression_policy('table1', if_exists => true)`,
+				`select add_compression_policy('table1', INTERVAL '1 day')`,
+				`select remove_retention_policy('table1', if_exists => true)`,
+				`select add_retention_policy('table1', INTERVAL '30 days')`,
+				`select add_tiering_policy('table1', INTERVAL '14 days')`,
+			},
+		},
+		{
+			name:       "removed policies",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{},
+			existing: &existingPolicies{
+				CompressionEnabled: true,
+				SegmentBy:          []string{locationHash},
+				CompressAfter:      "7 days",
+				DropAfter:          "90 days",
+				ReorderIndex:       "table1_time_idx",
+			},
+			expectedStatements: []string{
+				`select remove_compression_policy('table1', if_exists => true)`,
+				`alter table "table1" set (timescaledb.compress = false)`,
+				`select remove_retention_policy('table1', if_exists => true)`,
+				`select remove_reorder_policy('table1', if_exists => true)`,
+			},
+		},
+		{
+			name: "order by column not found",
+			hypertable: &schemasv1alpha4.TimescaleDBHypertable{
+				Compression: &schemasv1alpha4.TimescaleDBCompression{
+					OrderBy: &idColumnName,
+				},
+			},
+			wantErr: "compression order by column id not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := PolicyStatements("table1", test.hypertable, columns, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
+
+func Test_PolicyStatementsIntegerTime(t *testing.T) {
+	integerColumnName := "seq"
+	compressAfter := "100000"
+
+	columns := []*schemasv1alpha4.PostgresqlTableColumn{
+		{Name: integerColumnName, Type: "bigint"},
+		{Name: locationHash, Type: "text"},
+	}
+
+	hypertable := &schemasv1alpha4.TimescaleDBHypertable{
+		TimeColumnName: &integerColumnName,
+		Compression: &schemasv1alpha4.TimescaleDBCompression{
+			Interval: &compressAfter,
+		},
+		Retention: &schemasv1alpha4.TimescaleDBRetention{
+			Interval: "1000000",
+		},
+	}
+
+	statements, err := PolicyStatements("table1", hypertable, columns, &existingPolicies{
+		CompressionEnabled: true,
+		CompressAfter:      "100000",
+		DropAfter:          "500000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`select remove_retention_policy('table1', if_exists => true)`,
+		`select add_retention_policy('table1', 1000000)`,
+	}, statements)
+
+	hypertable.Retention.Interval = "30 days"
+	_, err = PolicyStatements("table1", hypertable, columns, nil)
+	assert.EqualError(t, err, `retention policy: interval "30 days" of an integer time column must be an integer`)
+}
@@ -0,0 +1,225 @@
+package timescaledb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	postgres "github.com/schemahero/schemahero/plugins/postgres/lib"
+)
+
+// queryCommentPrefix starts the comment that records the query a continuous aggregate was created with.
+// timescaledb rewrites the query of a view, so the definition in the database cannot be compared to the schema
+const queryCommentPrefix = "schemahero:query-sha256="
+
+// existingContinuousAggregate is a continuous aggregate read from timescaledb_information.continuous_aggregates
+type existingContinuousAggregate struct {
+	// QuerySHA is the hash of the query recorded when schemahero created the view, empty when it is not known
+	QuerySHA           string
+	MaterializedOnly   bool
+	CompressionEnabled bool
+
+	// RefreshPolicy is nil when the view has no refresh policy
+	RefreshPolicy *schemasv1alpha4.TimescaleDBRefreshPolicy
+	// CompressAfter is the interval of the compression policy, empty when the policy does not exist
+	CompressAfter string
+}
+
+func isContinuousAggregateSchema(viewSchema *schemasv1alpha4.TimescaleDBViewSchema) bool {
+	return viewSchema.IsContinuousAggregate != nil && *viewSchema.IsContinuousAggregate
+}
+
+// querySHA returns the hash of a query, ignoring differences in whitespace
+func querySHA(query string) string {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	normalized := strings.Join(strings.Fields(query), " ")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(normalized)))
+}
+
+func queryCommentStatement(viewName string, query string) string {
+	return fmt.Sprintf(`comment on view %s is '%s%s'`, pgx.Identifier{viewName}.Sanitize(), queryCommentPrefix, querySHA(query))
+}
+
+func offsetParam(name string, offset *string) string {
+	if offset == nil {
+		return fmt.Sprintf("%s => NULL", name)
+	}
+	return fmt.Sprintf("%s => INTERVAL '%s'", name, *offset)
+}
+
+func offsetsEqual(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return intervalsEqual(*a, *b)
+}
+
+func refreshPolicyChanged(desired *schemasv1alpha4.TimescaleDBRefreshPolicy, existing *schemasv1alpha4.TimescaleDBRefreshPolicy) bool {
+	if desired == nil || existing == nil {
+		return desired != existing
+	}
+	return !offsetsEqual(desired.StartOffset, existing.StartOffset) ||
+		!offsetsEqual(desired.EndOffset, existing.EndOffset) ||
+		!intervalsEqual(desired.ScheduleInterval, existing.ScheduleInterval)
+}
+
+// continuousAggregateSettingsStatements returns the statements to move the settings and policies of a continuous
+// aggregate from the existing state to the schema. When existing is nil, the view has just been created.
+func continuousAggregateSettingsStatements(viewName string, viewSchema *schemasv1alpha4.TimescaleDBViewSchema, existing *existingContinuousAggregate) ([]string, error) {
+	isNew := existing == nil
+	if isNew {
+		existing = &existingContinuousAggregate{}
+	}
+
+	viewLiteral := strings.ReplaceAll(pgx.Identifier{viewName}.Sanitize(), "\"", "'")
+
+	statements := []string{}
+
+	// materialized only is set when the view is created
+	if !isNew && viewSchema.MaterializedOnly != nil && *viewSchema.MaterializedOnly != existing.MaterializedOnly {
+		statements = append(statements, fmt.Sprintf(`alter materialized view %s set (timescaledb.materialized_only = %t)`, pgx.Identifier{viewName}.Sanitize(), *viewSchema.MaterializedOnly))
+	}
+
+	if refreshPolicyChanged(viewSchema.RefreshPolicy, existing.RefreshPolicy) {
+		if existing.RefreshPolicy != nil {
+			statements = append(statements, fmt.Sprintf(`select remove_continuous_aggregate_policy(%s, if_exists => true)`, viewLiteral))
+		}
+		if viewSchema.RefreshPolicy != nil {
+			if viewSchema.RefreshPolicy.ScheduleInterval == "" {
+				return nil, errors.Errorf("refresh policy of continuous aggregate %s requires a schedule interval", viewName)
+			}
+			statements = append(statements, fmt.Sprintf(`select add_continuous_aggregate_policy(%s, %s, %s, schedule_interval => INTERVAL '%s')`,
+				viewLiteral,
+				offsetParam("start_offset", viewSchema.RefreshPolicy.StartOffset),
+				offsetParam("end_offset", viewSchema.RefreshPolicy.EndOffset),
+				viewSchema.RefreshPolicy.ScheduleInterval))
+		}
+	}
+
+	compressAfter := ""
+	if viewSchema.Compression != nil && viewSchema.Compression.Interval != nil {
+		compressAfter = *viewSchema.Compression.Interval
+	}
+	// the view schema does not describe the type of the time bucket, so its intervals are time intervals
+	compressionPolicyStatements, err := intervalPolicyStatements("compression", viewLiteral, compressAfter, existing.CompressAfter, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "compression policy")
+	}
+
+	if viewSchema.Compression != nil {
+		if !existing.CompressionEnabled {
+			statements = append(statements, fmt.Sprintf(`alter materialized view %s set (timescaledb.compress = true)`, pgx.Identifier{viewName}.Sanitize()))
+		}
+		statements = append(statements, compressionPolicyStatements...)
+	} else {
+		statements = append(statements, compressionPolicyStatements...)
+		if existing.CompressionEnabled {
+			statements = append(statements, fmt.Sprintf(`alter materialized view %s set (timescaledb.compress = false)`, pgx.Identifier{viewName}.Sanitize()))
+		}
+	}
+
+	return statements, nil
+}
+
+// ContinuousAggregateStatements returns the statements to move a continuous aggregate from the existing state to
+// the schema. A continuous aggregate cannot be altered to a new query, so when the query changes the view is
+// dropped and created again, and its data is materialized again. A view that was not created with a recorded
+// query is assumed to match the schema, and the query of the schema is recorded.
+func ContinuousAggregateStatements(viewName string, viewSchema *schemasv1alpha4.TimescaleDBViewSchema, existing *existingContinuousAggregate) ([]string, error) {
+	if existing == nil {
+		return CreateViewStatements(viewName, viewSchema)
+	}
+
+	if existing.QuerySHA != "" && existing.QuerySHA != querySHA(viewSchema.Query) {
+		createStatements, err := CreateViewStatements(viewName, viewSchema)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create view statement")
+		}
+
+		statements := []string{
+			fmt.Sprintf(`drop materialized view %s`, pgx.Identifier{viewName}.Sanitize()),
+		}
+		return append(statements, createStatements...), nil
+	}
+
+	statements := []string{}
+	if existing.QuerySHA == "" {
+		statements = append(statements, queryCommentStatement(viewName, viewSchema.Query))
+	}
+
+	settingsStatements, err := continuousAggregateSettingsStatements(viewName, viewSchema, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(statements, settingsStatements...), nil
+}
+
+func getContinuousAggregate(p *postgres.PostgresConnection, viewName string) (*existingContinuousAggregate, error) {
+	conn := p.GetConnection()
+
+	existing := existingContinuousAggregate{}
+
+	query := `SELECT materialized_only, compression_enabled, materialization_hypertable_name,
+	coalesce(obj_description(format('%I.%I', view_schema, view_name)::regclass, 'pg_class'), '')
+FROM timescaledb_information.continuous_aggregates
+WHERE view_name = $1`
+	var materializationHypertableName, comment string
+	err := conn.QueryRow(context.Background(), query, viewName).Scan(&existing.MaterializedOnly, &existing.CompressionEnabled, &materializationHypertableName, &comment)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to query continuous aggregate")
+	}
+
+	if strings.HasPrefix(comment, queryCommentPrefix) {
+		existing.QuerySHA = strings.TrimPrefix(comment, queryCommentPrefix)
+	}
+
+	jobsQuery := `SELECT proc_name, config::text, schedule_interval::text FROM timescaledb_information.jobs WHERE hypertable_name = $1`
+	rows, err := conn.Query(context.Background(), jobsQuery, materializationHypertableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query jobs")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var procName, scheduleInterval string
+		var configJSON *string
+		if err := rows.Scan(&procName, &configJSON, &scheduleInterval); err != nil {
+			return nil, errors.Wrap(err, "failed to scan job")
+		}
+
+		config := map[string]interface{}{}
+		if configJSON != nil {
+			if err := json.Unmarshal([]byte(*configJSON), &config); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse config of job %s", procName)
+			}
+		}
+
+		switch procName {
+		case "policy_refresh_continuous_aggregate":
+			refreshPolicy := schemasv1alpha4.TimescaleDBRefreshPolicy{
+				ScheduleInterval: scheduleInterval,
+			}
+			if startOffset := jobConfigString(config, "start_offset"); startOffset != "" {
+				refreshPolicy.StartOffset = &startOffset
+			}
+			if endOffset := jobConfigString(config, "end_offset"); endOffset != "" {
+				refreshPolicy.EndOffset = &endOffset
+			}
+			existing.RefreshPolicy = &refreshPolicy
+		case "policy_compression":
+			existing.CompressAfter = jobConfigString(config, "compress_after")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read jobs")
+	}
+
+	return &existing, nil
+}
@@ -0,0 +1,151 @@
+package timescaledb
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ContinuousAggregateStatements(t *testing.T) {
+	query := `select time_bucket('1 day', time) as day, count(*) from events group by day`
+	oneMonth := "1 month"
+	oneHour := "1 hour"
+	falseVar := false
+
+	querySHAComment := `comment on view "daily" is 'schemahero:query-sha256=` + querySHA(query) + `'`
+
+	tests := []struct {
+		name               string
+		viewSchema         *schemasv1alpha4.TimescaleDBViewSchema
+		existing           *existingContinuousAggregate
+		expectedStatements []string
+		wantErr            string
+	}{
+		{
+			name: "create with policies",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				WithNoData:            &trueVar,
+				MaterializedOnly:      &falseVar,
+				Query:                 query,
+				RefreshPolicy: &schemasv1alpha4.TimescaleDBRefreshPolicy{
+					StartOffset:      &oneMonth,
+					EndOffset:        &oneHour,
+					ScheduleInterval: "1 hour",
+				},
+				Compression: &schemasv1alpha4.TimescaleDBContinuousAggregateCompression{
+					Interval: &interval,
+				},
+			},
+			expectedStatements: []string{
+				`create materialized view "daily" with (timescaledb.continuous, timescaledb.materialized_only = false) as ` + query + ` with no data`,
+				querySHAComment,
+				`select add_continuous_aggregate_policy('daily', start_offset => INTERVAL '1 month', end_offset => INTERVAL '1 hour', schedule_interval => INTERVAL '1 hour')`,
+				`alter materialized view "daily" set (timescaledb.compress = true)`,
+				`select add_compression_policy('daily', INTERVAL '7 days')`,
+			},
+		},
+		{
+			name: "unchanged",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				Query:                 "  " + query + ";\n",
+				RefreshPolicy: &schemasv1alpha4.TimescaleDBRefreshPolicy{
+					EndOffset:        &oneHour,
+					ScheduleInterval: "60 minutes",
+				},
+			},
+			existing: &existingContinuousAggregate{
+				QuerySHA: querySHA(query),
+				RefreshPolicy: &schemasv1alpha4.TimescaleDBRefreshPolicy{
+					EndOffset:        &oneHour,
+					ScheduleInterval: "01:00:00",
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "settings changed",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				MaterializedOnly:      &trueVar,
+				Query:                 query,
+				RefreshPolicy: &schemasv1alpha4.TimescaleDBRefreshPolicy{
+					StartOffset:      &oneMonth,
+					ScheduleInterval: "1 hour",
+				},
+			},
+			existing: &existingContinuousAggregate{
+				QuerySHA:           querySHA(query),
+				CompressionEnabled: true,
+				CompressAfter:      "7 days",
+				RefreshPolicy: &schemasv1alpha4.TimescaleDBRefreshPolicy{
+					EndOffset:        &oneHour,
+					ScheduleInterval: "01:00:00",
+				},
+			},
+			expectedStatements: []string{
+				`alter materialized view "daily" set (timescaledb.materialized_only = true)`,
+				`select remove_continuous_aggregate_policy('daily', if_exists => true)`,
+				`select add_continuous_aggregate_policy('daily', start_offset => INTERVAL '1 month', end_offset => NULL, schedule_interval => INTERVAL '1 hour')`,
+				`select remove_compression_policy('daily', if_exists => true)`,
+				`alter materialized view "daily" set (timescaledb.compress = false)`,
+			},
+		},
+		{
+			name: "query changed",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				Query:                 query,
+			},
+			existing: &existingContinuousAggregate{
+				QuerySHA: querySHA("select 1"),
+			},
+			expectedStatements: []string{
+				`drop materialized view "daily"`,
+				`create materialized view "daily" with (timescaledb.continuous) as ` + query + ` with data`,
+				querySHAComment,
+			},
+		},
+		{
+			name: "query not recorded",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				Query:                 query,
+			},
+			existing: &existingContinuousAggregate{},
+			expectedStatements: []string{
+				querySHAComment,
+			},
+		},
+		{
+			name: "refresh policy without schedule",
+			viewSchema: &schemasv1alpha4.TimescaleDBViewSchema{
+				IsContinuousAggregate: &trueVar,
+				Query:                 query,
+				RefreshPolicy:         &schemasv1alpha4.TimescaleDBRefreshPolicy{},
+			},
+			existing: &existingContinuousAggregate{
+				QuerySHA: querySHA(query),
+			},
+			wantErr: "refresh policy of continuous aggregate daily requires a schedule interval",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := ContinuousAggregateStatements("daily", test.viewSchema, test.existing)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}